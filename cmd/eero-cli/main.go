@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"time"
 
 	"github.com/dorin/eero-cli/internal/cmd"
 )
@@ -13,63 +16,125 @@ var Version = "dev"
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
 
 func run() error {
-	args := os.Args[1:]
+	opts, args, err := cmd.ParseGlobalFlags(os.Args[1:])
+	if err != nil {
+		return err
+	}
 
 	if len(args) == 0 {
 		cmd.Usage()
 		return nil
 	}
 
-	app, err := cmd.NewApp()
+	// Ctrl+C cancels API requests, waits, and prompts so the command stops
+	// cleanly. Once it has, a second Ctrl+C exits at once.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	opts.Context = ctx
+
+	app, err := cmd.NewApp(opts)
 	if err != nil {
 		return err
 	}
+	if opts.Stats {
+		defer app.PrintStats()
+	}
 
 	command := args[0]
 	subArgs := args[1:]
+	app.Command = command
 
+	// Commands that don't use the saved login
 	switch command {
 	case "help", "-h", "--help":
 		cmd.Usage()
 		return nil
 
+	case "completion":
+		return cmd.Completion(subArgs)
+
 	case "version", "-v", "--version":
 		fmt.Printf("eero-cli %s\n", Version)
 		return nil
 
 	case "login":
-		return app.Login()
+		return app.Login(subArgs)
 
 	case "logout":
 		return app.Logout()
 
+	case "account":
+		return app.Account(subArgs)
+	}
+
+	var run func() error
+	switch command {
 	case "status":
-		return app.Status()
+		run = app.Status
+
+	case "dashboard":
+		run = app.Dashboard
+
+	case "networks", "network":
+		run = func() error { return app.Networks(subArgs) }
 
 	case "devices":
-		return app.Devices(subArgs)
+		run = func() error { return app.Devices(subArgs) }
 
 	case "profiles":
-		return app.Profiles(subArgs)
+		run = func() error { return app.Profiles(subArgs) }
 
 	case "eeros":
-		return app.Eeros(subArgs)
+		run = func() error { return app.Eeros(subArgs) }
 
 	case "guest":
-		return app.Guest(subArgs)
+		run = func() error { return app.Guest(subArgs) }
 
 	case "reservations":
-		return app.Reservations(subArgs)
+		run = func() error { return app.Reservations(subArgs) }
+
+	case "forwards":
+		run = func() error { return app.Forwards(subArgs) }
+
+	case "internet":
+		run = func() error { return app.Internet(subArgs) }
+
+	case "dhcp":
+		run = func() error { return app.DHCP(subArgs) }
+
+	case "radio":
+		run = func() error { return app.Radio(subArgs) }
+
+	case "wifi":
+		run = func() error { return app.WiFi(subArgs) }
 
 	case "reboot":
-		return app.Reboot()
+		run = func() error { return app.Reboot(subArgs) }
+
+	case "update":
+		run = func() error { return app.Update(subArgs) }
+
+	case "speedtest":
+		run = func() error { return app.Speedtest(subArgs) }
+
+	case "export":
+		run = func() error { return app.Export(subArgs) }
 
 	default:
 		return fmt.Errorf("unknown command: %s\nRun 'eero-cli help' for usage", command)
 	}
+
+	// Temporary blocks expire on the first API command run after their
+	// deadline
+	app.ProcessScheduledUnblocks(time.Now())
+	return run()
 }
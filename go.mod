@@ -1,3 +1,15 @@
 module github.com/dorin/eero-cli
 
 go 1.25.4
+
+require (
+	github.com/zalando/go-keyring v0.2.8
+	golang.org/x/term v0.45.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/danieljoos/wincred v1.2.3 // indirect
+	github.com/godbus/dbus/v5 v5.2.2 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
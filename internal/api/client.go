@@ -3,59 +3,277 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// DefaultBaseURL is eero's API, used unless ClientOptions.BaseURL is set
+const DefaultBaseURL = "https://api-user.e2ro.com"
+
+const userAgent = "eero-ios/2.16.0 (iPhone8,1; iOS 11.3)"
+
+// Built-in defaults for ClientOptions
 const (
-	baseURL   = "https://api-user.e2ro.com"
-	userAgent = "eero-ios/2.16.0 (iPhone8,1; iOS 11.3)"
+	DefaultTimeout       = 30 * time.Second
+	DefaultRetries       = 2
+	DefaultRetryDelay    = 200 * time.Millisecond
+	DefaultRateLimitWait = 30 * time.Second
 )
 
+// NoRetries disables retries when used as ClientOptions.Retries
+const NoRetries = -1
+
+// ClientOptions tunes request behavior. Zero values use the built-in defaults.
+type ClientOptions struct {
+	// Timeout bounds each HTTP request
+	Timeout time.Duration
+	// Retries is the number of extra attempts after a dial or timeout error,
+	// a 502/503/504, or a 429. Use NoRetries to disable them.
+	Retries int
+	// RetryDelay is the base delay between retries, doubled on each attempt
+	RetryDelay time.Duration
+	// RateLimitWait caps how long to honor a 429 Retry-After header
+	RateLimitWait time.Duration
+	// PinSHA256, when set, is the hex SHA-256 hash (see ParsePin) the API
+	// server's certificate public key must match
+	PinSHA256 string
+	// BaseURL, when set, replaces the eero API URL, e.g. to send requests
+	// through a debugging proxy
+	BaseURL string
+}
+
+// withDefaults fills zero values with the built-in defaults
+func (o ClientOptions) withDefaults() ClientOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	switch {
+	case o.Retries == 0:
+		o.Retries = DefaultRetries
+	case o.Retries < 0:
+		o.Retries = 0
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = DefaultRetryDelay
+	}
+	if o.RateLimitWait <= 0 {
+		o.RateLimitWait = DefaultRateLimitWait
+	}
+	return o
+}
+
 // Client is the Eero API client
 type Client struct {
 	token      string
 	baseURL    string
 	httpClient *http.Client
+	opts       ClientOptions
+	logger     *slog.Logger
+	stats      *requestStats
+	// ctx is the parent of this client's requests; see WithContext
+	ctx context.Context
 }
 
-// New creates a new Eero API client
+// New creates a new Eero API client with the default options
 func New(token string) *Client {
+	return NewWithOptions(token, ClientOptions{})
+}
+
+// NewWithOptions creates a new Eero API client with the given options
+func NewWithOptions(token string, opts ClientOptions) *Client {
+	opts = opts.withDefaults()
+	httpClient := &http.Client{
+		Timeout: opts.Timeout,
+	}
+	if opts.PinSHA256 != "" {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{VerifyConnection: verifyPin(opts.PinSHA256)}
+		httpClient.Transport = transport
+	}
+	base := DefaultBaseURL
+	if opts.BaseURL != "" {
+		base = strings.TrimSuffix(opts.BaseURL, "/")
+	}
 	return &Client{
-		token:   token,
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		token:      token,
+		baseURL:    base,
+		httpClient: httpClient,
+		opts:       opts,
+		logger:     slog.New(slog.DiscardHandler),
+		stats:      &requestStats{},
+		ctx:        context.Background(),
 	}
 }
 
+// WithContext returns a copy of the client whose requests use ctx.
+// Cancelling it aborts the request in flight and any pending retries. The
+// copy shares the connection pool and request stats with c.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Options returns the client's effective options
+func (c *Client) Options() ClientOptions {
+	return c.opts
+}
+
 // SetToken updates the client's authentication token
 func (c *Client) SetToken(token string) {
 	c.token = token
 }
 
-// SetBaseURL overrides the API base URL (used for testing)
+// SetLogger sets the logger for request events. A nil logger discards them.
+func (c *Client) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	c.logger = logger
+}
+
+// SetRetryPolicy sets the total number of attempts per request and the base
+// backoff delay. A maxAttempts of 1 or less disables retries.
+func (c *Client) SetRetryPolicy(maxAttempts int, baseDelay time.Duration) {
+	c.opts.Retries = max(maxAttempts-1, 0)
+	if baseDelay > 0 {
+		c.opts.RetryDelay = baseDelay
+	}
+}
+
+// SetBaseURL overrides the API base URL (used for testing; see
+// ClientOptions.BaseURL)
 func (c *Client) SetBaseURL(url string) {
 	c.baseURL = url
 }
 
-// request makes an HTTP request to the Eero API
+// request makes an HTTP request to the Eero API, retrying transient network
+// errors, gateway errors, and rate limiting according to the client options
 func (c *Client) request(method, path string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+	return c.send(c.ctx, method, path, body, true)
+}
+
+// requestOnce makes an HTTP request that is never retried, for actions such
+// as reboots and firmware updates: a failed-looking attempt may already have
+// started, and repeating it would start it again
+func (c *Client) requestOnce(method, path string, body interface{}) ([]byte, error) {
+	return c.send(c.ctx, method, path, body, false)
+}
+
+// send makes a request, retrying it if retry is set
+func (c *Client) send(ctx context.Context, method, path string, body interface{}, retry bool) ([]byte, error) {
+	var payload []byte
 	if body != nil {
 		data, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshaling request body: %w", err)
 		}
-		reqBody = bytes.NewReader(data)
+		payload = data
+	}
+
+	for attempt := 0; ; attempt++ {
+		respBody, err := c.do(ctx, method, path, payload)
+		if err == nil {
+			return respBody, nil
+		}
+
+		// A cancelled context fails every retry the same way
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		delay, retryable := c.retryDelay(attempt, method, err)
+		if !retry || !retryable || attempt >= c.opts.Retries {
+			return nil, err
+		}
+		c.logger.Debug("retrying request", "method", method, "path", path, "attempt", attempt+1, "delay", delay, "error", err)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("making request: %w", err)
+		}
+	}
+}
+
+// sleepContext waits for d, returning ctx's error if it is cancelled first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryDelay reports whether err is worth retrying and how long to wait
+// before the next attempt. Requests that aren't idempotent, such as POSTs
+// that create something, are only retried when the server can't have acted
+// on them: a failed dial, or a 429 or 503 that says when to come back.
+func (c *Client) retryDelay(attempt int, method string, err error) (time.Duration, bool) {
+	backoff := c.opts.RetryDelay << attempt
+	safe := idempotent(method)
+
+	// A pin mismatch will not fix itself
+	var pinErr *PinError
+	if errors.As(err, &pinErr) {
+		return 0, false
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		// Failed dials and timeouts are transient; other network errors
+		// may mean the request was already received, and so may a timeout
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return backoff, true
+		}
+		var netErr net.Error
+		return backoff, safe && errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	switch {
+	case statusErr.StatusCode == http.StatusTooManyRequests,
+		statusErr.StatusCode == http.StatusServiceUnavailable && !safe:
+		if statusErr.RetryAfter > 0 {
+			return min(statusErr.RetryAfter, c.opts.RateLimitWait), true
+		}
+		return min(backoff, c.opts.RateLimitWait), safe
+	case statusErr.StatusCode == http.StatusBadGateway,
+		statusErr.StatusCode == http.StatusServiceUnavailable,
+		statusErr.StatusCode == http.StatusGatewayTimeout:
+		return backoff, safe
 	}
+	return 0, false
+}
+
+// idempotent reports whether sending a request with method twice has the
+// same effect as sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
 
-	req, err := http.NewRequest(method, c.baseURL+path, reqBody)
+// do performs a single HTTP request
+func (c *Client) do(ctx context.Context, method, path string, payload []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -66,28 +284,58 @@ func (c *Client) request(method, path string, body interface{}) ([]byte, error)
 		req.Header.Set("Cookie", "s="+c.token)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		c.stats.record(method, path, time.Since(start))
+		c.logger.Debug("request failed", "method", method, "path", path, "error", err)
 		return nil, fmt.Errorf("making request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.logger.Debug("request", "method", method, "path", path, "status", resp.StatusCode, "duration", time.Since(start))
 
 	respBody, err := io.ReadAll(resp.Body)
+	c.stats.record(method, path, time.Since(start))
 	if err != nil {
 		return nil, fmt.Errorf("reading response: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 		var apiErr APIError
 		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Meta.Error != "" {
-			return nil, fmt.Errorf("API error: %s", apiErr.Meta.Error)
+			statusErr.Message = apiErr.Meta.Error
 		}
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			statusErr.RetryAfter = time.Duration(secs) * time.Second
+		}
+		return nil, statusErr
 	}
 
 	return respBody, nil
 }
 
+// StatusError is returned when the API responds with a non-2xx status
+type StatusError struct {
+	StatusCode int
+	Message    string
+	Body       string
+	RetryAfter time.Duration // from the Retry-After header, if any
+}
+
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API error: %s", e.Message)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an API 404 response
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
 // APIError represents an error response from the Eero API
 type APIError struct {
 	Meta struct {
@@ -217,6 +465,41 @@ type Device struct {
 	} `json:"profile"`
 	ConnectionType string `json:"connection_type"`
 	DeviceType     string `json:"device_type"`
+	LastActive     string `json:"last_active"`
+	// Source is the eero node the device is connected through, if any
+	Source *DeviceSource `json:"source,omitempty"`
+	// Usage is only returned when requested with DeviceQuery.Usage
+	Usage *DeviceUsage `json:"usage,omitempty"`
+}
+
+// DeviceSource identifies the eero node a device is connected through
+type DeviceSource struct {
+	URL      string `json:"url"`
+	Location string `json:"location"`
+}
+
+// DeviceUsage is a device's recent bandwidth. Depending on the firmware the
+// API reports rates in Mbps, byte counts, or both.
+type DeviceUsage struct {
+	DownMbps  float64 `json:"down_mbps"`
+	UpMbps    float64 `json:"up_mbps"`
+	DownBytes int64   `json:"down_bytes"`
+	UpBytes   int64   `json:"up_bytes"`
+}
+
+// TotalMbps returns the combined download and upload rate
+func (u *DeviceUsage) TotalMbps() float64 {
+	return u.DownMbps + u.UpMbps
+}
+
+// TotalBytes returns the combined download and upload byte count
+func (u *DeviceUsage) TotalBytes() int64 {
+	return u.DownBytes + u.UpBytes
+}
+
+// IsZero reports whether no usage was recorded
+func (u *DeviceUsage) IsZero() bool {
+	return u == nil || (u.TotalMbps() == 0 && u.TotalBytes() == 0)
 }
 
 // DisplayName returns the best available name for the device
@@ -328,9 +611,77 @@ func (c *Client) GetDeviceRaw(networkID, deviceID string) (json.RawMessage, erro
 	return resp.Data, nil
 }
 
+// DeviceQuery holds optional server-side filters and paging for device listing.
+// Zero values are omitted from the request.
+type DeviceQuery struct {
+	Connected *bool // only connected (true) or disconnected (false) devices
+	Limit     int   // page size; 0 fetches everything in one request
+	Offset    int
+	Usage     bool // include per-device bandwidth usage
+}
+
+// Values encodes the query as URL parameters
+func (q DeviceQuery) Values() url.Values {
+	v := url.Values{}
+	if q.Connected != nil {
+		v.Set("connected", strconv.FormatBool(*q.Connected))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		v.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Usage {
+		v.Set("usage", "true")
+	}
+	return v
+}
+
 // GetDevices returns all devices on the network
 func (c *Client) GetDevices(networkID string) ([]Device, error) {
+	return c.GetDevicesWithQuery(networkID, DeviceQuery{})
+}
+
+// GetDevicesWithQuery returns devices on the network, passing the query's
+// filters to the server. When a page size is set, pages are fetched until a
+// short page is returned. Servers may ignore unsupported parameters, so
+// callers should still filter the results client-side.
+func (c *Client) GetDevicesWithQuery(networkID string, q DeviceQuery) ([]Device, error) {
+	var all []Device
+	var prevFirst string
+	for {
+		page, err := c.getDevicesPage(networkID, q)
+		if err != nil {
+			return nil, err
+		}
+
+		// A server that ignores offset returns the same page again
+		if len(page) > 0 && prevFirst != "" && page[0].URL == prevFirst {
+			return all, nil
+		}
+		all = append(all, page...)
+
+		if q.Limit <= 0 || len(page) != q.Limit {
+			return all, nil
+		}
+		prevFirst = page[0].URL
+		q.Offset += q.Limit
+	}
+}
+
+// GetDeviceUsage returns devices on the network along with their bandwidth usage
+func (c *Client) GetDeviceUsage(networkID string) ([]Device, error) {
+	return c.GetDevicesWithQuery(networkID, DeviceQuery{Usage: true})
+}
+
+// getDevicesPage fetches a single page of devices
+func (c *Client) getDevicesPage(networkID string, q DeviceQuery) ([]Device, error) {
 	path := fmt.Sprintf("/2.2/networks/%s/devices", networkID)
+	if params := q.Values().Encode(); params != "" {
+		path += "?" + params
+	}
+
 	data, err := c.request("GET", path, nil)
 	if err != nil {
 		return nil, err
@@ -414,6 +765,19 @@ type ProfileDetails struct {
 	Devices []struct {
 		URL string `json:"url"`
 	} `json:"devices"`
+	// Schedules are the blocks of time when the profile is paused
+	Schedules []ProfileSchedule `json:"schedules,omitempty"`
+	// Filters maps content filter names to whether they are enabled
+	Filters map[string]bool `json:"filters,omitempty"`
+}
+
+// ProfileSchedule is a recurring block of time when a profile is paused
+type ProfileSchedule struct {
+	Name    string   `json:"name"`
+	Days    []string `json:"days"`
+	Start   string   `json:"start"`
+	End     string   `json:"end"`
+	Enabled bool     `json:"enabled"`
 }
 
 // GetProfileDetails returns detailed profile information including devices
@@ -467,6 +831,12 @@ func (c *Client) PauseProfile(networkID, profileID string, pause bool) error {
 	return c.UpdateProfile(networkID, profileID, map[string]interface{}{"paused": pause})
 }
 
+// SetProfileContentFilter turns a profile's content filters on or off. Only
+// the filters named in the map change.
+func (c *Client) SetProfileContentFilter(networkID, profileID string, filters map[string]bool) error {
+	return c.UpdateProfile(networkID, profileID, map[string]interface{}{"filters": filters})
+}
+
 // GuestNetwork represents guest network settings
 type GuestNetwork struct {
 	Enabled  bool   `json:"enabled"`
@@ -512,33 +882,344 @@ func (c *Client) SetGuestNetworkPassword(networkID, password string) error {
 	return c.UpdateGuestNetwork(networkID, map[string]interface{}{"password": password})
 }
 
+// InternetPause is the state of the network-wide internet pause switch
+type InternetPause struct {
+	Paused bool `json:"paused"`
+}
+
+// GetInternetPause returns the state of the network-wide internet pause.
+// Firmware without the switch responds with 404 (see IsNotFound).
+func (c *Client) GetInternetPause(networkID string) (bool, error) {
+	path := fmt.Sprintf("/2.2/networks/%s/internet_pause", networkID)
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var resp APIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return false, fmt.Errorf("parsing response: %w", err)
+	}
+
+	var state InternetPause
+	if err := json.Unmarshal(resp.Data, &state); err != nil {
+		return false, fmt.Errorf("parsing internet pause data: %w", err)
+	}
+
+	return state.Paused, nil
+}
+
+// SetInternetPause pauses or resumes internet access for the whole network.
+// Firmware without the switch responds with 404 (see IsNotFound).
+func (c *Client) SetInternetPause(networkID string, paused bool) error {
+	path := fmt.Sprintf("/2.2/networks/%s/internet_pause", networkID)
+	_, err := c.request("PUT", path, InternetPause{Paused: paused})
+	return err
+}
+
+// Speedtest is a speed test result. A network that has never run a test
+// has an empty Date and zero speeds.
+type Speedtest struct {
+	DownMbps float64 `json:"down_mbps"`
+	UpMbps   float64 `json:"up_mbps"`
+	Date     string  `json:"date"`
+}
+
+// StartSpeedtest asks the gateway to run a speed test. The result appears
+// in GetSpeedtest once its Date changes.
+func (c *Client) StartSpeedtest(networkID string) error {
+	path := fmt.Sprintf("/2.2/networks/%s/speedtest", networkID)
+	_, err := c.request("POST", path, nil)
+	return err
+}
+
+// GetSpeedtest returns the network's most recent speed test result
+func (c *Client) GetSpeedtest(networkID string) (*Speedtest, error) {
+	path := fmt.Sprintf("/2.2/networks/%s/speedtest", networkID)
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp APIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	// The data is null before the first test, and may be a history list
+	// rather than a single result
+	raw := bytes.TrimSpace(resp.Data)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return &Speedtest{}, nil
+	}
+	if raw[0] == '[' {
+		var history []Speedtest
+		if err := json.Unmarshal(raw, &history); err != nil {
+			return nil, fmt.Errorf("parsing speed test data: %w", err)
+		}
+		latest := Speedtest{}
+		for _, s := range history {
+			if s.Date > latest.Date {
+				latest = s
+			}
+		}
+		return &latest, nil
+	}
+
+	var result Speedtest
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("parsing speed test data: %w", err)
+	}
+	return &result, nil
+}
+
+// Radio setting names accepted by SetRadioSetting
+const (
+	RadioBandSteering = "band_steering"
+	RadioLegacyMode   = "legacy_mode"
+)
+
+// RadioSettings holds network-wide radio options. A nil field means the
+// network's hardware does not expose that setting.
+type RadioSettings struct {
+	BandSteering *bool `json:"band_steering"`
+	LegacyMode   *bool `json:"legacy_mode"`
+}
+
+// Get returns the value of the named setting and whether it is supported
+func (r *RadioSettings) Get(name string) (value, supported bool) {
+	var v *bool
+	switch name {
+	case RadioBandSteering:
+		v = r.BandSteering
+	case RadioLegacyMode:
+		v = r.LegacyMode
+	}
+	if v == nil {
+		return false, false
+	}
+	return *v, true
+}
+
+// GetRadioSettings returns the network's radio settings
+func (c *Client) GetRadioSettings(networkID string) (*RadioSettings, error) {
+	path := fmt.Sprintf("/2.2/networks/%s", networkID)
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp APIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	var settings RadioSettings
+	if err := json.Unmarshal(resp.Data, &settings); err != nil {
+		return nil, fmt.Errorf("parsing network data: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// SetRadioSetting turns a single radio setting on or off
+func (c *Client) SetRadioSetting(networkID, name string, on bool) error {
+	switch name {
+	case RadioBandSteering, RadioLegacyMode:
+	default:
+		return fmt.Errorf("unknown radio setting: %s", name)
+	}
+
+	path := fmt.Sprintf("/2.2/networks/%s", networkID)
+	_, err := c.request("PUT", path, map[string]interface{}{name: on})
+	return err
+}
+
+// NetworkSettings holds the main WiFi network's name and password.
+// Password is empty when the API doesn't return it.
+type NetworkSettings struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// GetNetworkSettings returns the main WiFi network's name and, when the API
+// includes it, its password
+func (c *Client) GetNetworkSettings(networkID string) (*NetworkSettings, error) {
+	path := fmt.Sprintf("/2.2/networks/%s", networkID)
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp APIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	var settings NetworkSettings
+	if err := json.Unmarshal(resp.Data, &settings); err != nil {
+		return nil, fmt.Errorf("parsing network data: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// DHCPSettings describes the network's LAN subnet and DHCP pool
+type DHCPSettings struct {
+	Subnet string `json:"subnet"`
+	Start  string `json:"start_ip"`
+	End    string `json:"end_ip"`
+}
+
+// GetDHCP returns the network's DHCP settings
+func (c *Client) GetDHCP(networkID string) (*DHCPSettings, error) {
+	path := fmt.Sprintf("/2.2/networks/%s/dhcp", networkID)
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp APIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	var dhcp DHCPSettings
+	if err := json.Unmarshal(resp.Data, &dhcp); err != nil {
+		return nil, fmt.Errorf("parsing DHCP data: %w", err)
+	}
+
+	return &dhcp, nil
+}
+
+// SetDHCP updates the network's LAN subnet and DHCP pool
+func (c *Client) SetDHCP(networkID, subnet, start, end string) error {
+	path := fmt.Sprintf("/2.2/networks/%s/dhcp", networkID)
+	payload := DHCPSettings{Subnet: subnet, Start: start, End: end}
+	_, err := c.request("PUT", path, payload)
+	return err
+}
+
 // Reboot reboots the entire network
 func (c *Client) Reboot(networkID string) error {
 	path := fmt.Sprintf("/2.2/networks/%s/reboot", networkID)
-	_, err := c.request("POST", path, nil)
+	_, err := c.requestOnce("POST", path, nil)
+	return err
+}
+
+// UpdateStatus is the network's firmware update status
+type UpdateStatus struct {
+	HasUpdate      bool   `json:"has_update"`
+	CanUpdateNow   bool   `json:"can_update_now"`
+	TargetFirmware string `json:"target_firmware"`
+}
+
+// GetUpdateStatus retrieves whether a firmware update is available for the
+// network
+func (c *Client) GetUpdateStatus(networkID string) (*UpdateStatus, error) {
+	path := fmt.Sprintf("/2.2/networks/%s/updates", networkID)
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp APIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	var status UpdateStatus
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		return nil, fmt.Errorf("parsing update data: %w", err)
+	}
+
+	return &status, nil
+}
+
+// ApplyUpdate starts installing the available firmware update on every
+// eero in the network. The nodes reboot as part of the update.
+func (c *Client) ApplyUpdate(networkID string) error {
+	path := fmt.Sprintf("/2.2/networks/%s/updates", networkID)
+	_, err := c.requestOnce("POST", path, nil)
 	return err
 }
 
 // Eero represents an eero mesh node
 type Eero struct {
-	URL       string `json:"url"`
-	Serial    string `json:"serial"`
-	Location  string `json:"location"`
-	Gateway   bool   `json:"gateway"`
-	IPAddress string `json:"ip_address"`
-	Status    string `json:"status"`
-	Model     string `json:"model"`
-	OSVersion string `json:"os_version"`
-	Wired     bool   `json:"wired"`
-	State     string `json:"state"`
-	Resources struct {
+	URL             string `json:"url"`
+	Serial          string `json:"serial"`
+	Location        string `json:"location"`
+	Gateway         bool   `json:"gateway"`
+	IPAddress       string `json:"ip_address"`
+	Status          string `json:"status"`
+	Model           string `json:"model"`
+	OSVersion       string `json:"os_version"`
+	UpdateAvailable bool   `json:"update_available"`
+	Wired           bool   `json:"wired"`
+	State           string `json:"state"`
+	Resources       struct {
 		Reboot string `json:"reboot"`
 	} `json:"resources"`
-	MeshQualityBars         int  `json:"mesh_quality_bars"`
-	ConnectedClientsCount   int  `json:"connected_clients_count"`
-	HeartbeatOK             bool `json:"heartbeat_ok"`
-	IsPrimaryNode           bool `json:"is_primary_node"`
-	ConnectionType          string `json:"connection_type"`
+	MeshQualityBars       int    `json:"mesh_quality_bars"`
+	ConnectedClientsCount int    `json:"connected_clients_count"`
+	HeartbeatOK           bool   `json:"heartbeat_ok"`
+	IsPrimaryNode         bool   `json:"is_primary_node"`
+	ConnectionType        string `json:"connection_type"`
+	LastReboot            string `json:"last_reboot"`
+}
+
+// Uptime returns how long the eero has been up as of now, computed from
+// LastReboot. The second return value is false if the boot time is unknown.
+func (e *Eero) Uptime(now time.Time) (time.Duration, bool) {
+	if e.LastReboot == "" {
+		return 0, false
+	}
+	booted, err := time.Parse(time.RFC3339, e.LastReboot)
+	if err != nil {
+		return 0, false
+	}
+	if booted.After(now) {
+		return 0, true
+	}
+	return now.Sub(booted), true
+}
+
+// heavyClientLoad is the client count at which Grade marks a node down
+const heavyClientLoad = 40
+
+// Grade rates the node's health from A (best) to F for quick scanning.
+// Offline nodes (no heartbeat or red status) get F. Otherwise the score
+// starts at the mesh signal bars, counting a wired backhaul as 5, loses a
+// point for yellow status and a point for heavyClientLoad or more clients,
+// and maps 5=A, 4=B, 3=C, 2=D, and 1 or less=F.
+func (e *Eero) Grade() string {
+	if !e.HeartbeatOK || e.Status == "red" {
+		return "F"
+	}
+
+	score := min(max(e.MeshQualityBars, 0), 5)
+	if e.Wired || e.ConnectionType == "wired" {
+		score = 5
+	}
+	if e.Status == "yellow" {
+		score--
+	}
+	if e.ConnectedClientsCount >= heavyClientLoad {
+		score--
+	}
+
+	switch {
+	case score >= 5:
+		return "A"
+	case score == 4:
+		return "B"
+	case score == 3:
+		return "C"
+	case score == 2:
+		return "D"
+	default:
+		return "F"
+	}
 }
 
 // GetEeros returns all eero nodes on the network
@@ -581,7 +1262,7 @@ func (c *Client) GetEeroRaw(eeroID string) (json.RawMessage, error) {
 // RebootEero reboots a single eero node
 func (c *Client) RebootEero(eeroID string) error {
 	path := fmt.Sprintf("/2.2/eeros/%s/reboot", eeroID)
-	_, err := c.request("POST", path, nil)
+	_, err := c.requestOnce("POST", path, nil)
 	return err
 }
 
@@ -677,19 +1358,86 @@ func ExtractReservationID(url string) string {
 	return url
 }
 
-// ExtractNetworkID extracts the network ID from a URL path like "/2.2/networks/12345"
-func ExtractNetworkID(url string) string {
-	// URL format: /2.2/networks/{id}
-	const prefix = "/2.2/networks/"
-	if len(url) > len(prefix) && url[:len(prefix)] == prefix {
-		return url[len(prefix):]
+// Forward represents a port forwarding rule
+type Forward struct {
+	URL          string `json:"url"`
+	IP           string `json:"ip"`
+	ExternalPort int    `json:"gateway_port"`
+	InternalPort int    `json:"client_port"`
+	Protocol     string `json:"protocol"`
+	Description  string `json:"description"`
+	Enabled      bool   `json:"enabled"`
+}
+
+// GetForwards returns all port forwarding rules on the network
+func (c *Client) GetForwards(networkID string) ([]Forward, error) {
+	path := fmt.Sprintf("/2.2/networks/%s/forwards", networkID)
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp APIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	var forwards []Forward
+	if err := json.Unmarshal(resp.Data, &forwards); err != nil {
+		return nil, fmt.Errorf("parsing forwards data: %w", err)
+	}
+
+	return forwards, nil
+}
+
+// CreateForward creates a port forwarding rule. The rule's URL is ignored.
+func (c *Client) CreateForward(networkID string, f Forward) error {
+	path := fmt.Sprintf("/2.2/networks/%s/forwards", networkID)
+	payload := map[string]interface{}{
+		"ip":           f.IP,
+		"gateway_port": f.ExternalPort,
+		"client_port":  f.InternalPort,
+		"protocol":     f.Protocol,
+		"description":  f.Description,
+		"enabled":      f.Enabled,
+	}
+	_, err := c.request("POST", path, payload)
+	return err
+}
+
+// DeleteForward deletes a port forwarding rule
+func (c *Client) DeleteForward(networkID, forwardID string) error {
+	path := fmt.Sprintf("/2.2/networks/%s/forwards/%s", networkID, forwardID)
+	_, err := c.request("DELETE", path, nil)
+	return err
+}
+
+// ExtractForwardID extracts the forward ID from a URL path
+func ExtractForwardID(url string) string {
+	const marker = "/forwards/"
+	idx := strings.LastIndex(url, marker)
+	if idx >= 0 {
+		return url[idx+len(marker):]
 	}
 	return url
 }
 
+// ExtractNetworkID extracts the network ID from a URL path like
+// "/2.2/networks/12345", including nested paths such as
+// "/2.2/networks/12345/devices/aabbccdd1122"
+func ExtractNetworkID(url string) string {
+	const marker = "/networks/"
+	idx := strings.LastIndex(url, marker)
+	if idx < 0 {
+		return url
+	}
+	id, _, _ := strings.Cut(url[idx+len(marker):], "/")
+	return id
+}
+
 // ExtractDeviceID extracts the device ID from a URL path
 func ExtractDeviceID(url string) string {
-	// URL format: /{network_id}/devices/{device_id}
+	// URL format: /2.2/networks/{network_id}/devices/{device_id}
 	const marker = "/devices/"
 	idx := strings.LastIndex(url, marker)
 	if idx >= 0 {
@@ -700,7 +1448,7 @@ func ExtractDeviceID(url string) string {
 
 // ExtractProfileID extracts the profile ID from a URL path
 func ExtractProfileID(url string) string {
-	// URL format: /{network_id}/profiles/{profile_id}
+	// URL format: /2.2/networks/{network_id}/profiles/{profile_id}
 	const marker = "/profiles/"
 	idx := strings.LastIndex(url, marker)
 	if idx >= 0 {
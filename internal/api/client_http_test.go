@@ -1,13 +1,22 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // loadFixture reads a JSON fixture from testdata/
@@ -31,6 +40,24 @@ func newTestServer(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.S
 	return client, srv
 }
 
+func TestClientOptionsBaseURL(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write(loadFixture(t, "account.json"))
+	}))
+	t.Cleanup(srv.Close)
+
+	// A trailing slash doesn't double up in request paths
+	client := NewWithOptions("test-token", ClientOptions{BaseURL: srv.URL + "/"})
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/2.2/account" {
+		t.Errorf("Path = %q, want /2.2/account", gotPath)
+	}
+}
+
 // --- Auth cookie ---
 
 func TestRequestSetsAuthCookie(t *testing.T) {
@@ -133,6 +160,9 @@ func TestGetDevices(t *testing.T) {
 	if d.Profile.Name != "Adults" {
 		t.Errorf("Profile.Name = %q, want %q", d.Profile.Name, "Adults")
 	}
+	if d.Source == nil || d.Source.Location != "Living Room" {
+		t.Errorf("Source = %+v, want Living Room", d.Source)
+	}
 
 	// second device: no nickname, no profile, private
 	d2 := devices[1]
@@ -179,6 +209,121 @@ func TestGetDeviceRaw(t *testing.T) {
 	}
 }
 
+func TestGetDevicesNoQueryString(t *testing.T) {
+	var gotRawQuery string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotRawQuery = r.URL.RawQuery
+		w.Write(loadFixture(t, "devices.json"))
+	})
+
+	if _, err := client.GetDevices("12345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotRawQuery != "" {
+		t.Errorf("RawQuery = %q, want empty", gotRawQuery)
+	}
+}
+
+func TestGetDevicesWithQueryConnected(t *testing.T) {
+	var gotPath, gotRawQuery string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotRawQuery = r.URL.RawQuery
+		w.Write(loadFixture(t, "devices.json"))
+	})
+
+	connected := true
+	if _, err := client.GetDevicesWithQuery("12345", DeviceQuery{Connected: &connected}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/2.2/networks/12345/devices" {
+		t.Errorf("Path = %q", gotPath)
+	}
+	if gotRawQuery != "connected=true" {
+		t.Errorf("RawQuery = %q, want %q", gotRawQuery, "connected=true")
+	}
+}
+
+func TestGetDeviceUsage(t *testing.T) {
+	var gotRawQuery string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotRawQuery = r.URL.RawQuery
+		w.Write(loadFixture(t, "devices_usage.json"))
+	})
+
+	devices, err := client.GetDeviceUsage("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotRawQuery != "usage=true" {
+		t.Errorf("RawQuery = %q, want %q", gotRawQuery, "usage=true")
+	}
+	if len(devices) != 3 {
+		t.Fatalf("len(devices) = %d, want 3", len(devices))
+	}
+
+	laptop := devices[0].Usage
+	if laptop == nil || laptop.DownMbps != 3.2 || laptop.UpMbps != 1.1 ||
+		laptop.TotalBytes() != 62914560 {
+		t.Errorf("laptop usage = %+v", laptop)
+	}
+	if !devices[1].Usage.IsZero() {
+		t.Errorf("offline phone usage = %+v, want zero", devices[1].Usage)
+	}
+	if tv := devices[2].Usage; tv.TotalMbps() != 45.9 || tv.TotalBytes() != 0 {
+		t.Errorf("tv usage = %+v", tv)
+	}
+}
+
+func TestGetDevicesWithQueryPaging(t *testing.T) {
+	// devices.json has 3 devices; serve them two at a time
+	var all struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	json.Unmarshal(loadFixture(t, "devices.json"), &all)
+
+	var queries []string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := min(offset+limit, len(all.Data))
+		page, _ := json.Marshal(map[string]interface{}{"data": all.Data[offset:end]})
+		w.Write(page)
+	})
+
+	devices, err := client.GetDevicesWithQuery("12345", DeviceQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(devices) != 3 {
+		t.Errorf("len(devices) = %d, want 3", len(devices))
+	}
+	want := []string{"limit=2", "limit=2&offset=2"}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %v, want %v", queries, want)
+	}
+}
+
+func TestGetDevicesWithQueryServerIgnoresOffset(t *testing.T) {
+	requests := 0
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(loadFixture(t, "devices.json"))
+	})
+
+	devices, err := client.GetDevicesWithQuery("12345", DeviceQuery{Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(devices) != 3 {
+		t.Errorf("len(devices) = %d, want 3", len(devices))
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
 func TestUpdateDevice(t *testing.T) {
 	var gotMethod, gotPath string
 	var gotBody map[string]interface{}
@@ -296,6 +441,30 @@ func TestGetProfileDetails(t *testing.T) {
 	}
 }
 
+func TestGetProfileDetailsScheduleAndFilters(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(loadFixture(t, "profile_details_schedule.json"))
+	})
+
+	pd, err := client.GetProfileDetails("12345", "prof2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pd.Schedules) != 2 {
+		t.Fatalf("len(Schedules) = %d, want 2", len(pd.Schedules))
+	}
+	s := pd.Schedules[0]
+	if s.Name != "Bedtime" || s.Start != "21:00" || s.End != "07:00" || !s.Enabled || len(s.Days) != 5 {
+		t.Errorf("Schedules[0] = %+v", s)
+	}
+	if pd.Schedules[1].Enabled {
+		t.Error("Schedules[1].Enabled = true, want false")
+	}
+	if !pd.Filters["safe_search"] || pd.Filters["block_gaming"] || len(pd.Filters) != 3 {
+		t.Errorf("Filters = %v", pd.Filters)
+	}
+}
+
 func TestSetProfileDevices(t *testing.T) {
 	var gotBody map[string]interface{}
 	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -332,6 +501,28 @@ func TestPauseProfile(t *testing.T) {
 	}
 }
 
+func TestSetProfileContentFilter(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]interface{}
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	if err := client.SetProfileContentFilter("net1", "prof1", map[string]bool{"safe_search": true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "PUT" || gotPath != "/2.2/networks/net1/profiles/prof1" {
+		t.Errorf("request = %s %s", gotMethod, gotPath)
+	}
+	filters, _ := gotBody["filters"].(map[string]interface{})
+	if len(filters) != 1 || filters["safe_search"] != true {
+		t.Errorf("body = %v, want filters.safe_search true", gotBody)
+	}
+}
+
 // --- Eeros ---
 
 func TestGetEeros(t *testing.T) {
@@ -361,6 +552,15 @@ func TestGetEeros(t *testing.T) {
 	if eeros[1].MeshQualityBars != 3 {
 		t.Errorf("MeshQualityBars = %d, want 3", eeros[1].MeshQualityBars)
 	}
+	if eeros[0].LastReboot != "2023-11-10T08:00:00Z" {
+		t.Errorf("LastReboot = %q, want %q", eeros[0].LastReboot, "2023-11-10T08:00:00Z")
+	}
+	if eeros[1].LastReboot != "" {
+		t.Errorf("eeros[1].LastReboot = %q, want empty", eeros[1].LastReboot)
+	}
+	if eeros[0].UpdateAvailable || !eeros[1].UpdateAvailable {
+		t.Errorf("UpdateAvailable = %v, %v, want false, true", eeros[0].UpdateAvailable, eeros[1].UpdateAvailable)
+	}
 }
 
 func TestRebootEero(t *testing.T) {
@@ -408,6 +608,20 @@ func TestGetGuestNetwork(t *testing.T) {
 	}
 }
 
+func TestGetGuestNetworkUnconfigured(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(loadFixture(t, "guest_network_unconfigured.json"))
+	})
+
+	gn, err := client.GetGuestNetwork("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gn.Enabled || gn.Name != "" || gn.Password != "" {
+		t.Errorf("gn = %+v, want zero value", gn)
+	}
+}
+
 func TestEnableGuestNetwork(t *testing.T) {
 	var gotBody map[string]interface{}
 	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -516,6 +730,94 @@ func TestDeleteReservation(t *testing.T) {
 	}
 }
 
+// --- Port forwarding ---
+
+func TestGetForwards(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2.2/networks/12345/forwards" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write(loadFixture(t, "forwards.json"))
+	})
+
+	forwards, err := client.GetForwards("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(forwards) != 2 {
+		t.Fatalf("len(forwards) = %d, want 2", len(forwards))
+	}
+	f := forwards[0]
+	if f.IP != "192.168.1.10" || f.ExternalPort != 8443 || f.InternalPort != 443 || f.Protocol != "tcp" || f.Description != "NAS web" || !f.Enabled {
+		t.Errorf("forwards[0] = %+v", f)
+	}
+	if ExtractForwardID(f.URL) != "fwd1" {
+		t.Errorf("ExtractForwardID = %q, want fwd1", ExtractForwardID(f.URL))
+	}
+	if forwards[1].Enabled {
+		t.Error("forwards[1].Enabled = true, want false")
+	}
+}
+
+func TestCreateForward(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]interface{}
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	err := client.CreateForward("12345", Forward{
+		IP:           "192.168.1.10",
+		ExternalPort: 8443,
+		InternalPort: 443,
+		Protocol:     "tcp",
+		Description:  "NAS web",
+		Enabled:      true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "POST" || gotPath != "/2.2/networks/12345/forwards" {
+		t.Errorf("request = %s %s", gotMethod, gotPath)
+	}
+	want := map[string]interface{}{
+		"ip":           "192.168.1.10",
+		"gateway_port": float64(8443),
+		"client_port":  float64(443),
+		"protocol":     "tcp",
+		"description":  "NAS web",
+		"enabled":      true,
+	}
+	for k, v := range want {
+		if gotBody[k] != v {
+			t.Errorf("%s = %v, want %v", k, gotBody[k], v)
+		}
+	}
+	if _, ok := gotBody["url"]; ok {
+		t.Error("body should not include url")
+	}
+}
+
+func TestDeleteForward(t *testing.T) {
+	var gotMethod, gotPath string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	if err := client.DeleteForward("12345", "fwd1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "DELETE" || gotPath != "/2.2/networks/12345/forwards/fwd1" {
+		t.Errorf("request = %s %s", gotMethod, gotPath)
+	}
+}
+
 // --- Reboot network ---
 
 func TestRebootNetwork(t *testing.T) {
@@ -538,6 +840,268 @@ func TestRebootNetwork(t *testing.T) {
 	}
 }
 
+// --- Firmware updates ---
+
+func TestGetUpdateStatus(t *testing.T) {
+	var gotPath string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write(loadFixture(t, "updates.json"))
+	})
+
+	status, err := client.GetUpdateStatus("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/2.2/networks/12345/updates" {
+		t.Errorf("Path = %q", gotPath)
+	}
+	if !status.HasUpdate || !status.CanUpdateNow {
+		t.Errorf("HasUpdate = %v, CanUpdateNow = %v, want both true", status.HasUpdate, status.CanUpdateNow)
+	}
+	if status.TargetFirmware != "7.4.0-1234" {
+		t.Errorf("TargetFirmware = %q, want %q", status.TargetFirmware, "7.4.0-1234")
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	var gotMethod, gotPath string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	if err := client.ApplyUpdate("12345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "POST" {
+		t.Errorf("Method = %q, want POST", gotMethod)
+	}
+	if gotPath != "/2.2/networks/12345/updates" {
+		t.Errorf("Path = %q", gotPath)
+	}
+}
+
+// --- Radio settings ---
+
+func TestGetRadioSettings(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2.2/networks/12345" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write(loadFixture(t, "network.json"))
+	})
+
+	settings, err := client.GetRadioSettings("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := settings.Get(RadioBandSteering); !ok || !v {
+		t.Errorf("band steering = %v (supported %v), want on", v, ok)
+	}
+	// legacy_mode is absent from the fixture
+	if _, ok := settings.Get(RadioLegacyMode); ok {
+		t.Error("legacy mode should be unsupported when absent")
+	}
+}
+
+func TestGetNetworkSettings(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2.2/networks/12345" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write(loadFixture(t, "network.json"))
+	})
+
+	settings, err := client.GetNetworkSettings("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The fixture, like the API, has no password
+	if settings.Name != "Home" || settings.Password != "" {
+		t.Errorf("settings = %+v", settings)
+	}
+}
+
+func TestSetRadioSetting(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]interface{}
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	if err := client.SetRadioSetting("12345", RadioLegacyMode, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "PUT" || gotPath != "/2.2/networks/12345" {
+		t.Errorf("request = %s %s, want PUT /2.2/networks/12345", gotMethod, gotPath)
+	}
+	if len(gotBody) != 1 || gotBody["legacy_mode"] != true {
+		t.Errorf("body = %v, want {legacy_mode: true}", gotBody)
+	}
+
+	if err := client.SetRadioSetting("12345", RadioBandSteering, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotBody["band_steering"] != false {
+		t.Errorf("body = %v, want {band_steering: false}", gotBody)
+	}
+}
+
+func TestSetRadioSettingUnknown(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be made for an unknown setting")
+	})
+
+	if err := client.SetRadioSetting("12345", "turbo", true); err == nil {
+		t.Error("expected error for unknown setting")
+	}
+}
+
+// --- Internet pause ---
+
+func TestGetInternetPause(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2.2/networks/12345/internet_pause" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"meta":{"code":200},"data":{"paused":true}}`))
+	})
+
+	paused, err := client.GetInternetPause("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !paused {
+		t.Error("paused = false, want true")
+	}
+}
+
+func TestGetSpeedtest(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Speedtest
+	}{
+		{"object", `{"down_mbps": 512.3, "up_mbps": 40.1, "date": "2024-03-10T08:00:00Z"}`, Speedtest{512.3, 40.1, "2024-03-10T08:00:00Z"}},
+		{"history picks latest", `[{"down_mbps": 100, "up_mbps": 10, "date": "2024-03-09T08:00:00Z"}, {"down_mbps": 200, "up_mbps": 20, "date": "2024-03-10T08:00:00Z"}]`, Speedtest{200, 20, "2024-03-10T08:00:00Z"}},
+		{"never run", `null`, Speedtest{}},
+		{"empty history", `[]`, Speedtest{}},
+	}
+
+	for _, tt := range tests {
+		client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "GET" || r.URL.Path != "/2.2/networks/12345/speedtest" {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			w.Write([]byte(`{"meta":{"code":200},"data":` + tt.data + `}`))
+		})
+
+		got, err := client.GetSpeedtest("12345")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if *got != tt.want {
+			t.Errorf("%s: GetSpeedtest() = %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+func TestStartSpeedtest(t *testing.T) {
+	var gotMethod, gotPath string
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	if err := client.StartSpeedtest("12345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "POST" || gotPath != "/2.2/networks/12345/speedtest" {
+		t.Errorf("request = %s %s, want POST /2.2/networks/12345/speedtest", gotMethod, gotPath)
+	}
+}
+
+func TestSetInternetPause(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]interface{}
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	if err := client.SetInternetPause("12345", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "PUT" || gotPath != "/2.2/networks/12345/internet_pause" {
+		t.Errorf("request = %s %s", gotMethod, gotPath)
+	}
+	if gotBody["paused"] != true {
+		t.Errorf("body = %v, want paused: true", gotBody)
+	}
+}
+
+func TestSetInternetPauseUnsupported(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(loadFixture(t, "error_404.json"))
+	})
+
+	err := client.SetInternetPause("12345", true)
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false, want true", err)
+	}
+}
+
+// --- DHCP ---
+
+func TestGetDHCP(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/2.2/networks/12345/dhcp" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write(loadFixture(t, "dhcp.json"))
+	})
+
+	dhcp, err := client.GetDHCP("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dhcp.Subnet != "192.168.4.0/22" || dhcp.Start != "192.168.4.100" || dhcp.End != "192.168.7.200" {
+		t.Errorf("dhcp = %+v", dhcp)
+	}
+}
+
+func TestSetDHCP(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]interface{}
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write(loadFixture(t, "empty_ok.json"))
+	})
+
+	if err := client.SetDHCP("12345", "10.0.0.0/24", "10.0.0.50", "10.0.0.250"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != "PUT" || gotPath != "/2.2/networks/12345/dhcp" {
+		t.Errorf("request = %s %s, want PUT /2.2/networks/12345/dhcp", gotMethod, gotPath)
+	}
+	if gotBody["subnet"] != "10.0.0.0/24" || gotBody["start_ip"] != "10.0.0.50" || gotBody["end_ip"] != "10.0.0.250" {
+		t.Errorf("body = %v", gotBody)
+	}
+}
+
 // --- Login ---
 
 func TestLogin(t *testing.T) {
@@ -617,6 +1181,26 @@ func TestAPIError500(t *testing.T) {
 	}
 }
 
+func TestAPIError404IsNotFound(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(loadFixture(t, "error_404.json"))
+	})
+
+	_, err := client.GetGuestNetwork("12345")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false, want true", err)
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("error = %#v, want *StatusError with status 404", err)
+	}
+}
+
 func TestAPIErrorMalformedJSON(t *testing.T) {
 	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadGateway)
@@ -627,6 +1211,12 @@ func TestAPIErrorMalformedJSON(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
+	if got := err.Error(); got != "API error (status 502): not json" {
+		t.Errorf("error = %q", got)
+	}
+	if IsNotFound(err) {
+		t.Error("IsNotFound = true for a 502")
+	}
 }
 
 func TestValidateTokenWithServer(t *testing.T) {
@@ -649,3 +1239,323 @@ func TestValidateTokenFails(t *testing.T) {
 		t.Error("ValidateToken() = true, want false")
 	}
 }
+
+// --- Retries ---
+
+// newRetryTestServer returns a client with fast retries pointed at a server
+// that responds with the given status codes in order, then 200
+func newRetryTestServer(t *testing.T, retries int, statuses ...int) (*Client, *int) {
+	t.Helper()
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= len(statuses) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(statuses[requests-1])
+			w.Write(loadFixture(t, "error_500.json"))
+			return
+		}
+		w.Write(loadFixture(t, "account.json"))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewWithOptions("test-token", ClientOptions{
+		Retries:       retries,
+		RetryDelay:    time.Millisecond,
+		RateLimitWait: time.Millisecond,
+	})
+	client.SetBaseURL(srv.URL)
+	return client, &requests
+}
+
+func TestRequestRetriesUntilSuccess(t *testing.T) {
+	requests := 0
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write(loadFixture(t, "error_500.json"))
+			return
+		}
+		w.Write(loadFixture(t, "account.json"))
+	})
+	client.SetRetryPolicy(3, time.Millisecond)
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestRequestNoRetries(t *testing.T) {
+	client, requests := newRetryTestServer(t, NoRetries, 503)
+
+	if _, err := client.GetAccount(); err == nil {
+		t.Fatal("expected error")
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+}
+
+func TestRequestRetriesGatewayErrors(t *testing.T) {
+	client, requests := newRetryTestServer(t, 2, 502, 504)
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *requests != 3 {
+		t.Errorf("requests = %d, want 3", *requests)
+	}
+}
+
+func TestRequestDoesNotRetryInternalServerError(t *testing.T) {
+	client, requests := newRetryTestServer(t, 2, 500)
+
+	if _, err := client.GetAccount(); err == nil {
+		t.Fatal("expected error")
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+}
+
+func TestRequestDoesNotRetryReboot(t *testing.T) {
+	client, requests := newRetryTestServer(t, 2, 503)
+
+	if err := client.Reboot("12345"); err == nil {
+		t.Fatal("expected error")
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+}
+
+func TestRequestDoesNotRetryApplyUpdate(t *testing.T) {
+	client, requests := newRetryTestServer(t, 2, 503)
+
+	if err := client.ApplyUpdate("12345"); err == nil {
+		t.Fatal("expected error")
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+}
+
+func TestRequestDoesNotRetryEeroReboot(t *testing.T) {
+	client, requests := newRetryTestServer(t, 2, 503)
+
+	if err := client.RebootEero("8318690"); err == nil {
+		t.Fatal("expected error")
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+}
+
+func TestRequestDoesNotResendTimedOutPost(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Answer only after the client has given up
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := NewWithOptions("test-token", ClientOptions{
+		Timeout:    50 * time.Millisecond,
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+		BaseURL:    srv.URL,
+	})
+
+	if err := client.CreateReservation("12345", "192.168.1.50", "aa:bb:cc:dd:ee:ff", "TV"); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1: the server may have created the reservation", n)
+	}
+
+	// A timed-out GET is safe to repeat
+	requests.Store(0)
+	if _, err := client.GetAccount(); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("GET requests = %d, want 3", n)
+	}
+}
+
+func TestRequestRetriesPostOnlyWithRetryAfter(t *testing.T) {
+	// newRetryTestServer sends Retry-After with every error
+	client, requests := newRetryTestServer(t, 2, 503)
+	if err := client.StartSpeedtest("12345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *requests != 2 {
+		t.Errorf("requests = %d, want 2 after a 503 with Retry-After", *requests)
+	}
+
+	client, requests = newRetryTestServer(t, 2, 504)
+	if err := client.StartSpeedtest("12345"); err == nil {
+		t.Fatal("expected error")
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1 after a 504", *requests)
+	}
+}
+
+func TestRequestRetriesDialErrors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	client := New("test-token")
+	client.SetBaseURL(srv.URL)
+	client.SetRetryPolicy(2, time.Millisecond)
+	var buf bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if _, err := client.GetAccount(); err == nil {
+		t.Fatal("expected error from a closed server")
+	}
+	if retries := strings.Count(buf.String(), "retrying request"); retries != 1 {
+		t.Errorf("retries = %d, want 1", retries)
+	}
+}
+
+func TestRequestRetriesExhausted(t *testing.T) {
+	client, requests := newRetryTestServer(t, 1, 503, 503, 503)
+
+	if _, err := client.GetAccount(); err == nil {
+		t.Fatal("expected error after retries are exhausted")
+	}
+	if *requests != 2 {
+		t.Errorf("requests = %d, want 2", *requests)
+	}
+}
+
+func TestRequestCanceledContext(t *testing.T) {
+	client, requests := newRetryTestServer(t, 3, 503)
+	client.SetRetryPolicy(4, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client = client.WithContext(ctx)
+
+	start := time.Now()
+	_, err := client.GetAccount()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %s with a cancelled context", elapsed)
+	}
+	if *requests != 0 {
+		t.Errorf("requests = %d, want 0", *requests)
+	}
+}
+
+func TestClientWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := NewWithOptions("test-token", ClientOptions{Retries: NoRetries, BaseURL: "http://127.0.0.1:1"})
+
+	if _, err := client.WithContext(ctx).GetAccount(); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+	// The original client is unaffected, and shares request stats
+	if _, err := client.GetAccount(); errors.Is(err, context.Canceled) {
+		t.Errorf("original client used the copy's context: %v", err)
+	}
+	if got := client.Stats().Requests; got != 2 {
+		t.Errorf("stats requests = %d, want 2", got)
+	}
+}
+
+func TestRequestContextCancelsRetryWait(t *testing.T) {
+	client, requests := newRetryTestServer(t, 3, 503)
+	client.SetRetryPolicy(4, time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client = client.WithContext(ctx)
+
+	start := time.Now()
+	_, err := client.GetAccount()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retry wait took %s after the context expired", elapsed)
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+}
+
+func TestRequestDoesNotRetryClientErrors(t *testing.T) {
+	client, requests := newRetryTestServer(t, 3, 404)
+
+	if _, err := client.GetAccount(); err == nil {
+		t.Fatal("expected error")
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1", *requests)
+	}
+}
+
+func TestRequestRateLimitWaitIsCapped(t *testing.T) {
+	// The server asks for 60s; RateLimitWait caps the wait at 1ms
+	client, requests := newRetryTestServer(t, 1, 429)
+
+	start := time.Now()
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waited %v, want Retry-After capped by RateLimitWait", elapsed)
+	}
+	if *requests != 2 {
+		t.Errorf("requests = %d, want 2", *requests)
+	}
+}
+
+// --- Logging ---
+
+func TestRequestLogsDebugEvent(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(loadFixture(t, "account.json"))
+	})
+
+	var buf bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"level=DEBUG", "method=GET", "path=/2.2/account", "status=200"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "test-token") {
+		t.Error("log must not contain the auth token")
+	}
+}
+
+func TestRequestLogsRetries(t *testing.T) {
+	client, _ := newRetryTestServer(t, 1, 503)
+
+	var buf bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "retrying request") {
+		t.Errorf("log missing retry event:\n%s", buf.String())
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"testing"
+	"time"
 )
 
 func TestExtractNetworkID(t *testing.T) {
@@ -10,7 +11,12 @@ func TestExtractNetworkID(t *testing.T) {
 		expected string
 	}{
 		{"/2.2/networks/12345", "12345"},
+		{"/2.2/networks/1664356", "1664356"},
 		{"/2.2/networks/abc-def-ghi", "abc-def-ghi"},
+		{"/2.2/networks/0f8fad5b-d9cb-469f-a165-70867728950e", "0f8fad5b-d9cb-469f-a165-70867728950e"},
+		{"/2.2/networks/1664356/devices/f6af4e4424f1", "1664356"},
+		{"/2.3/networks/12345", "12345"},
+		{"12345", "12345"}, // Already just an ID
 	}
 
 	for _, tt := range tests {
@@ -28,6 +34,10 @@ func TestExtractDeviceID(t *testing.T) {
 	}{
 		{"/1664356/devices/f6af4e4424f1", "f6af4e4424f1"},
 		{"/123/devices/abc-def", "abc-def"},
+		{"/2.2/networks/12345/devices/aabbccdd1122", "aabbccdd1122"},
+		{"/2.2/networks/1664356/devices/aabbccdd1122", "aabbccdd1122"},
+		{"/2.2/networks/0f8fad5b-d9cb-469f-a165-70867728950e/devices/aabbccdd1122", "aabbccdd1122"},
+		{"aabbccdd1122", "aabbccdd1122"}, // Already just an ID
 	}
 
 	for _, tt := range tests {
@@ -45,6 +55,10 @@ func TestExtractProfileID(t *testing.T) {
 	}{
 		{"/1664356/profiles/prof123", "prof123"},
 		{"/123/profiles/abc-def", "abc-def"},
+		{"/2.2/networks/12345/profiles/prof123", "prof123"},
+		{"/2.2/networks/1664356/profiles/prof123", "prof123"},
+		{"/2.2/networks/0f8fad5b-d9cb-469f-a165-70867728950e/profiles/prof123", "prof123"},
+		{"prof123", "prof123"}, // Already just an ID
 	}
 
 	for _, tt := range tests {
@@ -84,6 +98,34 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClientDefaults(t *testing.T) {
+	opts := New("t").Options()
+	if opts.Timeout != DefaultTimeout || opts.Retries != DefaultRetries ||
+		opts.RetryDelay != DefaultRetryDelay || opts.RateLimitWait != DefaultRateLimitWait {
+		t.Errorf("default options = %+v", opts)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	client := NewWithOptions("t", ClientOptions{
+		Timeout:    5 * time.Second,
+		Retries:    4,
+		RetryDelay: time.Second,
+	})
+
+	opts := client.Options()
+	if opts.Timeout != 5*time.Second || opts.Retries != 4 || opts.RetryDelay != time.Second {
+		t.Errorf("options = %+v", opts)
+	}
+	// Unset values fall back to the defaults
+	if opts.RateLimitWait != DefaultRateLimitWait {
+		t.Errorf("RateLimitWait = %v, want default", opts.RateLimitWait)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("httpClient.Timeout = %v, want 5s", client.httpClient.Timeout)
+	}
+}
+
 func TestSetToken(t *testing.T) {
 	client := New("initial-token")
 	client.SetToken("new-token")
@@ -137,3 +179,52 @@ func TestShortenIPv6(t *testing.T) {
 		}
 	}
 }
+
+func TestEeroGrade(t *testing.T) {
+	tests := []struct {
+		name string
+		eero Eero
+		want string
+	}{
+		{"wired, green, 5 bars", Eero{Status: "green", HeartbeatOK: true, Wired: true, MeshQualityBars: 5}, "A"},
+		{"wired ignores bars", Eero{Status: "green", HeartbeatOK: true, ConnectionType: "wired", MeshQualityBars: 1}, "A"},
+		{"wireless, 5 bars", Eero{Status: "green", HeartbeatOK: true, MeshQualityBars: 5}, "A"},
+		{"wireless, 4 bars", Eero{Status: "green", HeartbeatOK: true, MeshQualityBars: 4}, "B"},
+		{"wireless, 3 bars", Eero{Status: "green", HeartbeatOK: true, MeshQualityBars: 3}, "C"},
+		{"wireless, 2 bars", Eero{Status: "green", HeartbeatOK: true, MeshQualityBars: 2}, "D"},
+		{"wireless, 1 bar", Eero{Status: "green", HeartbeatOK: true, MeshQualityBars: 1}, "F"},
+		{"yellow status", Eero{Status: "yellow", HeartbeatOK: true, Wired: true}, "B"},
+		{"heavy load", Eero{Status: "green", HeartbeatOK: true, MeshQualityBars: 4, ConnectedClientsCount: 45}, "C"},
+		{"red status", Eero{Status: "red", HeartbeatOK: true, Wired: true}, "F"},
+		{"no heartbeat", Eero{Status: "green", Wired: true}, "F"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.eero.Grade(); got != tt.want {
+			t.Errorf("%s: Grade() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEeroUptime(t *testing.T) {
+	now := time.Date(2023, 11, 12, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		lastReboot string
+		want       time.Duration
+		ok         bool
+	}{
+		{"2023-11-10T08:00:00Z", 50*time.Hour + 30*time.Minute, true},
+		{"2023-11-12T10:30:00Z", 0, true},
+		{"", 0, false},
+		{"not-a-time", 0, false},
+	}
+
+	for _, tt := range tests {
+		e := Eero{LastReboot: tt.lastReboot}
+		got, ok := e.Uptime(now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Uptime() with LastReboot %q = (%v, %v), want (%v, %v)", tt.lastReboot, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -16,6 +16,8 @@ type EeroAPI interface {
 
 	// Devices
 	GetDevices(networkID string) ([]Device, error)
+	GetDevicesWithQuery(networkID string, q DeviceQuery) ([]Device, error)
+	GetDeviceUsage(networkID string) ([]Device, error)
 	GetDeviceRaw(networkID, deviceID string) (json.RawMessage, error)
 	UpdateDevice(networkID, deviceID string, updates map[string]interface{}) error
 	PauseDevice(networkID, deviceID string, pause bool) error
@@ -29,11 +31,13 @@ type EeroAPI interface {
 	UpdateProfile(networkID, profileID string, updates map[string]interface{}) error
 	SetProfileDevices(networkID, profileID string, deviceURLs []string) error
 	PauseProfile(networkID, profileID string, pause bool) error
+	SetProfileContentFilter(networkID, profileID string, filters map[string]bool) error
 
 	// Eeros
 	GetEeros(networkID string) ([]Eero, error)
 	GetEeroRaw(eeroID string) (json.RawMessage, error)
 	RebootEero(eeroID string) error
+	GetTopology(networkID string) (*Topology, error)
 
 	// Guest Network
 	GetGuestNetwork(networkID string) (*GuestNetwork, error)
@@ -43,10 +47,28 @@ type EeroAPI interface {
 
 	// Network
 	Reboot(networkID string) error
+	GetRadioSettings(networkID string) (*RadioSettings, error)
+	GetNetworkSettings(networkID string) (*NetworkSettings, error)
+	SetRadioSetting(networkID, name string, on bool) error
+	GetDHCP(networkID string) (*DHCPSettings, error)
+	GetInternetPause(networkID string) (bool, error)
+	SetInternetPause(networkID string, paused bool) error
+	SetDHCP(networkID, subnet, start, end string) error
+	StartSpeedtest(networkID string) error
+	GetSpeedtest(networkID string) (*Speedtest, error)
 
 	// Reservations
 	GetReservations(networkID string) ([]Reservation, error)
 	GetReservationRaw(networkID, reservationID string) (json.RawMessage, error)
 	CreateReservation(networkID, ip, mac, description string) error
 	DeleteReservation(networkID, reservationID string) error
+
+	// Port forwarding
+	GetForwards(networkID string) ([]Forward, error)
+	CreateForward(networkID string, f Forward) error
+	DeleteForward(networkID, forwardID string) error
+
+	// Firmware updates
+	GetUpdateStatus(networkID string) (*UpdateStatus, error)
+	ApplyUpdate(networkID string) error
 }
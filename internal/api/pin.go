@@ -0,0 +1,53 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// PinError is returned when the server's certificate does not match the
+// configured public key pin
+type PinError struct {
+	Host string
+	Got  string
+	Want string
+}
+
+func (e *PinError) Error() string {
+	return fmt.Sprintf("certificate pin mismatch for %s: server key sha256 is %s, want %s", e.Host, e.Got, e.Want)
+}
+
+// ParsePin normalizes a SHA-256 public key pin given as hex, with or without
+// colon separators
+func ParsePin(pin string) (string, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(pin), ":", ""))
+	if b, err := hex.DecodeString(normalized); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid pin: %s (must be a hex SHA-256 hash)", pin)
+	}
+	return normalized, nil
+}
+
+// PublicKeyPin returns the hex SHA-256 hash of a certificate's public key
+// (its DER-encoded SubjectPublicKeyInfo)
+func PublicKeyPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyPin returns a tls.Config callback that requires the leaf certificate
+// to match pin, in addition to the usual chain verification
+func verifyPin(pin string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return &PinError{Host: cs.ServerName, Got: "none", Want: pin}
+		}
+		if got := PublicKeyPin(cs.PeerCertificates[0]); got != pin {
+			return &PinError{Host: cs.ServerName, Got: got, Want: pin}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newPinnedTestServer starts a TLS test server and returns a client pinned
+// to pin that trusts the server's test CA
+func newPinnedTestServer(t *testing.T, pin func(srv *httptest.Server) string) (*Client, *int) {
+	t.Helper()
+	requests := 0
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(loadFixture(t, "account.json"))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewWithOptions("test-token", ClientOptions{PinSHA256: pin(srv), Retries: 2})
+	client.SetBaseURL(srv.URL)
+
+	// Trust the test server's self-signed CA; the pin check runs on top of it
+	transport := client.httpClient.Transport.(*http.Transport)
+	transport.TLSClientConfig.RootCAs = srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	return client, &requests
+}
+
+func TestPinnedCertificateMatches(t *testing.T) {
+	client, _ := newPinnedTestServer(t, func(srv *httptest.Server) string {
+		return PublicKeyPin(srv.Certificate())
+	})
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error with matching pin: %v", err)
+	}
+}
+
+func TestPinnedCertificateMismatch(t *testing.T) {
+	client, requests := newPinnedTestServer(t, func(srv *httptest.Server) string {
+		return strings.Repeat("00", 32)
+	})
+
+	_, err := client.GetAccount()
+	var pinErr *PinError
+	if !errors.As(err, &pinErr) {
+		t.Fatalf("expected PinError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "certificate pin mismatch") {
+		t.Errorf("error = %q, want a clear pinning message", err.Error())
+	}
+	if *requests != 0 {
+		t.Errorf("requests = %d, want the handshake to fail before any request", *requests)
+	}
+}
+
+func TestParsePin(t *testing.T) {
+	want := strings.Repeat("ab", 32)
+	for _, in := range []string{want, strings.ToUpper(want), strings.Repeat("AB:", 31) + "AB"} {
+		got, err := ParsePin(in)
+		if err != nil {
+			t.Errorf("ParsePin(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParsePin(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "abc", strings.Repeat("zz", 32), strings.Repeat("ab", 31)} {
+		if _, err := ParsePin(in); err == nil {
+			t.Errorf("ParsePin(%q) expected error", in)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PathStats counts the requests made for one method and path pattern
+type PathStats struct {
+	Method   string
+	Pattern  string
+	Count    int
+	Duration time.Duration
+}
+
+// Stats summarizes the HTTP requests a client has made. Retries count as
+// separate requests.
+type Stats struct {
+	Requests int
+	Duration time.Duration
+	// Paths is sorted by count, most frequent first
+	Paths []PathStats
+}
+
+// requestStats accumulates per-pattern counters, safe for concurrent use
+type requestStats struct {
+	mu    sync.Mutex
+	paths map[string]*PathStats
+}
+
+// record adds one request to the counters
+func (s *requestStats) record(method, path string, d time.Duration) {
+	pattern := pathPattern(path)
+	key := method + " " + pattern
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.paths == nil {
+		s.paths = make(map[string]*PathStats)
+	}
+	ps, ok := s.paths[key]
+	if !ok {
+		ps = &PathStats{Method: method, Pattern: pattern}
+		s.paths[key] = ps
+	}
+	ps.Count++
+	ps.Duration += d
+}
+
+// snapshot returns the current totals
+func (s *requestStats) snapshot() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stats Stats
+	for _, ps := range s.paths {
+		stats.Requests += ps.Count
+		stats.Duration += ps.Duration
+		stats.Paths = append(stats.Paths, *ps)
+	}
+	sort.Slice(stats.Paths, func(i, j int) bool {
+		a, b := stats.Paths[i], stats.Paths[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Method+" "+a.Pattern < b.Method+" "+b.Pattern
+	})
+	return stats
+}
+
+// idCollections are path segments followed by a resource ID
+var idCollections = map[string]bool{
+	"networks":     true,
+	"devices":      true,
+	"profiles":     true,
+	"eeros":        true,
+	"reservations": true,
+	"forwards":     true,
+}
+
+// pathPattern replaces resource IDs in path with {id} and drops the query,
+// so "/2.2/networks/12345/devices/aabb?limit=5" becomes
+// "/2.2/networks/{id}/devices/{id}"
+func pathPattern(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if idCollections[segments[i-1]] && segments[i] != "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Stats returns a summary of the requests made so far
+func (c *Client) Stats() Stats {
+	return c.stats.snapshot()
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPathPattern(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/2.2/account", "/2.2/account"},
+		{"/2.2/networks/12345/devices", "/2.2/networks/{id}/devices"},
+		{"/2.2/networks/12345/devices/aabbccdd1122", "/2.2/networks/{id}/devices/{id}"},
+		{"/2.2/networks/12345/profiles/prof1", "/2.2/networks/{id}/profiles/{id}"},
+		{"/2.2/networks/12345/devices?limit=50&offset=100", "/2.2/networks/{id}/devices"},
+		{"/2.2/eeros/8318690/reboot", "/2.2/eeros/{id}/reboot"},
+		{"/2.2/networks/12345/forwards/fwd1", "/2.2/networks/{id}/forwards/{id}"},
+	}
+
+	for _, tt := range tests {
+		if got := pathPattern(tt.path); got != tt.want {
+			t.Errorf("pathPattern(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestClientStatsCountsRequests(t *testing.T) {
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(loadFixture(t, "account.json"))
+	})
+
+	if got := client.Stats(); got.Requests != 0 || len(got.Paths) != 0 {
+		t.Fatalf("new client stats = %+v, want empty", got)
+	}
+
+	for range 3 {
+		if _, err := client.GetAccount(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	client.GetProfileRaw("12345", "prof1")
+
+	stats := client.Stats()
+	if stats.Requests != 4 {
+		t.Errorf("Requests = %d, want 4", stats.Requests)
+	}
+	if len(stats.Paths) != 2 {
+		t.Fatalf("Paths = %+v, want 2 patterns", stats.Paths)
+	}
+	first := stats.Paths[0]
+	if first.Method != "GET" || first.Pattern != "/2.2/account" || first.Count != 3 {
+		t.Errorf("most frequent = %+v, want 3 × GET /2.2/account", first)
+	}
+	if stats.Paths[1].Pattern != "/2.2/networks/{id}/profiles/{id}" {
+		t.Errorf("second pattern = %q", stats.Paths[1].Pattern)
+	}
+	if stats.Duration <= 0 {
+		t.Error("Duration should accumulate request time")
+	}
+}
+
+func TestClientStatsCountsRetries(t *testing.T) {
+	client, _ := newRetryTestServer(t, 2, 503, 503)
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := client.Stats().Requests; got != 3 {
+		t.Errorf("Requests = %d, want 3 (two retries)", got)
+	}
+}
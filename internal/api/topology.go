@@ -0,0 +1,72 @@
+package api
+
+import "sort"
+
+// TopologyNode is an eero in the mesh tree with the nodes that uplink
+// through it
+type TopologyNode struct {
+	ID              string          `json:"id"`
+	Location        string          `json:"location"`
+	Gateway         bool            `json:"gateway"`
+	ConnectionType  string          `json:"connection_type"`
+	MeshQualityBars int             `json:"mesh_quality_bars"`
+	Children        []*TopologyNode `json:"children"`
+}
+
+// Topology is the mesh layout rooted at the gateway. Roots holds more than
+// one node only when the network reports no single gateway.
+type Topology struct {
+	Roots []*TopologyNode `json:"roots"`
+}
+
+// GetTopology returns the mesh layout of the network's eeros
+func (c *Client) GetTopology(networkID string) (*Topology, error) {
+	eeros, err := c.GetEeros(networkID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTopology(eeros), nil
+}
+
+// BuildTopology derives the mesh tree from the eero list. The API does not
+// report per-node upstream links, so every leaf is attached to the gateway;
+// wired leaves are listed before wireless ones, each sorted by location.
+func BuildTopology(eeros []Eero) *Topology {
+	nodes := make([]*TopologyNode, 0, len(eeros))
+	var root *TopologyNode
+	for _, e := range eeros {
+		n := &TopologyNode{
+			ID:              ExtractEeroID(e.URL),
+			Location:        e.Location,
+			Gateway:         e.Gateway,
+			ConnectionType:  e.ConnectionType,
+			MeshQualityBars: e.MeshQualityBars,
+			Children:        []*TopologyNode{},
+		}
+		if n.ConnectionType == "" {
+			n.ConnectionType = "wireless"
+			if e.Wired {
+				n.ConnectionType = "wired"
+			}
+		}
+		if root == nil && (e.Gateway || e.IsPrimaryNode) {
+			root = n
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		wi, wj := nodes[i].ConnectionType == "wired", nodes[j].ConnectionType == "wired"
+		if wi != wj {
+			return wi
+		}
+		return nodes[i].Location < nodes[j].Location
+	})
+
+	if root == nil {
+		return &Topology{Roots: nodes}
+	}
+	root.Children = nodes
+	return &Topology{Roots: []*TopologyNode{root}}
+}
@@ -0,0 +1,37 @@
+package api
+
+import "testing"
+
+func TestBuildTopology(t *testing.T) {
+	eeros := []Eero{
+		{URL: "/2.2/eeros/2", Location: "Bedroom", ConnectionType: "wireless", MeshQualityBars: 3},
+		{URL: "/2.2/eeros/1", Location: "Living Room", Gateway: true, Wired: true},
+		{URL: "/2.2/eeros/3", Location: "Office", Wired: true},
+	}
+
+	topo := BuildTopology(eeros)
+	if len(topo.Roots) != 1 {
+		t.Fatalf("len(Roots) = %d, want 1", len(topo.Roots))
+	}
+	root := topo.Roots[0]
+	if root.ID != "1" || !root.Gateway {
+		t.Errorf("root = %+v, want the gateway", root)
+	}
+	if len(root.Children) != 2 {
+		t.Fatalf("len(Children) = %d, want 2", len(root.Children))
+	}
+	// Wired leaves come first; connection type falls back to the Wired flag
+	if root.Children[0].ID != "3" || root.Children[0].ConnectionType != "wired" {
+		t.Errorf("first child = %+v, want wired Office", root.Children[0])
+	}
+	if root.Children[1].ID != "2" || root.Children[1].MeshQualityBars != 3 {
+		t.Errorf("second child = %+v, want wireless Bedroom", root.Children[1])
+	}
+}
+
+func TestBuildTopologyNoGateway(t *testing.T) {
+	topo := BuildTopology([]Eero{{URL: "/2.2/eeros/1"}, {URL: "/2.2/eeros/2"}})
+	if len(topo.Roots) != 2 {
+		t.Errorf("len(Roots) = %d, want every node as a root", len(topo.Roots))
+	}
+}
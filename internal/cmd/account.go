@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// AccountInfo is one saved account as listed by account list. The token is
+// never included.
+type AccountInfo struct {
+	Name      string `json:"name"`
+	NetworkID string `json:"network_id,omitempty"`
+	LoggedIn  bool   `json:"logged_in"`
+	Current   bool   `json:"current"`
+}
+
+// Account handles the account command
+func (a *App) Account(args []string) error {
+	if len(args) == 0 {
+		return a.ListAccounts()
+	}
+
+	switch args[0] {
+	case "list":
+		return a.ListAccounts()
+	case "use":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: account use <name>")
+		}
+		return a.UseAccount(args[1])
+	case "add":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: account add <name> [--identity <email|phone>] [--code <code>]")
+		}
+		return a.AddAccount(args[1], args[2:])
+	default:
+		return fmt.Errorf("unknown account subcommand: %s", args[0])
+	}
+}
+
+// ListAccounts lists the saved accounts, marking the one in use
+func (a *App) ListAccounts() error {
+	var accounts []AccountInfo
+	for _, name := range a.Config.AccountNames() {
+		accounts = append(accounts, AccountInfo{
+			Name:      name,
+			NetworkID: a.Config.AccountNetworkID(name),
+			LoggedIn:  a.Config.AccountLoggedIn(name),
+			Current:   name == a.Config.ActiveAccount(),
+		})
+	}
+
+	if a.structuredOutput() {
+		return a.printData(accounts)
+	}
+
+	headers := []string{"NAME", "NETWORK", "LOGGED IN", "CURRENT"}
+	var rows [][]string
+	for _, acct := range accounts {
+		loggedIn := "no"
+		if acct.LoggedIn {
+			loggedIn = "yes"
+		}
+		current := ""
+		if acct.Current {
+			current = "*"
+		}
+		rows = append(rows, []string{acct.Name, acct.NetworkID, loggedIn, current})
+	}
+
+	PrintTable(headers, rows)
+	return nil
+}
+
+// UseAccount makes a saved account the one commands use
+func (a *App) UseAccount(name string) error {
+	if !a.Config.HasAccount(name) {
+		return notFoundError("account", name, a.Config.AccountNames())
+	}
+
+	if err := a.updateConfig(func(c *config.Config) { c.UseAccount(name) }); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	a.Client.SetToken(a.Config.Token)
+
+	if a.Config.TokenFromEnv {
+		a.progressf("Note: %s is set and overrides the account's token.\n", config.EnvToken)
+	}
+	if !a.Config.HasToken() {
+		fmt.Printf("Using account '%s' (not logged in; run 'eero-cli login')\n", name)
+		return nil
+	}
+	fmt.Printf("Using account '%s'\n", name)
+	return nil
+}
+
+// AddAccount logs in to a new account and makes it the one in use. The
+// previous account is kept; the new one is only saved once login succeeds.
+func (a *App) AddAccount(name string, loginArgs []string) error {
+	if err := a.Config.AddAccount(name); err != nil {
+		return err
+	}
+	a.Config.TokenFromEnv = false
+	a.Client.SetToken("")
+	return a.Login(loginArgs)
+}
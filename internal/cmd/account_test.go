@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// addFamilyAccount saves the test app's login as the default account, then
+// adds and logs in to a "family" account
+func addFamilyAccount(t *testing.T, app *App) {
+	t.Helper()
+	if err := app.Config.Save(); err != nil {
+		t.Fatalf("saving config: %v", err)
+	}
+
+	var err error
+	feedStdin(t, "", func() {
+		captureStdout(t, func() {
+			err = app.Account([]string{"add", "family", "--identity", "me@example.com", "--code", "123456"})
+		})
+	})
+	if err != nil {
+		t.Fatalf("account add: %v", err)
+	}
+}
+
+func TestAccountAdd(t *testing.T) {
+	useTempConfigDir(t)
+	var gotIdentity, gotCode string
+	app := newTestApp(loginMock(&gotIdentity, &gotCode))
+
+	addFamilyAccount(t, app)
+
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if saved.ActiveAccount() != "family" || saved.Token != "tok-123" {
+		t.Errorf("active = %s with token %q, want family with tok-123", saved.ActiveAccount(), saved.Token)
+	}
+	// The previous login is kept under its own name
+	if saved.Accounts["default"].Token != "test-token" || saved.Accounts["default"].NetworkID != "12345" {
+		t.Errorf("default account = %+v", saved.Accounts["default"])
+	}
+}
+
+func TestAccountAddExisting(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Account([]string{"add", "default"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected already exists error, got: %v", err)
+	}
+	if app.Config.Token != "test-token" {
+		t.Errorf("Token = %q, want the current login kept", app.Config.Token)
+	}
+}
+
+func TestAccountUse(t *testing.T) {
+	useTempConfigDir(t)
+	var gotIdentity, gotCode string
+	app := newTestApp(loginMock(&gotIdentity, &gotCode))
+	addFamilyAccount(t, app)
+
+	out := captureStdout(t, func() {
+		if err := app.Account([]string{"use", "default"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Using account 'default'") {
+		t.Errorf("output = %q", out)
+	}
+	if app.Config.Token != "test-token" || app.Config.NetworkID != "12345" {
+		t.Errorf("in-memory config = %s/%s, want the default account's", app.Config.Token, app.Config.NetworkID)
+	}
+
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if saved.ActiveAccount() != "default" || saved.Token != "test-token" {
+		t.Errorf("saved active = %s with token %q, want default", saved.ActiveAccount(), saved.Token)
+	}
+	if saved.Accounts["family"].Token != "tok-123" {
+		t.Errorf("family account = %+v", saved.Accounts["family"])
+	}
+}
+
+func TestAccountUseUnknown(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Account([]string{"use", "defualt"})
+	if err == nil || !strings.Contains(err.Error(), "did you mean 'default'") {
+		t.Errorf("expected suggestion error, got: %v", err)
+	}
+}
+
+func TestAccountList(t *testing.T) {
+	useTempConfigDir(t)
+	var gotIdentity, gotCode string
+	app := newTestApp(loginMock(&gotIdentity, &gotCode))
+	addFamilyAccount(t, app)
+
+	out := captureStdout(t, func() {
+		if err := app.Account(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if got := tableColumn(out, 0); strings.Join(got, ",") != "default,family" {
+		t.Errorf("NAME column = %v, want [default family]", got)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "family") && !strings.HasSuffix(strings.TrimSpace(line), "*") {
+			t.Errorf("family should be marked current: %q", line)
+		}
+	}
+}
+
+func TestAccountListJSON(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Account([]string{"list"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var accounts []AccountInfo
+	if err := json.Unmarshal([]byte(out), &accounts); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+	}
+	want := AccountInfo{Name: "default", NetworkID: "12345", LoggedIn: true, Current: true}
+	if len(accounts) != 1 || accounts[0] != want {
+		t.Errorf("accounts = %+v, want [%+v]", accounts, want)
+	}
+	if strings.Contains(out, "test-token") {
+		t.Error("account list must not include the token")
+	}
+}
+
+func TestAccountUnknownSubcommand(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Account([]string{"bogus"})
+	if err == nil || !strings.Contains(err.Error(), "unknown account subcommand") {
+		t.Errorf("err = %v", err)
+	}
+}
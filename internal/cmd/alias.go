@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// DeviceAlias handles the devices alias subcommand
+func (a *App) DeviceAlias(args []string) error {
+	if len(args) == 0 {
+		return a.ListDeviceAliases()
+	}
+
+	switch args[0] {
+	case "list":
+		return a.ListDeviceAliases()
+	case "set":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: devices alias set <alias> <device>")
+		}
+		return a.SetDeviceAlias(args[1], strings.Join(args[2:], " "))
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: devices alias remove <alias>")
+		}
+		return a.RemoveDeviceAlias(args[1])
+	default:
+		return fmt.Errorf("unknown devices alias subcommand: %s", args[0])
+	}
+}
+
+// validAlias reports whether name is usable as an alias: letters, digits,
+// '-' and '_'
+func validAlias(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// SetDeviceAlias saves alias for the device matching deviceQuery, keyed to
+// the device's ID so it survives renames
+func (a *App) SetDeviceAlias(alias, deviceQuery string) error {
+	if !validAlias(alias) {
+		return fmt.Errorf("invalid alias: %s (use letters, digits, '-' and '_')", alias)
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	d, err := a.findDevice(networkID, deviceQuery)
+	if err != nil {
+		return err
+	}
+	deviceID := api.ExtractDeviceID(d.URL)
+
+	alias = strings.ToLower(alias)
+	err = a.updateConfig(func(c *config.Config) {
+		if c.DeviceAliases == nil {
+			c.DeviceAliases = make(map[string]string)
+		}
+		c.DeviceAliases[alias] = deviceID
+	})
+	if err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	fmt.Printf("Alias %s → %s (%s)\n", alias, d.DisplayName(), deviceID)
+	return nil
+}
+
+// RemoveDeviceAlias deletes an alias
+func (a *App) RemoveDeviceAlias(alias string) error {
+	alias = strings.ToLower(alias)
+	if _, ok := a.Config.DeviceAliases[alias]; !ok {
+		return notFoundError("alias", alias, nil)
+	}
+
+	err := a.updateConfig(func(c *config.Config) {
+		delete(c.DeviceAliases, alias)
+	})
+	if err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	fmt.Printf("Alias %s removed\n", alias)
+	return nil
+}
+
+// ListDeviceAliases shows each alias with the current name of its device
+func (a *App) ListDeviceAliases() error {
+	if len(a.Config.DeviceAliases) == 0 {
+		fmt.Println("No device aliases. Add one with 'eero-cli devices alias set <alias> <device>'.")
+		return nil
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+	names := make(map[string]string, len(devices))
+	for _, d := range devices {
+		names[api.ExtractDeviceID(d.URL)] = d.DisplayName()
+	}
+
+	aliases := make([]string, 0, len(a.Config.DeviceAliases))
+	for alias := range a.Config.DeviceAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	headers := []string{"ALIAS", "DEVICE", "NAME"}
+	var rows [][]string
+	for _, alias := range aliases {
+		deviceID := a.Config.DeviceAliases[alias]
+		name, ok := names[deviceID]
+		if !ok {
+			name = "(not on network)"
+		}
+		rows = append(rows, []string{alias, deviceID, name})
+	}
+
+	PrintTable(headers, rows)
+	return nil
+}
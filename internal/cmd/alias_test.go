@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+func TestFindDeviceAliasTakesPrecedence(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	// "nas" would otherwise match the device named NAS by name
+	app.Config.DeviceAliases = map[string]string{"nas": "aabbccdd1122"}
+
+	d, err := app.findDevice("12345", "NAS")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.DisplayName() != "My Laptop" {
+		t.Errorf("findDevice(NAS) = %q, want the aliased device", d.DisplayName())
+	}
+
+	app.Config.DeviceAliases = map[string]string{"gone": "000000000000"}
+	_, err = app.findDevice("12345", "gone")
+	if err == nil || !strings.Contains(err.Error(), "no longer on the network") {
+		t.Errorf("expected stale alias error, got: %v", err)
+	}
+}
+
+func TestSetAndListDeviceAliases(t *testing.T) {
+	useTempConfigDir(t)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	captureStdout(t, func() {
+		if err := app.DeviceAlias([]string{"set", "Box", "11:22:33:44:55:66"}); err != nil {
+			t.Fatalf("alias set: %v", err)
+		}
+	})
+
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if saved.DeviceAliases["box"] != "112233445566" {
+		t.Errorf("saved aliases = %v, want box → 112233445566", saved.DeviceAliases)
+	}
+
+	out := captureStdout(t, func() {
+		if err := app.DeviceAlias([]string{"list"}); err != nil {
+			t.Fatalf("alias list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "box") || !strings.Contains(out, "NAS") {
+		t.Errorf("alias list output missing alias or device name:\n%s", out)
+	}
+
+	if err := app.DeviceAlias([]string{"set", "bad alias!", "NAS"}); err == nil {
+		t.Error("expected error for invalid alias name")
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// BackupReservations writes every reservation, including its URL, to path
+// as a JSON array
+func (a *App) BackupReservations(path string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	reservations, err := a.Client.GetReservations(networkID)
+	if err != nil {
+		return fmt.Errorf("getting reservations: %w", err)
+	}
+	if reservations == nil {
+		reservations = []api.Reservation{}
+	}
+
+	data, err := json.MarshalIndent(reservations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("formatting JSON: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing backup: %w", err)
+	}
+
+	fmt.Printf("Saved %d reservations to %s\n", len(reservations), path)
+	return nil
+}
+
+// ReservationUpdate pairs a current reservation with its desired state
+type ReservationUpdate struct {
+	Current api.Reservation
+	Desired api.Reservation
+}
+
+// ReservationPlan lists the changes that bring the network's reservations
+// in line with a backup
+type ReservationPlan struct {
+	Create []api.Reservation
+	Update []ReservationUpdate
+	Delete []api.Reservation
+}
+
+// Empty reports whether the plan has nothing to do
+func (p ReservationPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// planReservations compares desired against current reservations by MAC.
+// Reservations missing from desired are only deleted when prune is set.
+func planReservations(desired, current []api.Reservation, prune bool) ReservationPlan {
+	var plan ReservationPlan
+
+	currentByMAC := make(map[string]api.Reservation)
+	for _, r := range current {
+		currentByMAC[normalizeMAC(r.MAC)] = r
+	}
+
+	wanted := make(map[string]bool)
+	for _, r := range desired {
+		mac := normalizeMAC(r.MAC)
+		wanted[mac] = true
+
+		cur, ok := currentByMAC[mac]
+		switch {
+		case !ok:
+			plan.Create = append(plan.Create, r)
+		case cur.IP != r.IP || cur.Description != r.Description:
+			plan.Update = append(plan.Update, ReservationUpdate{Current: cur, Desired: r})
+		}
+	}
+
+	if prune {
+		for _, r := range current {
+			if !wanted[normalizeMAC(r.MAC)] {
+				plan.Delete = append(plan.Delete, r)
+			}
+		}
+	}
+
+	return plan
+}
+
+// loadReservationBackup reads a backup written by BackupReservations
+func loadReservationBackup(path string) ([]api.Reservation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading backup: %w", err)
+	}
+
+	var reservations []api.Reservation
+	if err := json.Unmarshal(data, &reservations); err != nil {
+		return nil, fmt.Errorf("parsing backup %s: not a reservation list", path)
+	}
+
+	seen := make(map[string]bool)
+	for i, r := range reservations {
+		if r.MAC == "" || r.IP == "" {
+			return nil, fmt.Errorf("parsing backup %s: entry %d is missing a MAC or IP", path, i+1)
+		}
+		mac := normalizeMAC(r.MAC)
+		if seen[mac] {
+			return nil, fmt.Errorf("parsing backup %s: duplicate MAC %s", path, r.MAC)
+		}
+		seen[mac] = true
+	}
+
+	return reservations, nil
+}
+
+// RestoreReservations reconciles the network's reservations with a backup:
+// missing ones are created, changed ones are recreated with the backed-up
+// IP and description, and with prune, ones absent from the backup are
+// deleted. The plan is shown and confirmed (unless yes is set) first.
+// Restoring is idempotent, so a partially applied restore can be re-run.
+func (a *App) RestoreReservations(path string, prune, yes bool) error {
+	desired, err := loadReservationBackup(path)
+	if err != nil {
+		return err
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	current, err := a.Client.GetReservations(networkID)
+	if err != nil {
+		return fmt.Errorf("getting reservations: %w", err)
+	}
+
+	plan := planReservations(desired, current, prune)
+	if plan.Empty() {
+		fmt.Printf("Reservations already match %s\n", path)
+		return nil
+	}
+
+	fmt.Println("The following changes will be made:")
+	for _, r := range plan.Create {
+		fmt.Printf("  + %s  %s  %s\n", r.IP, r.MAC, r.Description)
+	}
+	for _, u := range plan.Update {
+		fmt.Printf("  ~ %s  %s\n", u.Desired.MAC, strings.Join(reservationChanges(u), ", "))
+	}
+	for _, r := range plan.Delete {
+		fmt.Printf("  - %s  %s  %s\n", r.IP, r.MAC, r.Description)
+	}
+
+	if !yes && !Confirm(a.context(), "Apply these changes?") {
+		fmt.Println("Restore cancelled")
+		return nil
+	}
+
+	// Deletes go first so their IPs are free for creates and updates
+	var created, updated, deleted, failed int
+	total := len(plan.Create) + len(plan.Update) + len(plan.Delete)
+	for _, r := range plan.Delete {
+		if err := a.Client.DeleteReservation(networkID, api.ExtractReservationID(r.URL)); err != nil {
+			fmt.Printf("Failed to delete reservation %s (%s): %v\n", r.IP, r.MAC, err)
+			failed++
+			continue
+		}
+		deleted++
+	}
+	// The API has no update, so changed reservations are recreated
+	for _, u := range plan.Update {
+		if err := a.Client.DeleteReservation(networkID, api.ExtractReservationID(u.Current.URL)); err != nil {
+			fmt.Printf("Failed to update reservation %s (%s): %v\n", u.Desired.IP, u.Desired.MAC, err)
+			failed++
+			continue
+		}
+		if err := a.Client.CreateReservation(networkID, u.Desired.IP, u.Desired.MAC, u.Desired.Description); err != nil {
+			fmt.Printf("Failed to update reservation %s (%s): %v\n", u.Desired.IP, u.Desired.MAC, err)
+			failed++
+			continue
+		}
+		updated++
+	}
+	for _, r := range plan.Create {
+		if err := a.Client.CreateReservation(networkID, r.IP, r.MAC, r.Description); err != nil {
+			fmt.Printf("Failed to create reservation %s (%s): %v\n", r.IP, r.MAC, err)
+			failed++
+			continue
+		}
+		created++
+	}
+
+	fmt.Printf("Restored reservations: %d created, %d updated, %d deleted\n", created, updated, deleted)
+	if failed > 0 {
+		return fmt.Errorf("failed to apply %d of %d changes (re-run restore to retry)", failed, total)
+	}
+	return nil
+}
+
+// reservationChanges describes how an update changes a reservation
+func reservationChanges(u ReservationUpdate) []string {
+	var changes []string
+	if u.Current.IP != u.Desired.IP {
+		changes = append(changes, fmt.Sprintf("ip %s → %s", u.Current.IP, u.Desired.IP))
+	}
+	if u.Current.Description != u.Desired.Description {
+		changes = append(changes, fmt.Sprintf("description %q → %q", u.Current.Description, u.Desired.Description))
+	}
+	return changes
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// writeBackup writes reservations to a temp backup file and returns its path
+func writeBackup(t *testing.T, reservations []api.Reservation) string {
+	t.Helper()
+	data, err := json.Marshal(reservations)
+	if err != nil {
+		t.Fatalf("marshaling backup: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "reservations.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("writing backup: %v", err)
+	}
+	return path
+}
+
+// recordingReservationsMock serves testReservations and records each
+// create and delete call
+func recordingReservationsMock(created, deleted *[]string) *mockClient {
+	return &mockClient{
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return testReservations(), nil
+		},
+		CreateReservationFn: func(networkID, ip, mac, description string) error {
+			*created = append(*created, ip+" "+mac+" "+description)
+			return nil
+		},
+		DeleteReservationFn: func(networkID, reservationID string) error {
+			*deleted = append(*deleted, reservationID)
+			return nil
+		},
+	}
+}
+
+func TestBackupReservations(t *testing.T) {
+	mock := &mockClient{
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return testReservations(), nil
+		},
+	}
+	app := newTestApp(mock)
+	path := filepath.Join(t.TempDir(), "backup.json")
+
+	captureStdout(t, func() {
+		if err := app.Reservations([]string{"backup", path}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	got, err := loadReservationBackup(path)
+	if err != nil {
+		t.Fatalf("loading backup: %v", err)
+	}
+	if !reflect.DeepEqual(got, testReservations()) {
+		t.Errorf("backup = %+v, want every reservation including its URL", got)
+	}
+}
+
+func TestRestoreReservationsCreateAndUpdate(t *testing.T) {
+	backup := []api.Reservation{
+		// Unchanged
+		{IP: "192.168.1.10", MAC: "11:22:33:44:55:66", Description: "NAS Server"},
+		// New IP for the printer
+		{IP: "192.168.1.21", MAC: "aa:bb:cc:dd:ee:ff", Description: "Printer"},
+		// Missing from the network
+		{IP: "192.168.1.30", MAC: "00:11:22:33:44:55", Description: "TV"},
+	}
+	path := writeBackup(t, backup)
+
+	var created, deleted []string
+	app := newTestApp(recordingReservationsMock(&created, &deleted))
+
+	out := captureStdout(t, func() {
+		if err := app.Reservations([]string{"restore", path, "--yes"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	sort.Strings(created)
+	wantCreated := []string{"192.168.1.21 aa:bb:cc:dd:ee:ff Printer", "192.168.1.30 00:11:22:33:44:55 TV"}
+	if !reflect.DeepEqual(created, wantCreated) {
+		t.Errorf("created = %v, want %v", created, wantCreated)
+	}
+	if !reflect.DeepEqual(deleted, []string{"res2"}) {
+		t.Errorf("deleted = %v, want only the updated printer reservation", deleted)
+	}
+	if !strings.Contains(out, "ip 192.168.1.20 → 192.168.1.21") {
+		t.Errorf("plan should describe the update:\n%s", out)
+	}
+	if !strings.Contains(out, "1 created, 1 updated, 0 deleted") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+}
+
+func TestRestoreReservationsCountsOnlySuccesses(t *testing.T) {
+	backup := []api.Reservation{
+		{IP: "192.168.1.10", MAC: "11:22:33:44:55:66", Description: "NAS Server"},
+		{IP: "192.168.1.21", MAC: "aa:bb:cc:dd:ee:ff", Description: "Printer"},
+		{IP: "192.168.1.30", MAC: "00:11:22:33:44:55", Description: "TV"},
+	}
+	path := writeBackup(t, backup)
+
+	var created, deleted []string
+	mock := recordingReservationsMock(&created, &deleted)
+	// The new TV reservation fails; the printer update goes through
+	mock.CreateReservationFn = func(networkID, ip, mac, description string) error {
+		if description == "TV" {
+			return &api.StatusError{StatusCode: 500, Message: "boom"}
+		}
+		created = append(created, ip)
+		return nil
+	}
+	app := newTestApp(mock)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = app.RestoreReservations(path, false, true)
+	})
+	if !strings.Contains(out, "Failed to create reservation 192.168.1.30") {
+		t.Errorf("output missing failure:\n%s", out)
+	}
+	if !strings.Contains(out, "0 created, 1 updated, 0 deleted") {
+		t.Errorf("summary should count only applied changes:\n%s", out)
+	}
+	if err == nil || !strings.Contains(err.Error(), "failed to apply 1 of 2 changes") {
+		t.Errorf("err = %v, want failure count", err)
+	}
+}
+
+func TestRestoreReservationsPrune(t *testing.T) {
+	path := writeBackup(t, []api.Reservation{
+		{IP: "192.168.1.10", MAC: "11:22:33:44:55:66", Description: "NAS Server"},
+	})
+
+	var created, deleted []string
+	app := newTestApp(recordingReservationsMock(&created, &deleted))
+
+	// Without --prune, extra reservations are left alone
+	out := captureStdout(t, func() {
+		if err := app.RestoreReservations(path, false, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if len(deleted) != 0 || !strings.Contains(out, "already match") {
+		t.Errorf("deleted = %v, output = %q; want no changes without --prune", deleted, out)
+	}
+
+	captureStdout(t, func() {
+		if err := app.RestoreReservations(path, true, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !reflect.DeepEqual(deleted, []string{"res2"}) || len(created) != 0 {
+		t.Errorf("deleted = %v, created = %v; want only res2 pruned", deleted, created)
+	}
+}
+
+func TestRestoreReservationsCancelled(t *testing.T) {
+	path := writeBackup(t, []api.Reservation{
+		{IP: "192.168.1.30", MAC: "00:11:22:33:44:55", Description: "TV"},
+	})
+
+	var created, deleted []string
+	app := newTestApp(recordingReservationsMock(&created, &deleted))
+
+	feedStdin(t, "n\n", func() {
+		captureStdout(t, func() {
+			if err := app.RestoreReservations(path, true, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+	if len(created) != 0 || len(deleted) != 0 {
+		t.Errorf("created = %v, deleted = %v; want nothing applied when declined", created, deleted)
+	}
+}
+
+func TestLoadReservationBackupInvalid(t *testing.T) {
+	dup := writeBackup(t, []api.Reservation{
+		{IP: "192.168.1.10", MAC: "11:22:33:44:55:66"},
+		{IP: "192.168.1.11", MAC: "11-22-33-44-55-66"},
+	})
+	if _, err := loadReservationBackup(dup); err == nil || !strings.Contains(err.Error(), "duplicate MAC") {
+		t.Errorf("expected duplicate MAC error, got: %v", err)
+	}
+
+	missing := writeBackup(t, []api.Reservation{{MAC: "11:22:33:44:55:66"}})
+	if _, err := loadReservationBackup(missing); err == nil || !strings.Contains(err.Error(), "missing a MAC or IP") {
+		t.Errorf("expected missing field error, got: %v", err)
+	}
+}
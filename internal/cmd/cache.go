@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// fetchCached calls fetch and, when the disk cache is enabled, saves a
+// successful result under key. If fetch fails because the API is unreachable
+// and --stale-ok is set, the last cached result is returned instead and a
+// notice is printed to stderr. Only read commands should use this.
+func fetchCached[T any](a *App, key string, fetch func() (T, error)) (T, error) {
+	v, err := fetch()
+	if err == nil {
+		if a.DiskCache {
+			if data, err := json.Marshal(v); err == nil {
+				// Best effort: a failed cache write shouldn't fail the command
+				config.WriteCache(key+".json", data)
+			}
+		}
+		return v, nil
+	}
+
+	if !a.StaleOK || !apiUnreachable(err) {
+		return v, err
+	}
+
+	data, cachedAt, cacheErr := config.ReadCache(key + ".json")
+	if cacheErr != nil {
+		return v, err
+	}
+	var cached T
+	if json.Unmarshal(data, &cached) != nil {
+		return v, err
+	}
+
+	fmt.Fprintf(os.Stderr, "(cached, API unreachable, data from %s)\n", cachedAt.Format("2006-01-02 15:04:05"))
+	return cached, nil
+}
+
+// apiUnreachable reports whether err means the API could not be reached or
+// failed on its end, as opposed to rejecting the request
+func apiUnreachable(err error) bool {
+	var statusErr *api.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestListDevicesStaleOKFallback(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	apiDown := false
+	mock := &mockClient{
+		ValidateTokenFn: func() bool { return !apiDown },
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			if apiDown {
+				return nil, errors.New("making request: dial tcp: connection refused")
+			}
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.DiskCache = true
+	app.StaleOK = true
+
+	// A successful fetch populates the cache
+	captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(dir, "cache", "devices-12345.json")); err != nil {
+		t.Fatalf("cache not written: %v", err)
+	}
+
+	// With the API down, the cached devices are shown
+	apiDown = true
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("expected cached fallback, got error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "My Laptop") || !strings.Contains(out, "Total: 3 devices") {
+		t.Errorf("expected cached devices, got:\n%s", out)
+	}
+
+	// Without --stale-ok the outage is an error
+	app.StaleOK = false
+	err := app.ListDevices(DeviceFilters{})
+	if err == nil {
+		t.Error("expected error without --stale-ok")
+	}
+}
+
+func TestFetchCachedDoesNotMaskClientErrors(t *testing.T) {
+	useTempConfigDir(t)
+
+	app := newTestApp(&mockClient{})
+	app.DiskCache = true
+	app.StaleOK = true
+
+	fetchCached(app, "test", func() ([]string, error) {
+		return []string{"cached"}, nil
+	})
+
+	_, err := fetchCached(app, "test", func() ([]string, error) {
+		return nil, &api.StatusError{StatusCode: 401, Message: "unauthorized"}
+	})
+	if err == nil {
+		t.Error("a 401 should not fall back to cached data")
+	}
+
+	got, err := fetchCached(app, "test", func() ([]string, error) {
+		return nil, &api.StatusError{StatusCode: 503}
+	})
+	if err != nil || len(got) != 1 || got[0] != "cached" {
+		t.Errorf("a 503 should fall back to cached data, got %v, %v", got, err)
+	}
+}
+
+func TestFetchCachedNoCache(t *testing.T) {
+	useTempConfigDir(t)
+
+	app := newTestApp(&mockClient{})
+	app.StaleOK = true
+
+	_, err := fetchCached(app, "missing", func() ([]string, error) {
+		return nil, errors.New("connection refused")
+	})
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected the original error when nothing is cached, got: %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// defaultChurnWindow is how far back devices churn looks without --since
+const defaultChurnWindow = 7 * 24 * time.Hour
+
+// ipChurn summarizes a device's recent IP changes
+type ipChurn struct {
+	Name       string    `json:"name"`
+	MAC        string    `json:"mac"`
+	IP         string    `json:"ip"`
+	Changes    int       `json:"changes"`
+	LastChange time.Time `json:"last_change"`
+	History    []string  `json:"history"`
+}
+
+// recordIPs adds the connected devices' current IPs to the IP history. It is
+// best effort: a failure shouldn't fail the command that listed the devices.
+func (a *App) recordIPs(devices []api.Device, now time.Time) {
+	if !a.TrackIPs {
+		return
+	}
+	history, err := config.LoadIPHistory()
+	if err != nil {
+		return
+	}
+	changed := false
+	for _, d := range devices {
+		if d.Connected && history.Record(d.MAC, d.IP, now) {
+			changed = true
+		}
+	}
+	if changed {
+		history.Save()
+	}
+}
+
+// ChurnDevices lists devices whose IP address changed within the window
+func (a *App) ChurnDevices(window time.Duration) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+	now := time.Now()
+	a.recordIPs(devices, now)
+
+	history, err := config.LoadIPHistory()
+	if err != nil {
+		return fmt.Errorf("loading IP history: %w", err)
+	}
+
+	if window <= 0 {
+		window = defaultChurnWindow
+	}
+	churn := ipChanges(history, devices, now.Add(-window))
+
+	headers := []string{"NAME", "MAC", "IP", "CHANGES", "LAST CHANGE", "HISTORY"}
+	var rows [][]string
+	for _, c := range churn {
+		rows = append(rows, []string{
+			c.Name,
+			c.MAC,
+			c.IP,
+			strconv.Itoa(c.Changes),
+			c.LastChange.Local().Format("2006-01-02 15:04"),
+			strings.Join(c.History, " -> "),
+		})
+	}
+
+	switch a.outputFormat() {
+	case OutputJSON, OutputYAML:
+		if churn == nil {
+			churn = []ipChurn{}
+		}
+		return a.printData(churn)
+	case OutputCSV:
+		return printCSV(headers, rows)
+	}
+
+	if len(churn) == 0 {
+		fmt.Printf("No IP changes seen in the last %s.\n", formatWindow(window))
+		if len(history) == 0 {
+			fmt.Println("IP history builds up each time 'eero-cli devices' runs.")
+		}
+		return nil
+	}
+	PrintTable(headers, rows)
+	return nil
+}
+
+// ipChanges finds devices in history whose IP changed after cutoff, most
+// changes first. Names come from devices when the MAC is still on the network.
+func ipChanges(history config.IPHistory, devices []api.Device, cutoff time.Time) []ipChurn {
+	names := make(map[string]string, len(devices))
+	for _, d := range devices {
+		names[strings.ToLower(d.MAC)] = d.DisplayName()
+	}
+
+	var churn []ipChurn
+	for mac, seen := range history {
+		c := ipChurn{MAC: mac, Name: names[mac]}
+		if c.Name == "" {
+			c.Name = mac
+		}
+		// The first sighting isn't a change
+		for i, obs := range seen {
+			if i > 0 && obs.Seen.After(cutoff) {
+				c.Changes++
+				c.LastChange = obs.Seen
+			}
+			c.History = append(c.History, obs.IP)
+		}
+		if c.Changes == 0 {
+			continue
+		}
+		c.IP = seen[len(seen)-1].IP
+		churn = append(churn, c)
+	}
+
+	sort.Slice(churn, func(i, j int) bool {
+		if churn[i].Changes != churn[j].Changes {
+			return churn[i].Changes > churn[j].Changes
+		}
+		if !churn[i].LastChange.Equal(churn[j].LastChange) {
+			return churn[i].LastChange.After(churn[j].LastChange)
+		}
+		return churn[i].MAC < churn[j].MAC
+	})
+	return churn
+}
+
+// parseSince parses a --since window: a number of days like "7d", or a
+// duration like "12h"
+func parseSince(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --since value: %s (e.g. 7d, 12h)", s)
+	}
+	return d, nil
+}
+
+// formatWindow renders a churn window, using days when it is a whole number
+func formatWindow(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		days := int(d / (24 * time.Hour))
+		if days == 1 {
+			return "day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return d.String()
+}
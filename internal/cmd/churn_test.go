@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+func TestIPChangesCountsRecentChanges(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	history := config.IPHistory{
+		// Changed twice this week
+		"aa:bb:cc:dd:11:22": {
+			{IP: "192.168.1.100", Seen: now.Add(-30 * day)},
+			{IP: "192.168.1.130", Seen: now.Add(-3 * day)},
+			{IP: "192.168.1.140", Seen: now.Add(-1 * day)},
+		},
+		// Changed once this week
+		"ee:ff:00:11:22:33": {
+			{IP: "192.168.1.101", Seen: now.Add(-2 * day)},
+			{IP: "192.168.1.102", Seen: now.Add(-2 * time.Hour)},
+		},
+		// Only changed long ago
+		"11:22:33:44:55:66": {
+			{IP: "192.168.1.50", Seen: now.Add(-60 * day)},
+			{IP: "192.168.1.10", Seen: now.Add(-40 * day)},
+		},
+		// Never changed
+		"99:99:99:99:99:99": {
+			{IP: "192.168.1.99", Seen: now.Add(-1 * day)},
+		},
+	}
+
+	churn := ipChanges(history, testDevices(), now.Add(-7*day))
+
+	if len(churn) != 2 {
+		t.Fatalf("got %d devices, want 2: %+v", len(churn), churn)
+	}
+	if c := churn[0]; c.Name != "My Laptop" || c.Changes != 2 || c.IP != "192.168.1.140" ||
+		!c.LastChange.Equal(now.Add(-1*day)) || len(c.History) != 3 {
+		t.Errorf("churn[0] = %+v", c)
+	}
+	if c := churn[1]; c.Name != "phone" || c.Changes != 1 {
+		t.Errorf("churn[1] = %+v", c)
+	}
+}
+
+func TestListDevicesRecordsIPHistory(t *testing.T) {
+	useTempConfigDir(t)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.TrackIPs = true
+
+	captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("ListDevices error: %v", err)
+		}
+	})
+
+	history, err := config.LoadIPHistory()
+	if err != nil {
+		t.Fatalf("LoadIPHistory error: %v", err)
+	}
+	if got := history["aa:bb:cc:dd:11:22"]; len(got) != 1 || got[0].IP != "192.168.1.100" {
+		t.Errorf("laptop history = %+v", got)
+	}
+	// Offline devices keep their last lease, which says nothing new
+	if _, ok := history["ee:ff:00:11:22:33"]; ok {
+		t.Error("offline device should not be recorded")
+	}
+}
+
+func TestChurnDevices(t *testing.T) {
+	useTempConfigDir(t)
+
+	history := config.IPHistory{}
+	history.Record("AA:BB:CC:DD:11:22", "192.168.1.130", time.Now().Add(-48*time.Hour))
+	if err := history.Save(); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.TrackIPs = true
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"churn", "--since", "7d"}); err != nil {
+			t.Fatalf("Devices churn error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"My Laptop", "192.168.1.130 -> 192.168.1.100"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "NAS") {
+		t.Errorf("NAS has not changed IP:\n%s", out)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	tests := map[string]time.Duration{
+		"7d":  7 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+	}
+	for in, want := range tests {
+		if got, err := parseSince(in); err != nil || got != want {
+			t.Errorf("parseSince(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0d", "-1h", "week"} {
+		if _, err := parseSince(in); err == nil {
+			t.Errorf("parseSince(%q) should fail", in)
+		}
+	}
+}
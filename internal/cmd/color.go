@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// ANSI escape codes used for terminal output
+const (
+	boldStart = "\033[1m"
+	boldEnd   = "\033[0m"
+
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorQR     = "\033[97;40m" // bright white on black
+)
+
+// ansiPattern matches ANSI SGR escape sequences
+var ansiPattern = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// bold wraps text in bold escape codes when color output is enabled
+func (a *App) bold(s string) string {
+	if !a.Color {
+		return s
+	}
+	return boldStart + s + boldEnd
+}
+
+// boldIf wraps text in bold if condition is true
+func (a *App) boldIf(s string, condition bool) string {
+	if condition {
+		return a.bold(s)
+	}
+	return s
+}
+
+// statusColor colors a device or eero status when color output is enabled:
+// green when up, yellow when paused or degraded, red when down or blocked
+func (a *App) statusColor(status string) string {
+	if !a.Color {
+		return status
+	}
+	var code string
+	switch status {
+	case "online", "connected", "green":
+		code = colorGreen
+	case "paused", "yellow":
+		code = colorYellow
+	case "offline", "disconnected", "blocked", "red":
+		code = colorRed
+	default:
+		return status
+	}
+	return code + status + colorReset
+}
+
+// visibleLen returns the number of characters s takes up on screen,
+// ignoring ANSI escape codes
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(s, ""))
+}
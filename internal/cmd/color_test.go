@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestStatusColor(t *testing.T) {
+	app := &App{Color: true}
+	tests := map[string]string{
+		"online":  colorGreen,
+		"green":   colorGreen,
+		"paused":  colorYellow,
+		"offline": colorRed,
+		"blocked": colorRed,
+	}
+	for status, code := range tests {
+		if got := app.statusColor(status); got != code+status+colorReset {
+			t.Errorf("statusColor(%q) = %q", status, got)
+		}
+	}
+	if got := app.statusColor("updating"); got != "updating" {
+		t.Errorf("unknown status should stay plain, got %q", got)
+	}
+
+	app.Color = false
+	if got := app.statusColor("online"); got != "online" {
+		t.Errorf("statusColor with color disabled = %q", got)
+	}
+}
+
+func TestPrintTableIgnoresColorCodes(t *testing.T) {
+	colored := colorGreen + "online" + colorReset
+	out := captureStdout(t, func() {
+		PrintTable([]string{"STATUS", "NAME"}, [][]string{
+			{colored, "a"},
+			{"offline", "b"},
+		})
+	})
+
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	// Both NAME cells start in the same column once escape codes are removed
+	first := ansiPattern.ReplaceAllString(lines[2], "")
+	second := lines[3]
+	if strings.Index(first, "a") != strings.Index(second, "b") {
+		t.Errorf("columns misaligned:\n%q\n%q", first, second)
+	}
+}
+
+func TestListDevicesColorsTableNotCSV(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Color = true
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("ListDevices error: %v", err)
+		}
+	})
+	if !strings.Contains(out, colorGreen+"online"+colorReset) || !strings.Contains(out, colorRed+"offline"+colorReset) {
+		t.Errorf("table statuses not colored:\n%q", out)
+	}
+
+	app.Output = OutputCSV
+	out = captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("ListDevices error: %v", err)
+		}
+	})
+	if strings.Contains(out, "\033[") {
+		t.Errorf("CSV output contains escape codes:\n%q", out)
+	}
+}
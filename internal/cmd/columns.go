@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// deviceColumn is a field that devices --format can show
+type deviceColumn struct {
+	Name   string
+	Header string
+	Value  func(d api.Device) string
+}
+
+// deviceColumns lists the fields accepted by devices --format, in the order
+// they are documented
+var deviceColumns = []deviceColumn{
+	{"id", "ID", func(d api.Device) string { return api.ExtractDeviceID(d.URL) }},
+	{"name", "NAME", func(d api.Device) string { return d.DisplayName() }},
+	{"ip", "IP", func(d api.Device) string { return d.DisplayIP() }},
+	{"mac", "MAC", func(d api.Device) string { return d.MAC }},
+	{"status", "STATUS", deviceStatus},
+	{"type", "TYPE", connType},
+	{"private", "PRIVATE", func(d api.Device) string {
+		if d.IsPrivate {
+			return "yes"
+		}
+		return "no"
+	}},
+	{"profile", "PROFILE", profileDisplay},
+	{"hostname", "HOSTNAME", func(d api.Device) string { return d.Hostname }},
+	{"nickname", "NICKNAME", func(d api.Device) string { return d.Nickname }},
+	{"device_type", "DEVICE TYPE", func(d api.Device) string { return d.DeviceType }},
+	{"last_active", "LAST ACTIVE", func(d api.Device) string { return d.LastActive }},
+	{"node", "NODE", func(d api.Device) string {
+		if d.Source == nil {
+			return ""
+		}
+		return d.Source.Location
+	}},
+}
+
+// defaultDeviceFormat is the devices table without --format
+var defaultDeviceFormat = []string{"id", "name", "ip", "mac", "status", "type", "private", "profile"}
+
+// parseDeviceFormat parses a --format value: a comma-separated list of
+// field names from deviceColumns
+func parseDeviceFormat(s string) ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		if _, ok := findDeviceColumn(f); !ok {
+			return nil, fmt.Errorf("invalid --format field: %s (valid: %s)", f, strings.Join(deviceColumnNames(), ", "))
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--format needs at least one field (valid: %s)", strings.Join(deviceColumnNames(), ", "))
+	}
+	return fields, nil
+}
+
+// findDeviceColumn looks up a --format field by name
+func findDeviceColumn(name string) (deviceColumn, bool) {
+	for _, c := range deviceColumns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return deviceColumn{}, false
+}
+
+// deviceColumnNames returns the field names accepted by --format
+func deviceColumnNames() []string {
+	names := make([]string, len(deviceColumns))
+	for i, c := range deviceColumns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// deviceHeaders returns the table headers for the given fields
+func deviceHeaders(fields []string) []string {
+	headers := make([]string, 0, len(fields))
+	for _, f := range fields {
+		c, _ := findDeviceColumn(f)
+		headers = append(headers, c.Header)
+	}
+	return headers
+}
+
+// deviceFieldsRow formats a device as a table row with the given fields
+func deviceFieldsRow(d api.Device, fields []string) []string {
+	row := make([]string, 0, len(fields))
+	for _, f := range fields {
+		c, _ := findDeviceColumn(f)
+		row = append(row, c.Value(d))
+	}
+	return row
+}
+
+// connType describes how a device is connected
+func connType(d api.Device) string {
+	if d.Wireless {
+		return "wireless"
+	}
+	return "wired"
+}
+
+// profileDisplay shows a device's profile name and ID, or Guest
+func profileDisplay(d api.Device) string {
+	if d.IsGuest {
+		return "Guest"
+	}
+	if d.Profile != nil {
+		return fmt.Sprintf("%s (%s)", d.Profile.Name, api.ExtractProfileID(d.Profile.URL))
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestParseDeviceFormat(t *testing.T) {
+	got, err := parseDeviceFormat("name, IP,profile,type")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"name", "ip", "profile", "type"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+}
+
+func TestParseDeviceFormatUnknownField(t *testing.T) {
+	_, err := parseDeviceFormat("name,speed")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "invalid --format field: speed") {
+		t.Errorf("error = %q", msg)
+	}
+	for _, name := range deviceColumnNames() {
+		if !strings.Contains(msg, name) {
+			t.Errorf("error should list valid field %q: %q", name, msg)
+		}
+	}
+}
+
+func TestParseDeviceFormatEmpty(t *testing.T) {
+	if _, err := parseDeviceFormat(" , "); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListDevicesFormat(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputCSV
+
+	out := captureStdout(t, func() {
+		err := app.ListDevices(DeviceFilters{Format: []string{"name", "ip", "profile", "type"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := "NAME,IP,PROFILE,TYPE\n" +
+		"My Laptop,192.168.1.100,Adults (prof1),wireless\n" +
+		"NAS,192.168.1.10,,wired\n" +
+		"phone,192.168.1.101,,wireless\n"
+	if out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func TestListDevicesFormatTable(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"--format=status,name"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	lines := strings.Split(out, "\n")
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "STATUS NAME" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if !strings.Contains(out, "online") || !strings.Contains(out, "My Laptop") {
+		t.Errorf("output missing row content:\n%s", out)
+	}
+	if strings.Contains(out, "192.168.1.100") || strings.Contains(out, "MAC") {
+		t.Errorf("output should only show the requested fields:\n%s", out)
+	}
+}
+
+func TestDevicesFormatUnknownField(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Devices([]string{"--format", "name,bogus"})
+	if err == nil || !strings.Contains(err.Error(), "valid: id, name") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import "fmt"
+
+// Completion handles the completion command: it prints the completion
+// script for a shell
+func Completion(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: completion <bash|zsh|fish>")
+	}
+
+	switch args[0] {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		return fmt.Errorf("unsupported shell: %s (must be bash, zsh, or fish)", args[0])
+	}
+	return nil
+}
+
+// bashCompletion completes commands, subcommands, and device IDs for the
+// device subcommands, which it gets from eero-cli itself
+const bashCompletion = `# bash completion for eero-cli
+# Load with: source <(eero-cli completion bash)
+
+_eero_cli_device_ids() {
+    eero-cli --quiet devices --format id --output csv 2>/dev/null | tail -n +2
+}
+
+_eero_cli() {
+    local cur cmd sub
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    cmd="${COMP_WORDS[1]}"
+    sub="${COMP_WORDS[2]}"
+
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=($(compgen -W "login logout status account dashboard networks devices profiles eeros guest reservations forwards internet dhcp radio wifi reboot update speedtest export completion help version" -- "$cur"))
+        return
+    fi
+
+    if [ "$COMP_CWORD" -eq 2 ]; then
+        local subs=""
+        case "$cmd" in
+            account) subs="list use add" ;;
+            networks|network) subs="list use" ;;
+            devices) subs="monitor paused blocked inspect whois locate top churn snapshot diff adopt alias pause unpause block unblock rename unprofile move set" ;;
+            profiles) subs="show inspect devices pause unpause pause-all resume-all filter add remove" ;;
+            eeros) subs="topology inspect reboot" ;;
+            guest) subs="enable disable password rename clients setup" ;;
+            reservations) subs="add remove inspect backup restore" ;;
+            forwards) subs="add remove" ;;
+            internet) subs="status pause resume" ;;
+            dhcp) subs="show set" ;;
+            radio) subs="show set" ;;
+            wifi) subs="qr" ;;
+            update) subs="status apply" ;;
+            speedtest) subs="last" ;;
+            completion) subs="bash zsh fish" ;;
+        esac
+        COMPREPLY=($(compgen -W "$subs" -- "$cur"))
+        return
+    fi
+
+    if [ "$COMP_CWORD" -eq 3 ] && [ "$cmd" = "devices" ]; then
+        case "$sub" in
+            inspect|pause|unpause|block|unblock|rename|unprofile|move|set)
+                COMPREPLY=($(compgen -W "$(_eero_cli_device_ids)" -- "$cur"))
+                ;;
+        esac
+    fi
+}
+
+complete -F _eero_cli eero-cli
+`
+
+// zshCompletion completes the same words as bashCompletion
+const zshCompletion = `#compdef eero-cli
+# zsh completion for eero-cli
+# Load with: source <(eero-cli completion zsh)
+
+_eero_cli() {
+    local -a subs
+    case $CURRENT in
+        2)
+            subs=(login logout status account dashboard networks devices profiles eeros guest reservations forwards internet dhcp radio wifi reboot update speedtest export completion help version)
+            ;;
+        3)
+            case $words[2] in
+                account) subs=(list use add) ;;
+                networks|network) subs=(list use) ;;
+                devices) subs=(monitor paused blocked inspect whois locate top churn snapshot diff adopt alias pause unpause block unblock rename unprofile move set) ;;
+                profiles) subs=(show inspect devices pause unpause pause-all resume-all filter add remove) ;;
+                eeros) subs=(topology inspect reboot) ;;
+                guest) subs=(enable disable password rename clients setup) ;;
+                reservations) subs=(add remove inspect backup restore) ;;
+                forwards) subs=(add remove) ;;
+                internet) subs=(status pause resume) ;;
+                dhcp) subs=(show set) ;;
+                radio) subs=(show set) ;;
+                wifi) subs=(qr) ;;
+                update) subs=(status apply) ;;
+                speedtest) subs=(last) ;;
+                completion) subs=(bash zsh fish) ;;
+            esac
+            ;;
+        4)
+            if [[ $words[2] == devices ]]; then
+                case $words[3] in
+                    inspect|pause|unpause|block|unblock|rename|unprofile|move|set)
+                        subs=(${(f)"$(eero-cli --quiet devices --format id --output csv 2>/dev/null | tail -n +2)"})
+                        ;;
+                esac
+            fi
+            ;;
+    esac
+    compadd -a subs
+}
+
+if [[ $zsh_eval_context[-1] == loadautofunc ]]; then
+    _eero_cli "$@"
+else
+    compdef _eero_cli eero-cli
+fi
+`
+
+// fishCompletion completes commands and subcommands
+const fishCompletion = `# fish completion for eero-cli
+# Load with: eero-cli completion fish | source
+
+set -l commands login logout status account dashboard networks devices profiles eeros guest reservations forwards internet dhcp radio wifi reboot update speedtest export completion help version
+
+complete -c eero-cli -f
+complete -c eero-cli -n "not __fish_seen_subcommand_from $commands" -a "$commands"
+complete -c eero-cli -n "__fish_seen_subcommand_from account" -a "list use add"
+complete -c eero-cli -n "__fish_seen_subcommand_from networks" -a "list use"
+complete -c eero-cli -n "__fish_seen_subcommand_from devices" -a "monitor paused blocked inspect whois locate top churn snapshot diff adopt alias pause unpause block unblock rename unprofile move set"
+complete -c eero-cli -n "__fish_seen_subcommand_from profiles" -a "show inspect devices pause unpause pause-all resume-all filter add remove"
+complete -c eero-cli -n "__fish_seen_subcommand_from eeros" -a "topology inspect reboot"
+complete -c eero-cli -n "__fish_seen_subcommand_from guest" -a "enable disable password rename clients setup"
+complete -c eero-cli -n "__fish_seen_subcommand_from reservations" -a "add remove inspect backup restore"
+complete -c eero-cli -n "__fish_seen_subcommand_from forwards" -a "add remove"
+complete -c eero-cli -n "__fish_seen_subcommand_from internet" -a "status pause resume"
+complete -c eero-cli -n "__fish_seen_subcommand_from dhcp radio" -a "show set"
+complete -c eero-cli -n "__fish_seen_subcommand_from wifi" -a "qr"
+complete -c eero-cli -n "__fish_seen_subcommand_from update" -a "status apply"
+complete -c eero-cli -n "__fish_seen_subcommand_from speedtest" -a "last"
+complete -c eero-cli -n "__fish_seen_subcommand_from completion" -a "bash zsh fish"
+`
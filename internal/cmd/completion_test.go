@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompletionScripts(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		out := captureStdout(t, func() {
+			if err := Completion([]string{shell}); err != nil {
+				t.Fatalf("%s: unexpected error: %v", shell, err)
+			}
+		})
+		if strings.TrimSpace(out) == "" {
+			t.Errorf("%s: empty script", shell)
+			continue
+		}
+		for _, want := range []string{"eero-cli", "login", "devices", "profiles", "guest", "reservations", "pause", "unpause", "block", "rename", "inspect"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s script missing %q", shell, want)
+			}
+		}
+	}
+}
+
+func TestCompletionErrors(t *testing.T) {
+	if err := Completion(nil); err == nil || !strings.HasPrefix(err.Error(), "usage:") {
+		t.Errorf("no shell: error = %v, want usage error", err)
+	}
+	if err := Completion([]string{"powershell"}); err == nil || !strings.Contains(err.Error(), "unsupported shell") {
+		t.Errorf("unknown shell: error = %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// Dashboard is a one-call summary of the network, shared by the text and
+// JSON renderers
+type Dashboard struct {
+	Account      DashboardAccount `json:"account"`
+	Eeros        []DashboardEero  `json:"eeros"`
+	DeviceCounts DeviceCounts     `json:"device_counts"`
+	Guest        DashboardGuest   `json:"guest"`
+	Update       DashboardUpdate  `json:"update"`
+}
+
+// DashboardAccount identifies the account and active network
+type DashboardAccount struct {
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	NetworkID   string `json:"network_id"`
+	NetworkName string `json:"network_name"`
+}
+
+// DashboardEero summarizes one eero node
+type DashboardEero struct {
+	ID        string `json:"id"`
+	Location  string `json:"location"`
+	Model     string `json:"model"`
+	Status    string `json:"status"`
+	Gateway   bool   `json:"gateway"`
+	Clients   int    `json:"clients"`
+	OSVersion string `json:"os_version"`
+}
+
+// DeviceCounts tallies devices by state
+type DeviceCounts struct {
+	Total    int `json:"total"`
+	Online   int `json:"online"`
+	Offline  int `json:"offline"`
+	Wireless int `json:"wireless"`
+	Wired    int `json:"wired"`
+	Guest    int `json:"guest"`
+	Paused   int `json:"paused"`
+	Blocked  int `json:"blocked"`
+}
+
+// DashboardGuest summarizes the guest network
+type DashboardGuest struct {
+	Configured bool   `json:"configured"`
+	Enabled    bool   `json:"enabled"`
+	Name       string `json:"name,omitempty"`
+}
+
+// DashboardUpdate summarizes the firmware running across the eeros
+type DashboardUpdate struct {
+	OSVersions []string `json:"os_versions"`
+	Consistent bool     `json:"consistent"`
+}
+
+// Dashboard shows a summary of the account, eeros, devices, and guest network
+func (a *App) Dashboard() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	d, err := a.buildDashboard(networkID)
+	if err != nil {
+		return err
+	}
+
+	if a.structuredOutput() {
+		return a.printData(d)
+	}
+
+	printDashboard(d)
+	return nil
+}
+
+// buildDashboard gathers the dashboard data for a network
+func (a *App) buildDashboard(networkID string) (*Dashboard, error) {
+	account, err := a.Client.GetAccount()
+	if err != nil {
+		return nil, fmt.Errorf("getting account: %w", err)
+	}
+
+	eeros, err := a.eeros(networkID)
+	if err != nil {
+		return nil, fmt.Errorf("getting eeros: %w", err)
+	}
+
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return nil, fmt.Errorf("getting devices: %w", err)
+	}
+
+	gn, guestErr := a.Client.GetGuestNetwork(networkID)
+	if guestErr != nil && !api.IsNotFound(guestErr) {
+		return nil, fmt.Errorf("getting guest network: %w", guestErr)
+	}
+
+	d := &Dashboard{
+		Account: DashboardAccount{
+			Name:      account.Name,
+			Email:     account.Email.Value,
+			NetworkID: networkID,
+		},
+		Eeros: []DashboardEero{},
+	}
+
+	for _, n := range account.Networks.Data {
+		if api.ExtractNetworkID(n.URL) == networkID {
+			d.Account.NetworkName = n.Name
+			break
+		}
+	}
+
+	versions := make(map[string]bool)
+	for _, e := range sortedBySerial(eeros) {
+		d.Eeros = append(d.Eeros, DashboardEero{
+			ID:        api.ExtractEeroID(e.URL),
+			Location:  e.Location,
+			Model:     e.Model,
+			Status:    e.Status,
+			Gateway:   e.Gateway,
+			Clients:   e.ConnectedClientsCount,
+			OSVersion: e.OSVersion,
+		})
+		if e.OSVersion != "" {
+			versions[e.OSVersion] = true
+		}
+	}
+
+	d.Update.OSVersions = []string{}
+	for v := range versions {
+		d.Update.OSVersions = append(d.Update.OSVersions, v)
+	}
+	sort.Strings(d.Update.OSVersions)
+	d.Update.Consistent = len(d.Update.OSVersions) <= 1
+
+	for _, dev := range devices {
+		c := &d.DeviceCounts
+		c.Total++
+		if dev.Connected {
+			c.Online++
+		} else {
+			c.Offline++
+		}
+		if dev.Wireless {
+			c.Wireless++
+		} else {
+			c.Wired++
+		}
+		if dev.IsGuest {
+			c.Guest++
+		}
+		if dev.Paused {
+			c.Paused++
+		}
+		if dev.Blocked {
+			c.Blocked++
+		}
+	}
+
+	if guestErr == nil && !guestUnconfigured(gn) {
+		d.Guest = DashboardGuest{Configured: true, Enabled: gn.Enabled, Name: gn.Name}
+	}
+
+	return d, nil
+}
+
+// printDashboard renders the dashboard as text
+func printDashboard(d *Dashboard) {
+	fmt.Printf("Network: %s (ID: %s)\n", d.Account.NetworkName, d.Account.NetworkID)
+	if d.Account.Name != "" {
+		fmt.Printf("Account: %s\n", d.Account.Name)
+	}
+	fmt.Println()
+
+	fmt.Printf("Eeros (%d)\n", len(d.Eeros))
+	for _, e := range d.Eeros {
+		role := ""
+		if e.Gateway {
+			role = " [gateway]"
+		}
+		fmt.Printf("  %-20s %-8s %3d clients  %s%s\n", e.Location, e.Status, e.Clients, e.Model, role)
+	}
+	fmt.Println()
+
+	c := d.DeviceCounts
+	fmt.Printf("Devices: %d total, %d online, %d offline\n", c.Total, c.Online, c.Offline)
+	fmt.Printf("         %d wireless, %d wired, %d guest, %d paused, %d blocked\n", c.Wireless, c.Wired, c.Guest, c.Paused, c.Blocked)
+	fmt.Println()
+
+	switch {
+	case !d.Guest.Configured:
+		fmt.Println("Guest:   not configured")
+	case d.Guest.Enabled:
+		fmt.Printf("Guest:   %s (enabled)\n", d.Guest.Name)
+	default:
+		fmt.Printf("Guest:   %s (disabled)\n", d.Guest.Name)
+	}
+
+	switch {
+	case len(d.Update.OSVersions) == 0:
+		fmt.Println("Firmware: unknown")
+	case d.Update.Consistent:
+		fmt.Printf("Firmware: %s\n", d.Update.OSVersions[0])
+	default:
+		fmt.Printf("Firmware: mixed (%s)\n", strings.Join(d.Update.OSVersions, ", "))
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// dashboardMock returns a mock client serving the standard test fixtures
+func dashboardMock() *mockClient {
+	return &mockClient{
+		GetAccountFn: func() (*api.Account, error) {
+			account := &api.Account{Name: "Test User"}
+			account.Networks.Data = []api.Network{{URL: "/2.2/networks/12345", Name: "Home"}}
+			return account, nil
+		},
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return guestDevices(), nil
+		},
+		GetGuestNetworkFn: func(networkID string) (*api.GuestNetwork, error) {
+			return &api.GuestNetwork{Enabled: true, Name: "Home Guest", Password: "secret123"}, nil
+		},
+	}
+}
+
+func TestDashboardJSON(t *testing.T) {
+	app := newTestApp(dashboardMock())
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Dashboard(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		t.Fatalf("output is not a JSON object: %v\n%s", err, out)
+	}
+	for _, key := range []string{"account", "eeros", "device_counts", "guest", "update"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("missing %q section", key)
+		}
+	}
+
+	var d Dashboard
+	if err := json.Unmarshal([]byte(out), &d); err != nil {
+		t.Fatalf("output does not match Dashboard: %v", err)
+	}
+	if d.Account.NetworkName != "Home" || d.Account.NetworkID != "12345" {
+		t.Errorf("account = %+v", d.Account)
+	}
+	if len(d.Eeros) != 2 || d.Eeros[0].Location != "Living Room" {
+		t.Errorf("eeros = %+v", d.Eeros)
+	}
+	want := DeviceCounts{Total: 5, Online: 3, Offline: 2, Wireless: 4, Wired: 1, Guest: 2}
+	if d.DeviceCounts != want {
+		t.Errorf("device_counts = %+v, want %+v", d.DeviceCounts, want)
+	}
+	if !d.Guest.Configured || !d.Guest.Enabled || d.Guest.Name != "Home Guest" {
+		t.Errorf("guest = %+v", d.Guest)
+	}
+	if !d.Update.Consistent || len(d.Update.OSVersions) != 1 || d.Update.OSVersions[0] != "7.2.1" {
+		t.Errorf("update = %+v", d.Update)
+	}
+	if strings.Contains(out, "secret123") {
+		t.Error("dashboard must not include the guest password")
+	}
+}
+
+func TestDashboardSortsEerosBySerial(t *testing.T) {
+	mock := dashboardMock()
+	mock.GetEerosFn = func(networkID string) ([]api.Eero, error) {
+		eeros := testEeros()
+		return []api.Eero{eeros[1], eeros[0]}, nil
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Dashboard(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var d Dashboard
+	if err := json.Unmarshal([]byte(out), &d); err != nil {
+		t.Fatalf("output does not match Dashboard: %v", err)
+	}
+	if len(d.Eeros) != 2 || d.Eeros[0].Location != "Living Room" || d.Eeros[1].Location != "Bedroom" {
+		t.Errorf("eeros = %+v, want Living Room then Bedroom", d.Eeros)
+	}
+}
+
+func TestDashboardText(t *testing.T) {
+	mock := dashboardMock()
+	mock.GetGuestNetworkFn = func(networkID string) (*api.GuestNetwork, error) {
+		return nil, &api.StatusError{StatusCode: 404}
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Dashboard(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"Network: Home (ID: 12345)", "Eeros (2)", "Living Room", "[gateway]", "Devices: 5 total, 3 online, 2 offline", "Guest:   not configured", "Firmware: 7.2.1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
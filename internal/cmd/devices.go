@@ -1,9 +1,11 @@
 package cmd
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,7 +13,7 @@ import (
 	"github.com/dorin/eero-cli/internal/api"
 )
 
-// DeviceFilters holds filter options for device listing
+// DeviceFilters holds filter and display options for device listing
 type DeviceFilters struct {
 	Profile   string
 	NoProfile bool
@@ -20,18 +22,93 @@ type DeviceFilters struct {
 	Online    bool
 	Offline   bool
 	Paused    bool
+	Blocked   bool
 	Private   bool
 	Guest     bool
 	NoGuest   bool
-	Interval  int
+	Type      string
+	Grep      string
+	PageSize  int
+	Dedupe    string
+	Format    []string
+	Sort      sortSpec
 }
 
-// Devices handles the devices command
-func (a *App) Devices(args []string) error {
-	// Parse flags
-	var filters DeviceFilters
-	var filteredArgs []string
+// MonitorOptions holds the options of devices monitor. Type, Grep, and the
+// display options of the embedded filters don't apply.
+type MonitorOptions struct {
+	DeviceFilters
+	Interval time.Duration
+	Only     []string
+	Webhook  string
+}
+
+// AdoptOptions holds the options of devices adopt
+type AdoptOptions struct {
+	Profile string
+	Type    string
+	Yes     bool
+}
+
+// selfCheckOptions control the check that refuses to pause or block this
+// machine: YesReally confirms it, NoSelfCheck skips detecting it altogether
+type selfCheckOptions struct {
+	YesReally   bool
+	NoSelfCheck bool
+}
+
+// deviceFilterFlags select devices, for listings and devices monitor
+var deviceFilterFlags = []string{
+	"--profile", "--noprofile", "--wired", "--wireless", "--online", "--offline",
+	"--paused", "--blocked", "--private", "--guest", "--noguest",
+}
+
+// deviceListFlags are the flags of a device listing
+var deviceListFlags = append(slices.Clone(deviceFilterFlags),
+	"--type", "--grep", "--page-size", "--dedupe", "--format", "--sort")
+
+// deviceArgs is a parsed devices command line: the subcommand and its
+// arguments, and the value of every flag given. Each subcommand checks that
+// it only got flags it supports, then builds its own options.
+type deviceArgs struct {
+	positional []string
+	flags      []string // flag names in the order given, e.g. "--profile"
+	invalid    []string // unknown flags, or flags missing their value
+
+	filters  DeviceFilters
+	interval time.Duration
+	only     []string
+	webhook  string
+	yes      bool
+	out      string
+	until    string
+	limit    int
+	since    time.Duration
+	pauseFor time.Duration
+	fromFile string
+	self     selfCheckOptions
+}
+
+// allow returns a usage error if a flag other than allowed was given
+func (d *deviceArgs) allow(usage string, allowed ...string) error {
+	if len(d.invalid) > 0 {
+		return fmt.Errorf("usage: %s (unknown flag or missing value: %s)", usage, d.invalid[0])
+	}
+	for _, f := range d.flags {
+		if !slices.Contains(allowed, f) {
+			return fmt.Errorf("usage: %s (%s is not supported here)", usage, f)
+		}
+	}
+	return nil
+}
+
+// parseDeviceArgs parses the devices command line. Flags may come before or
+// after the subcommand.
+func parseDeviceArgs(args []string) (*deviceArgs, error) {
+	d := &deviceArgs{}
+	filters := &d.filters
 	for i := 0; i < len(args); i++ {
+		name, _, _ := strings.Cut(args[i], "=")
 		if args[i] == "--profile" && i+1 < len(args) {
 			filters.Profile = args[i+1]
 			i++ // skip the value
@@ -47,6 +124,8 @@ func (a *App) Devices(args []string) error {
 			filters.Offline = true
 		} else if args[i] == "--paused" {
 			filters.Paused = true
+		} else if args[i] == "--blocked" {
+			filters.Blocked = true
 		} else if args[i] == "--private" {
 			filters.Private = true
 		} else if args[i] == "--guest" {
@@ -56,59 +135,390 @@ func (a *App) Devices(args []string) error {
 		} else if args[i] == "--noprofile" {
 			filters.NoProfile = true
 		} else if args[i] == "--interval" && i+1 < len(args) {
-			if v, err := strconv.Atoi(args[i+1]); err == nil {
-				filters.Interval = v
+			v, err := parseInterval(args[i+1])
+			if err != nil {
+				return nil, err
 			}
+			d.interval = v
 			i++ // skip the value
 		} else if strings.HasPrefix(args[i], "--interval=") {
-			if v, err := strconv.Atoi(strings.TrimPrefix(args[i], "--interval=")); err == nil {
-				filters.Interval = v
+			v, err := parseInterval(strings.TrimPrefix(args[i], "--interval="))
+			if err != nil {
+				return nil, err
+			}
+			d.interval = v
+		} else if args[i] == "--type" && i+1 < len(args) {
+			filters.Type = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--type=") {
+			filters.Type = strings.TrimPrefix(args[i], "--type=")
+		} else if args[i] == "--yes" || args[i] == "-y" {
+			d.yes = true
+			name = "--yes"
+		} else if args[i] == "--dedupe" {
+			filters.Dedupe = dedupeName
+		} else if strings.HasPrefix(args[i], "--dedupe=") {
+			filters.Dedupe = strings.TrimPrefix(args[i], "--dedupe=")
+			switch filters.Dedupe {
+			case dedupeName, dedupeHostname, dedupeMAC:
+			default:
+				return nil, fmt.Errorf("invalid --dedupe key: %s (must be name, hostname, or mac)", filters.Dedupe)
+			}
+		} else if args[i] == "--until" && i+1 < len(args) {
+			d.until = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--until=") {
+			d.until = strings.TrimPrefix(args[i], "--until=")
+		} else if args[i] == "--limit" && i+1 < len(args) {
+			v, err := parseLimit(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			d.limit = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--limit=") {
+			v, err := parseLimit(strings.TrimPrefix(args[i], "--limit="))
+			if err != nil {
+				return nil, err
 			}
+			d.limit = v
+		} else if args[i] == "--for" && i+1 < len(args) {
+			v, err := parseFor(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			d.pauseFor = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--for=") {
+			v, err := parseFor(strings.TrimPrefix(args[i], "--for="))
+			if err != nil {
+				return nil, err
+			}
+			d.pauseFor = v
+		} else if args[i] == "--format" && i+1 < len(args) {
+			v, err := parseDeviceFormat(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			filters.Format = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--format=") {
+			v, err := parseDeviceFormat(strings.TrimPrefix(args[i], "--format="))
+			if err != nil {
+				return nil, err
+			}
+			filters.Format = v
+		} else if args[i] == "--sort" && i+1 < len(args) {
+			v, err := parseSort(args[i+1], deviceSortFields)
+			if err != nil {
+				return nil, err
+			}
+			filters.Sort = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--sort=") {
+			v, err := parseSort(strings.TrimPrefix(args[i], "--sort="), deviceSortFields)
+			if err != nil {
+				return nil, err
+			}
+			filters.Sort = v
+		} else if args[i] == "--since" && i+1 < len(args) {
+			v, err := parseSince(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			d.since = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--since=") {
+			v, err := parseSince(strings.TrimPrefix(args[i], "--since="))
+			if err != nil {
+				return nil, err
+			}
+			d.since = v
+		} else if args[i] == "--yes-really" {
+			d.self.YesReally = true
+		} else if args[i] == "--no-self-check" {
+			d.self.NoSelfCheck = true
+		} else if args[i] == "--webhook" && i+1 < len(args) {
+			v, err := parseWebhookURL(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			d.webhook = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--webhook=") {
+			v, err := parseWebhookURL(strings.TrimPrefix(args[i], "--webhook="))
+			if err != nil {
+				return nil, err
+			}
+			d.webhook = v
+		} else if args[i] == "--only" && i+1 < len(args) {
+			d.only = append(d.only, args[i+1])
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--only=") {
+			d.only = append(d.only, strings.TrimPrefix(args[i], "--only="))
+		} else if args[i] == "--page-size" && i+1 < len(args) {
+			v, err := parsePageSize(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			filters.PageSize = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--page-size=") {
+			v, err := parsePageSize(strings.TrimPrefix(args[i], "--page-size="))
+			if err != nil {
+				return nil, err
+			}
+			filters.PageSize = v
+		} else if args[i] == "--grep" && i+1 < len(args) {
+			filters.Grep = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--grep=") {
+			filters.Grep = strings.TrimPrefix(args[i], "--grep=")
+		} else if args[i] == "--from-file" && i+1 < len(args) {
+			d.fromFile = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--from-file=") {
+			d.fromFile = strings.TrimPrefix(args[i], "--from-file=")
+		} else if args[i] == "--out" && i+1 < len(args) {
+			d.out = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--out=") {
+			d.out = strings.TrimPrefix(args[i], "--out=")
+		} else if strings.HasPrefix(args[i], "-") && args[i] != "-" {
+			d.invalid = append(d.invalid, args[i])
+			continue
 		} else {
-			filteredArgs = append(filteredArgs, args[i])
+			d.positional = append(d.positional, args[i])
+			continue
 		}
+		d.flags = append(d.flags, name)
+	}
+	return d, nil
+}
+
+// Devices handles the devices command
+func (a *App) Devices(args []string) error {
+	// set takes valued --paused/--blocked flags, which clash with the
+	// list filters, so it parses its own arguments
+	if len(args) > 0 && args[0] == "set" {
+		return a.SetDevice(args[1:])
 	}
 
-	if len(filteredArgs) == 0 {
-		return a.ListDevices(filters)
+	d, err := parseDeviceArgs(args)
+	if err != nil {
+		return err
 	}
+	const listUsage = "devices [paused|blocked] [<filters>] [--type <type>] [--grep <text>] [--page-size <n>] [--dedupe[=<key>]] [--format <columns>] [--sort <field>]"
 
-	switch filteredArgs[0] {
+	if len(d.positional) == 0 {
+		if err := d.allow(listUsage, deviceListFlags...); err != nil {
+			return err
+		}
+		return a.ListDevices(d.filters)
+	}
+
+	sub, rest := d.positional[0], d.positional[1:]
+	switch sub {
 	case "monitor":
-		return a.MonitorDevices(filters)
+		if err := d.allow("devices monitor [<filters>] [--interval <duration>] [--only <device>]... [--webhook <url>]",
+			append(slices.Clone(deviceFilterFlags), "--interval", "--only", "--webhook")...); err != nil {
+			return err
+		}
+		return a.MonitorDevices(MonitorOptions{DeviceFilters: d.filters, Interval: d.interval, Only: d.only, Webhook: d.webhook})
+	case "paused":
+		if err := d.allow(listUsage, deviceListFlags...); err != nil {
+			return err
+		}
+		d.filters.Paused = true
+		return a.ListDevices(d.filters)
+	case "blocked":
+		if err := d.allow(listUsage, deviceListFlags...); err != nil {
+			return err
+		}
+		d.filters.Blocked = true
+		return a.ListDevices(d.filters)
+	case "alias":
+		if err := d.allow("devices alias set|remove|list ..."); err != nil {
+			return err
+		}
+		return a.DeviceAlias(rest)
+	case "top":
+		if err := d.allow("devices top [--limit <n>]", "--limit"); err != nil {
+			return err
+		}
+		return a.TopDevices(d.limit)
+	case "churn":
+		if err := d.allow("devices churn [--since <duration>]", "--since"); err != nil {
+			return err
+		}
+		return a.ChurnDevices(d.since)
+	case "adopt":
+		const usage = "devices adopt --profile <name|id> [--type <type>] [--yes]"
+		if err := d.allow(usage, "--profile", "--type", "--yes"); err != nil {
+			return err
+		}
+		if d.filters.Profile == "" {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		return a.AdoptDevices(AdoptOptions{Profile: d.filters.Profile, Type: d.filters.Type, Yes: d.yes})
+	case "snapshot":
+		if err := d.allow("devices snapshot [--out <path>]", "--out"); err != nil {
+			return err
+		}
+		return a.SnapshotDevices(d.out)
+	case "diff":
+		const usage = "devices diff <snapshot.json>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		return a.DiffDevices(rest[0])
 	case "inspect":
-		if len(filteredArgs) < 2 {
-			return fmt.Errorf("usage: devices inspect <device-id>")
+		const usage = "devices inspect <device-id>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		return a.InspectDevice(rest[0])
+	case "locate":
+		const usage = "devices locate <mac>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		return a.LocateDevice(rest[0])
+	case "whois":
+		const usage = "devices whois <ip|mac>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
 		}
-		return a.InspectDevice(filteredArgs[1])
+		return a.WhoisDevice(rest[0])
 	case "pause":
-		if len(filteredArgs) < 2 {
-			return fmt.Errorf("usage: devices pause <device-id>")
+		const usage = "devices pause <device-id> [--for <duration>] [--yes-really|--no-self-check]"
+		if err := d.allow(usage, "--for", "--yes-really", "--no-self-check"); err != nil {
+			return err
 		}
-		return a.PauseDevice(filteredArgs[1], true)
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		device := rest[0]
+		if err := a.checkSelfDevice(device, "pausing", d.self); err != nil {
+			return err
+		}
+		if d.pauseFor > 0 {
+			return a.pauseFor("device "+device, "devices unpause "+device, d.pauseFor, func(pause bool) error {
+				return a.PauseDevice(device, pause)
+			})
+		}
+		return a.PauseDevice(device, true)
 	case "unpause":
-		if len(filteredArgs) < 2 {
-			return fmt.Errorf("usage: devices unpause <device-id>")
+		const usage = "devices unpause <device-id>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
 		}
-		return a.PauseDevice(filteredArgs[1], false)
+		return a.PauseDevice(rest[0], false)
 	case "block":
-		if len(filteredArgs) < 2 {
-			return fmt.Errorf("usage: devices block <device-id>")
+		const usage = "devices block <device-id> [--until <time|duration>] [--yes-really|--no-self-check]"
+		if err := d.allow(usage, "--until", "--yes-really", "--no-self-check"); err != nil {
+			return err
 		}
-		return a.BlockDevice(filteredArgs[1], true)
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		if err := a.checkSelfDevice(rest[0], "blocking", d.self); err != nil {
+			return err
+		}
+		if d.until != "" {
+			until, err := parseUntil(d.until, time.Now())
+			if err != nil {
+				return err
+			}
+			return a.BlockDeviceUntil(rest[0], until)
+		}
+		return a.BlockDevice(rest[0], true)
 	case "unblock":
-		if len(filteredArgs) < 2 {
-			return fmt.Errorf("usage: devices unblock <device-id>")
+		const usage = "devices unblock <device-id>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		return a.BlockDevice(rest[0], false)
+	case "unprofile":
+		const usage = "devices unprofile <device-id>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		return a.UnprofileDevice(strings.Join(rest, " "))
+	case "move":
+		const usage = "devices move <device-id> <profile>"
+		if err := d.allow(usage); err != nil {
+			return err
+		}
+		if len(rest) < 2 {
+			return fmt.Errorf("usage: %s", usage)
 		}
-		return a.BlockDevice(filteredArgs[1], false)
+		return a.MoveDeviceToProfile(rest[0], strings.Join(rest[1:], " "))
 	case "rename":
-		if len(filteredArgs) < 3 {
-			return fmt.Errorf("usage: devices rename <device-id> <name>")
+		const usage = "devices rename <device-id> <name> | devices rename --from-file <path>"
+		if err := d.allow(usage, "--from-file"); err != nil {
+			return err
 		}
-		return a.RenameDevice(filteredArgs[1], strings.Join(filteredArgs[2:], " "))
+		if d.fromFile != "" && len(rest) == 0 {
+			return a.RenameDevicesFromFile(d.fromFile)
+		}
+		if d.fromFile != "" || len(rest) < 2 {
+			return fmt.Errorf("usage: %s", usage)
+		}
+		return a.RenameDevice(rest[0], strings.Join(rest[1:], " "))
 	default:
-		return fmt.Errorf("unknown devices subcommand: %s", filteredArgs[0])
+		return fmt.Errorf("unknown devices subcommand: %s", sub)
+	}
+}
+
+// parseLimit parses a --limit value, which must be a positive number
+func parseLimit(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid --limit: %s (must be a positive number)", s)
+	}
+	return n, nil
+}
+
+// parsePageSize parses a --page-size value, which must be a positive number
+func parsePageSize(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid --page-size: %s (must be a positive number)", s)
+	}
+	return n, nil
+}
+
+// serverQuery translates the filters the API can apply itself into a
+// DeviceQuery. All filters are still applied client-side as a fallback.
+func (f DeviceFilters) serverQuery() api.DeviceQuery {
+	q := api.DeviceQuery{Limit: f.PageSize}
+	if f.Online != f.Offline {
+		connected := f.Online
+		q.Connected = &connected
 	}
+	return q
 }
 
 // ListDevices lists all devices on the network, optionally filtered
@@ -118,56 +528,35 @@ func (a *App) ListDevices(filters DeviceFilters) error {
 		return err
 	}
 
-	devices, err := a.Client.GetDevices(networkID)
+	query := filters.serverQuery()
+	cacheKey := "devices-" + networkID
+	if query.Connected != nil {
+		cacheKey += fmt.Sprintf("-connected-%t", *query.Connected)
+	}
+	devices, err := fetchCached(a, cacheKey, func() ([]api.Device, error) {
+		return a.Client.GetDevicesWithQuery(networkID, query)
+	})
 	if err != nil {
 		return fmt.Errorf("getting devices: %w", err)
 	}
+	a.recordIPs(devices, time.Now())
 
-	// Build profile ID to name map for resolving filter
-	var resolvedProfileName string
-	var resolvedProfileID string
-	if filters.Profile != "" {
-		profiles, err := a.Client.GetProfiles(networkID)
-		if err == nil {
-			for _, p := range profiles {
-				profileID := api.ExtractProfileID(p.URL)
-				// Check if filter matches ID or name
-				if strings.EqualFold(profileID, filters.Profile) || strings.EqualFold(p.Name, filters.Profile) {
-					resolvedProfileName = p.Name
-					resolvedProfileID = profileID
-					break
-				}
-			}
-		}
-		if resolvedProfileName == "" {
-			// No exact match found, use filter as-is for name matching
-			resolvedProfileName = filters.Profile
-		}
-	}
+	// Resolve the profile filter (a comma-separated list) to names and IDs
+	profileFilter := a.resolveProfileFilter(networkID, filters.Profile)
 
-	headers := []string{"ID", "NAME", "IP", "MAC", "STATUS", "TYPE", "PRIVATE", "PROFILE"}
-	var rows [][]string
-	var filteredCount int
+	matched := []api.Device{}
 
 	for _, d := range devices {
-		profileDisplay := ""
 		profileName := ""
 		profileID := ""
-		if d.IsGuest {
-			profileDisplay = "Guest"
-		} else if d.Profile != nil {
+		if d.Profile != nil && !d.IsGuest {
 			profileName = d.Profile.Name
 			profileID = api.ExtractProfileID(d.Profile.URL)
-			profileDisplay = fmt.Sprintf("%s (%s)", profileName, profileID)
 		}
 
-		// Apply profile filter if specified (match by name or ID)
-		if filters.Profile != "" {
-			match := strings.EqualFold(profileName, resolvedProfileName) ||
-				strings.EqualFold(profileID, filters.Profile)
-			if !match {
-				continue
-			}
+		// Apply profile filter if specified (match any listed name or ID)
+		if filters.Profile != "" && !profileFilter.matches(profileName, profileID) {
+			continue
 		}
 
 		// Apply wired/wireless filter
@@ -186,10 +575,13 @@ func (a *App) ListDevices(filters DeviceFilters) error {
 			continue
 		}
 
-		// Apply paused filter
+		// Apply paused/blocked filters
 		if filters.Paused && !d.Paused {
 			continue
 		}
+		if filters.Blocked && !d.Blocked {
+			continue
+		}
 
 		// Apply private filter
 		if filters.Private && !d.IsPrivate {
@@ -211,53 +603,70 @@ func (a *App) ListDevices(filters DeviceFilters) error {
 			continue
 		}
 
-		filteredCount++
-
-		status := "offline"
-		if d.Connected {
-			status = "online"
-		}
-		if d.Paused {
-			status = "paused"
-		}
-		if d.Blocked {
-			status = "blocked"
+		// Apply device type filter
+		if filters.Type != "" && !strings.EqualFold(d.DeviceType, filters.Type) {
+			continue
 		}
 
-		connType := "wired"
-		if d.Wireless {
-			connType = "wireless"
+		// Apply substring search
+		if filters.Grep != "" && !deviceContains(d, filters.Grep) {
+			continue
 		}
 
-		private := "no"
-		if d.IsPrivate {
-			private = "yes"
-		}
+		matched = append(matched, d)
+	}
+	filteredCount := len(matched)
 
-		deviceID := api.ExtractDeviceID(d.URL)
+	// Collapse near-duplicates after filtering
+	var counts []int
+	if filters.Dedupe != "" {
+		matched, counts = dedupeDevices(matched, filters.Dedupe)
+	}
+	sortDevices(matched, counts, filters.Sort)
+
+	fields := filters.Format
+	if fields == nil {
+		fields = defaultDeviceFormat
+	}
+	headers := deviceHeaders(fields)
+	if counts != nil {
+		headers = append(headers, "COUNT")
+	}
+	var rows [][]string
+	for i, d := range matched {
+		row := deviceFieldsRow(d, fields)
+		if counts != nil {
+			row = append(row, strconv.Itoa(counts[i]))
+		}
+		rows = append(rows, row)
+	}
 
-		rows = append(rows, []string{
-			deviceID,
-			d.DisplayName(),
-			d.DisplayIP(),
-			d.MAC,
-			status,
-			connType,
-			private,
-			profileDisplay,
-		})
+	switch a.outputFormat() {
+	case OutputJSON, OutputYAML:
+		// JSON stays in stable MAC order unless a sort was asked for
+		if filters.Sort.Field == "" {
+			return a.printData(sortedByMAC(matched))
+		}
+		return a.printData(matched)
+	case OutputCSV:
+		return printCSV(headers, rows)
+	case OutputHosts:
+		printHosts(matched)
+		return nil
 	}
 
+	// Color only the table: CSV and hosts output stay plain
+	if status := slices.Index(fields, "status"); status >= 0 {
+		for _, row := range rows {
+			row[status] = a.statusColor(row[status])
+		}
+	}
 	PrintTable(headers, rows)
 
 	// Build filter description
 	var filterParts []string
 	if filters.Profile != "" {
-		if resolvedProfileID != "" {
-			filterParts = append(filterParts, fmt.Sprintf("profile: %s [%s]", resolvedProfileName, resolvedProfileID))
-		} else {
-			filterParts = append(filterParts, fmt.Sprintf("profile: %s", filters.Profile))
-		}
+		filterParts = append(filterParts, profileFilter.describe())
 	}
 	if filters.Wired {
 		filterParts = append(filterParts, "wired")
@@ -274,6 +683,9 @@ func (a *App) ListDevices(filters DeviceFilters) error {
 	if filters.Paused {
 		filterParts = append(filterParts, "paused")
 	}
+	if filters.Blocked {
+		filterParts = append(filterParts, "blocked")
+	}
 	if filters.Private {
 		filterParts = append(filterParts, "private")
 	}
@@ -286,16 +698,108 @@ func (a *App) ListDevices(filters DeviceFilters) error {
 	if filters.NoProfile {
 		filterParts = append(filterParts, "no profile")
 	}
+	if filters.Type != "" {
+		filterParts = append(filterParts, fmt.Sprintf("type: %s", filters.Type))
+	}
+	if filters.Grep != "" {
+		filterParts = append(filterParts, fmt.Sprintf("grep: %s", filters.Grep))
+	}
 
 	if len(filterParts) > 0 {
 		fmt.Printf("\nTotal: %d devices (filtered by %s)\n", filteredCount, strings.Join(filterParts, ", "))
 	} else {
 		fmt.Printf("\nTotal: %d devices\n", len(devices))
 	}
+	if counts != nil {
+		fmt.Printf("Collapsed %d duplicates into %d rows (by %s)\n", filteredCount-len(matched), len(matched), filters.Dedupe)
+	}
 
 	return nil
 }
 
+// deviceContains reports whether the device's name, hostname, MAC, or IP
+// contains term, ignoring case
+func deviceContains(d api.Device, term string) bool {
+	term = strings.ToLower(term)
+	for _, field := range []string{d.DisplayName(), d.Hostname, d.MAC, d.IP} {
+		if strings.Contains(strings.ToLower(field), term) {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys accepted by --dedupe
+const (
+	dedupeName     = "name"
+	dedupeHostname = "hostname"
+	dedupeMAC      = "mac"
+)
+
+// dedupeKey returns the grouping key for d, or "" if d has no value for it
+func dedupeKey(d api.Device, key string) string {
+	switch key {
+	case dedupeHostname:
+		return strings.ToLower(d.Hostname)
+	case dedupeMAC:
+		return normalizeMAC(d.MAC)
+	default:
+		return strings.ToLower(d.DisplayName())
+	}
+}
+
+// dedupeDevices collapses devices sharing a key, keeping the most recently
+// seen device of each group (connected first, then by last activity) in the
+// position of the group's first device. It returns the kept devices and the
+// size of each group. Devices without a key value are never grouped.
+func dedupeDevices(devices []api.Device, key string) ([]api.Device, []int) {
+	kept := make([]api.Device, 0, len(devices))
+	var counts []int
+	index := make(map[string]int)
+
+	for _, d := range devices {
+		k := dedupeKey(d, key)
+		i, seen := index[k]
+		if k == "" || !seen {
+			if k != "" {
+				index[k] = len(kept)
+			}
+			kept = append(kept, d)
+			counts = append(counts, 1)
+			continue
+		}
+		counts[i]++
+		if moreRecent(d, kept[i]) {
+			kept[i] = d
+		}
+	}
+
+	return kept, counts
+}
+
+// moreRecent reports whether a was seen more recently than b
+func moreRecent(a, b api.Device) bool {
+	if a.Connected != b.Connected {
+		return a.Connected
+	}
+	return a.LastActive > b.LastActive
+}
+
+// deviceStatus returns a device's display status: blocked, paused, online,
+// or offline
+func deviceStatus(d api.Device) string {
+	switch {
+	case d.Blocked:
+		return "blocked"
+	case d.Paused:
+		return "paused"
+	case d.Connected:
+		return "online"
+	default:
+		return "offline"
+	}
+}
+
 // DeviceState tracks the state of a device for monitoring
 type DeviceState struct {
 	Name      string
@@ -310,58 +814,144 @@ type DeviceState struct {
 	Profile   string
 }
 
-const (
-	boldStart = "\033[1m"
-	boldEnd   = "\033[0m"
-)
+// status returns the state's status, worked out as deviceStatus does for
+// the device
+func (s DeviceState) status() string {
+	return deviceStatus(api.Device{Connected: s.Connected, Paused: s.Paused, Blocked: s.Blocked})
+}
 
-// bold wraps text in bold escape codes
-func bold(s string) string {
-	return boldStart + s + boldEnd
+// profileTerm is one entry of a --profile filter, resolved against the
+// network's profiles when possible
+type profileTerm struct {
+	Query string
+	Name  string
+	ID    string
 }
 
-// boldIf wraps text in bold if condition is true
-func boldIf(s string, condition bool) string {
-	if condition {
-		return bold(s)
+// profileFilter matches devices against any of several profiles
+type profileFilter []profileTerm
+
+// resolveProfileFilter splits a comma-separated --profile value and resolves
+// each entry to a profile name and ID. Entries that match no profile are kept
+// as-is for name matching.
+func (a *App) resolveProfileFilter(networkID, filter string) profileFilter {
+	queries := splitFields(filter)
+	if len(queries) == 0 {
+		return nil
+	}
+
+	// A lookup failure is not fatal: unresolved entries still match by name
+	profiles, _ := a.profiles(networkID)
+
+	terms := make(profileFilter, 0, len(queries))
+	for _, q := range queries {
+		term := profileTerm{Query: q, Name: q}
+		for _, p := range profiles {
+			profileID := api.ExtractProfileID(p.URL)
+			// Check if the entry matches ID or name
+			if strings.EqualFold(profileID, q) || strings.EqualFold(p.Name, q) {
+				term.Name = p.Name
+				term.ID = profileID
+				break
+			}
+		}
+		terms = append(terms, term)
 	}
-	return s
+	return terms
 }
 
-// MonitorDevices monitors devices for state changes
-func (a *App) MonitorDevices(filters DeviceFilters) error {
+// matches reports whether a device's profile is one of the filter's profiles
+func (f profileFilter) matches(profileName, profileID string) bool {
+	for _, t := range f {
+		if strings.EqualFold(profileName, t.Name) || (profileID != "" && strings.EqualFold(profileID, t.Query)) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe renders the filter for the listing footer
+func (f profileFilter) describe() string {
+	parts := make([]string, len(f))
+	for i, t := range f {
+		if t.ID != "" {
+			parts[i] = fmt.Sprintf("%s [%s]", t.Name, t.ID)
+		} else {
+			parts[i] = t.Query
+		}
+	}
+	if len(parts) == 1 {
+		return "profile: " + parts[0]
+	}
+	return "profiles: " + strings.Join(parts, ", ")
+}
+
+// Monitor polling intervals
+const (
+	defaultMonitorInterval = 10 * time.Second
+	minMonitorInterval     = time.Second
+)
+
+// parseInterval parses an --interval value: a duration such as "1m30s", or
+// a bare integer number of seconds
+func parseInterval(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if secs, atoiErr := strconv.Atoi(s); atoiErr == nil {
+		d, err = time.Duration(secs)*time.Second, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid --interval value: %s (e.g. 30, 1m30s)", s)
+	}
+	if d < minMonitorInterval {
+		return 0, fmt.Errorf("--interval must be at least %s", minMonitorInterval)
+	}
+	return d, nil
+}
+
+// MonitorDevices monitors devices for state changes. With --output json it
+// prints each change as one JSON object per line, and its notices go to
+// stderr.
+func (a *App) MonitorDevices(opts MonitorOptions) error {
 	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
 	}
 
-	interval := filters.Interval
+	interval := opts.Interval
 	if interval <= 0 {
-		interval = 10
+		interval = defaultMonitorInterval
 	}
 
 	// Resolve profile filter once
-	var resolvedProfileName string
-	if filters.Profile != "" {
-		profiles, err := a.Client.GetProfiles(networkID)
-		if err == nil {
-			for _, p := range profiles {
-				profileID := api.ExtractProfileID(p.URL)
-				if strings.EqualFold(profileID, filters.Profile) || strings.EqualFold(p.Name, filters.Profile) {
-					resolvedProfileName = p.Name
-					break
-				}
-			}
-		}
-		if resolvedProfileName == "" {
-			resolvedProfileName = filters.Profile
-		}
+	profileFilter := a.resolveProfileFilter(networkID, opts.Profile)
+
+	// Resolve --only devices once; an empty set means no restriction
+	onlyIDs, err := a.resolveDeviceIDs(networkID, opts.Only)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("Monitoring devices every %d seconds. Press Ctrl+C to stop.\n\n", interval)
+	jsonLines := a.outputFormat() == OutputJSON
+
+	if len(onlyIDs) > 0 {
+		a.progressf("Monitoring %d devices every %s. Press Ctrl+C to stop.\n\n", len(onlyIDs), interval)
+	} else {
+		a.progressf("Monitoring devices every %s. Press Ctrl+C to stop.\n\n", interval)
+	}
+
+	var hook *webhook
+	if opts.Webhook != "" {
+		hook = newWebhook(opts.Webhook)
+		a.progressf("Posting changes to %s\n\n", opts.Webhook)
+	}
+
+	// Ctrl+C cancels the request in flight and the wait between polls
+	ctx := a.context()
 
 	// Print table header
-	printMonitorHeader()
+	if !jsonLines {
+		printMonitorHeader()
+	}
 
 	// Track previous state
 	prevState := make(map[string]DeviceState)
@@ -369,13 +959,25 @@ func (a *App) MonitorDevices(filters DeviceFilters) error {
 
 	for {
 		devices, err := a.Client.GetDevices(networkID)
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
-			fmt.Printf("[%s] Error fetching devices: %v\n", time.Now().Format("15:04:05"), err)
-			time.Sleep(time.Duration(interval) * time.Second)
+			a.progressf("[%s] Error fetching devices: %v\n", time.Now().Format("15:04:05"), err)
+			if pauseWait(ctx, interval) != nil {
+				break
+			}
 			continue
 		}
 
+		seen := make(map[string]bool, len(devices))
 		for _, d := range devices {
+			deviceID := api.ExtractDeviceID(d.URL)
+			seen[deviceID] = true
+			if len(onlyIDs) > 0 && !onlyIDs[deviceID] {
+				continue
+			}
+
 			// Apply filters
 			profileName := ""
 			profileDisplay := ""
@@ -387,47 +989,47 @@ func (a *App) MonitorDevices(filters DeviceFilters) error {
 				profileDisplay = fmt.Sprintf("%s (%s)", profileName, profileID)
 			}
 
-			if filters.Profile != "" {
+			if opts.Profile != "" {
 				profileID := ""
 				if d.Profile != nil {
 					profileID = api.ExtractProfileID(d.Profile.URL)
 				}
-				match := strings.EqualFold(profileName, resolvedProfileName) ||
-					strings.EqualFold(profileID, filters.Profile)
-				if !match {
+				if !profileFilter.matches(profileName, profileID) {
 					continue
 				}
 			}
 
-			if filters.Wired && d.Wireless {
+			if opts.Wired && d.Wireless {
 				continue
 			}
-			if filters.Wireless && !d.Wireless {
+			if opts.Wireless && !d.Wireless {
 				continue
 			}
-			if filters.Online && !d.Connected {
+			if opts.Online && !d.Connected {
 				continue
 			}
-			if filters.Offline && d.Connected {
+			if opts.Offline && d.Connected {
 				continue
 			}
-			if filters.Paused && !d.Paused {
+			if opts.Paused && !d.Paused {
 				continue
 			}
-			if filters.Private && !d.IsPrivate {
+			if opts.Blocked && !d.Blocked {
 				continue
 			}
-			if filters.Guest && !d.IsGuest {
+			if opts.Private && !d.IsPrivate {
 				continue
 			}
-			if filters.NoGuest && d.IsGuest {
+			if opts.Guest && !d.IsGuest {
 				continue
 			}
-			if filters.NoProfile && d.Profile != nil {
+			if opts.NoGuest && d.IsGuest {
+				continue
+			}
+			if opts.NoProfile && d.Profile != nil {
 				continue
 			}
 
-			deviceID := api.ExtractDeviceID(d.URL)
 			currentState := DeviceState{
 				Name:      d.DisplayName(),
 				IP:        d.DisplayIP(),
@@ -457,15 +1059,62 @@ func (a *App) MonitorDevices(filters DeviceFilters) error {
 			}
 
 			if hasChanges {
-				printMonitorRow(deviceID, prev, currentState, !exists)
+				event := monitorEvent(deviceID, prev, currentState, !exists, time.Now())
+				err := a.reportMonitorEvent(event, hook, jsonLines, func() {
+					a.printMonitorRow(deviceID, prev, currentState, !exists)
+				})
+				if err != nil {
+					return err
+				}
 			}
 
 			prevState[deviceID] = currentState
 		}
 
+		// Devices missing from the response were removed from the network.
+		// A device that no longer matches the filters is still tracked.
+		for _, deviceID := range slices.Sorted(maps.Keys(prevState)) {
+			if seen[deviceID] {
+				continue
+			}
+			prev := prevState[deviceID]
+			delete(prevState, deviceID)
+			err := a.reportMonitorEvent(removedEvent(deviceID, prev, time.Now()), hook, jsonLines, func() {
+				a.printMonitorRemovedRow(deviceID, prev)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		first = false
-		time.Sleep(time.Duration(interval) * time.Second)
+		if pauseWait(ctx, interval) != nil {
+			break
+		}
+	}
+
+	a.progressf("\nStopped monitoring.\n")
+	return nil
+}
+
+// reportMonitorEvent prints a monitor event, as a JSON line or with
+// printRow, and posts it to the webhook if there is one
+func (a *App) reportMonitorEvent(event MonitorEvent, hook *webhook, jsonLines bool, printRow func()) error {
+	if jsonLines {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("formatting event: %w", err)
+		}
+		fmt.Println(string(line))
+	} else {
+		printRow()
 	}
+	if hook != nil {
+		if err := hook.Send(event); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	return nil
 }
 
 func printMonitorHeader() {
@@ -483,20 +1132,10 @@ func pad(s string, width int) string {
 	return s + strings.Repeat(" ", width-len(s))
 }
 
-func printMonitorRow(deviceID string, prev, curr DeviceState, isNew bool) {
+func (a *App) printMonitorRow(deviceID string, prev, curr DeviceState, isNew bool) {
 	timestamp := time.Now().Format("15:04:05")
 
-	// Determine status
-	status := "offline"
-	if curr.Connected {
-		status = "online"
-	}
-	if curr.Paused {
-		status = "paused"
-	}
-	if curr.Blocked {
-		status = "blocked"
-	}
+	status := curr.status()
 
 	connType := "wired"
 	if curr.Wireless {
@@ -518,56 +1157,196 @@ func printMonitorRow(deviceID string, prev, curr DeviceState, isNew bool) {
 
 	if isNew {
 		// New device - bold everything
-		name = bold(name)
-		ip = bold(ip)
-		statusPad = bold(statusPad)
-		privatePad = boldIf(privatePad, curr.IsPrivate)
+		name = a.bold(name)
+		ip = a.bold(ip)
+		statusPad = a.bold(statusPad)
+		privatePad = a.boldIf(privatePad, curr.IsPrivate)
 	} else {
 		// Bold only changed values
 		statusChanged := prev.Connected != curr.Connected || prev.Paused != curr.Paused || prev.Blocked != curr.Blocked
-		statusPad = boldIf(statusPad, statusChanged)
-		ip = boldIf(ip, prev.IP != curr.IP)
-		privatePad = boldIf(privatePad, prev.IsPrivate != curr.IsPrivate)
+		statusPad = a.boldIf(statusPad, statusChanged)
+		ip = a.boldIf(ip, prev.IP != curr.IP)
+		privatePad = a.boldIf(privatePad, prev.IsPrivate != curr.IsPrivate)
 	}
 
 	fmt.Printf("%-8s  %-12s  %s  %s  %s  %s  %s  %s  %s\n",
 		timestamp, deviceID, name, ip, mac, statusPad, connTypePad, privatePad, curr.Profile)
 }
 
-// findDeviceID finds a device by partial ID, MAC, or name
+// printMonitorRemovedRow prints a device's last known state with the status
+// "removed"
+func (a *App) printMonitorRemovedRow(deviceID string, prev DeviceState) {
+	timestamp := time.Now().Format("15:04:05")
+
+	connType := "wired"
+	if prev.Wireless {
+		connType = "wireless"
+	}
+
+	private := "no"
+	if prev.IsPrivate {
+		private = "yes"
+	}
+
+	fmt.Printf("%-8s  %-12s  %s  %s  %s  %s  %s  %s  %s\n",
+		timestamp, deviceID, pad(prev.Name, 25), pad(prev.IP, 32), pad(prev.MAC, 17),
+		a.bold(pad("removed", 7)), pad(connType, 8), pad(private, 7), prev.Profile)
+}
+
+// findDeviceID resolves a device query to its ID (see findDevice)
 func (a *App) findDeviceID(networkID, query string) (string, error) {
-	devices, err := a.Client.GetDevices(networkID)
+	d, err := a.findDevice(networkID, query)
 	if err != nil {
-		return "", fmt.Errorf("getting devices: %w", err)
+		return "", err
+	}
+	return api.ExtractDeviceID(d.URL), nil
+}
+
+// findDevice finds a device by partial ID, MAC, or name
+func (a *App) findDevice(networkID, query string) (*api.Device, error) {
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return nil, fmt.Errorf("getting devices: %w", err)
 	}
 
 	query = strings.ToLower(query)
 
-	for _, d := range devices {
+	// Aliases take precedence and resolve to an exact device ID
+	if deviceID, ok := a.Config.DeviceAliases[query]; ok {
+		for i, d := range devices {
+			if api.ExtractDeviceID(d.URL) == deviceID {
+				return &devices[i], nil
+			}
+		}
+		return nil, &codedError{code: ExitNotFound, err: fmt.Errorf("alias %s points to device %s, which is no longer on the network", query, deviceID)}
+	}
+
+	var candidates []string
+	for i, d := range devices {
 		deviceID := api.ExtractDeviceID(d.URL)
+		candidates = append(candidates, d.DisplayName())
 
 		// Exact ID match
 		if deviceID == query {
-			return deviceID, nil
+			return &devices[i], nil
 		}
 
 		// Partial ID match
 		if strings.HasPrefix(strings.ToLower(deviceID), query) {
-			return deviceID, nil
+			return &devices[i], nil
 		}
 
 		// MAC match
 		if strings.ToLower(d.MAC) == query || strings.ReplaceAll(strings.ToLower(d.MAC), ":", "") == strings.ReplaceAll(query, ":", "") {
-			return deviceID, nil
+			return &devices[i], nil
+		}
+
+		// IP match
+		if d.IP == query || hasIPv6(d, query) {
+			return &devices[i], nil
 		}
 
 		// Name match
 		if strings.EqualFold(d.DisplayName(), query) {
-			return deviceID, nil
+			return &devices[i], nil
 		}
 	}
 
-	return "", fmt.Errorf("device not found: %s", query)
+	return nil, notFoundError("device", query, candidates)
+}
+
+// hasIPv6 reports whether one of d's IPv6 addresses equals addr
+// (case-insensitive)
+func hasIPv6(d api.Device, addr string) bool {
+	for _, a := range d.IPv6Addresses {
+		if strings.EqualFold(a.Address, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// AdoptDevices assigns every device without a profile (optionally limited to
+// one device type) to the profile in opts.Profile with a single update
+func (a *App) AdoptDevices(opts AdoptOptions) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	profileID, err := a.findProfileID(networkID, opts.Profile)
+	if err != nil {
+		return err
+	}
+
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+
+	// Guests are never assigned to profiles
+	var candidates []api.Device
+	for _, d := range devices {
+		if d.Profile != nil || d.IsGuest {
+			continue
+		}
+		if opts.Type != "" && !strings.EqualFold(d.DeviceType, opts.Type) {
+			continue
+		}
+		candidates = append(candidates, d)
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("No unprofiled devices to adopt")
+		return nil
+	}
+
+	profile, err := a.Client.GetProfileDetails(networkID, profileID)
+	if err != nil {
+		return fmt.Errorf("getting profile: %w", err)
+	}
+
+	headers := []string{"ID", "NAME", "MAC", "TYPE"}
+	var rows [][]string
+	for _, d := range candidates {
+		rows = append(rows, []string{api.ExtractDeviceID(d.URL), d.DisplayName(), d.MAC, d.DeviceType})
+	}
+	fmt.Printf("The following devices will be added to profile %s:\n\n", profile.Name)
+	PrintTable(headers, rows)
+	fmt.Println()
+
+	if !opts.Yes && !Confirm(a.context(), fmt.Sprintf("Add %d devices to profile %s?", len(candidates), profile.Name)) {
+		fmt.Println("Adopt cancelled")
+		return nil
+	}
+
+	deviceURLs := make([]string, 0, len(profile.Devices)+len(candidates))
+	for _, d := range profile.Devices {
+		deviceURLs = append(deviceURLs, d.URL)
+	}
+	for _, d := range candidates {
+		deviceURLs = append(deviceURLs, fmt.Sprintf("/2.2/networks/%s/devices/%s", networkID, api.ExtractDeviceID(d.URL)))
+	}
+
+	if err := a.Client.SetProfileDevices(networkID, profileID, deviceURLs); err != nil {
+		return fmt.Errorf("updating profile: %w", err)
+	}
+
+	fmt.Printf("Added %d devices to profile %s\n", len(candidates), profile.Name)
+	return nil
+}
+
+// resolveDeviceIDs resolves each query to a device ID, returning the set of IDs
+func (a *App) resolveDeviceIDs(networkID string, queries []string) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	for _, q := range queries {
+		deviceID, err := a.findDeviceID(networkID, q)
+		if err != nil {
+			return nil, err
+		}
+		ids[deviceID] = true
+	}
+	return ids, nil
 }
 
 // PauseDevice pauses or unpauses a device
@@ -611,6 +1390,11 @@ func (a *App) BlockDevice(deviceQuery string, block bool) error {
 		return fmt.Errorf("updating device: %w", err)
 	}
 
+	// Blocking indefinitely or unblocking by hand overrides a timed block
+	if err := a.cancelScheduledUnblock(networkID, deviceID); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
 	action := "blocked"
 	if !block {
 		action = "unblocked"
@@ -641,6 +1425,151 @@ func (a *App) RenameDevice(deviceQuery, name string) error {
 	return nil
 }
 
+// UnprofileDevice removes a device from whichever profile it belongs to
+func (a *App) UnprofileDevice(deviceQuery string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	d, err := a.findDevice(networkID, deviceQuery)
+	if err != nil {
+		return err
+	}
+
+	deviceID := api.ExtractDeviceID(d.URL)
+	if d.Profile == nil {
+		fmt.Printf("Device %s (%s) is not in a profile\n", deviceID, d.DisplayName())
+		return nil
+	}
+
+	return a.RemoveDeviceFromProfile(api.ExtractProfileID(d.Profile.URL), deviceID)
+}
+
+// MoveDeviceToProfile moves a device to a profile, first removing it from
+// the profile it is in, if any
+func (a *App) MoveDeviceToProfile(deviceQuery, profileQuery string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	d, err := a.findDevice(networkID, deviceQuery)
+	if err != nil {
+		return err
+	}
+	profileID, err := a.findProfileID(networkID, profileQuery)
+	if err != nil {
+		return err
+	}
+
+	deviceID := api.ExtractDeviceID(d.URL)
+	if d.Profile != nil {
+		currentID := api.ExtractProfileID(d.Profile.URL)
+		if currentID == profileID {
+			fmt.Printf("Device %s (%s) is already in profile %s\n", deviceID, d.DisplayName(), d.Profile.Name)
+			return nil
+		}
+		if err := a.RemoveDeviceFromProfile(currentID, deviceID); err != nil {
+			return err
+		}
+	}
+
+	return a.AddDeviceToProfile(profileID, deviceID)
+}
+
+// SetDevice applies several device attributes with a single update, e.g.
+// devices set <device> --nickname X --paused true --blocked false
+func (a *App) SetDevice(args []string) error {
+	var self selfCheckOptions
+	var nickname, paused, blocked *string
+	var queryArgs []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--nickname" && i+1 < len(args) {
+			nickname = &args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--nickname=") {
+			v := strings.TrimPrefix(args[i], "--nickname=")
+			nickname = &v
+		} else if args[i] == "--paused" && i+1 < len(args) {
+			paused = &args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--paused=") {
+			v := strings.TrimPrefix(args[i], "--paused=")
+			paused = &v
+		} else if args[i] == "--blocked" && i+1 < len(args) {
+			blocked = &args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--blocked=") {
+			v := strings.TrimPrefix(args[i], "--blocked=")
+			blocked = &v
+		} else if args[i] == "--yes-really" {
+			self.YesReally = true
+		} else if args[i] == "--no-self-check" {
+			self.NoSelfCheck = true
+		} else {
+			queryArgs = append(queryArgs, args[i])
+		}
+	}
+
+	const usage = "usage: devices set <device> [--nickname <name>] [--paused true|false] [--blocked true|false]"
+	if len(queryArgs) == 0 || (nickname == nil && paused == nil && blocked == nil) {
+		return fmt.Errorf(usage)
+	}
+	deviceQuery := strings.Join(queryArgs, " ")
+
+	updates := make(map[string]interface{})
+	var changes []string
+	if nickname != nil {
+		updates["nickname"] = *nickname
+		changes = append(changes, fmt.Sprintf("nickname='%s'", *nickname))
+	}
+	for _, attr := range []struct {
+		name  string
+		value *string
+	}{{"paused", paused}, {"blocked", blocked}} {
+		if attr.value == nil {
+			continue
+		}
+		b, err := strconv.ParseBool(*attr.value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s value: %s (must be true or false)", attr.name, *attr.value)
+		}
+		updates[attr.name] = b
+		changes = append(changes, fmt.Sprintf("%s=%t", attr.name, b))
+	}
+
+	action := ""
+	if updates["blocked"] == true {
+		action = "blocking"
+	} else if updates["paused"] == true {
+		action = "pausing"
+	}
+	if action != "" {
+		if err := a.checkSelfDevice(deviceQuery, action, self); err != nil {
+			return err
+		}
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	deviceID, err := a.findDeviceID(networkID, deviceQuery)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Client.UpdateDevice(networkID, deviceID, updates); err != nil {
+		return fmt.Errorf("updating device: %w", err)
+	}
+
+	fmt.Printf("Device %s updated: %s\n", deviceID, strings.Join(changes, ", "))
+
+	return nil
+}
+
 // InspectDevice prints the full device state as JSON
 func (a *App) InspectDevice(deviceQuery string) error {
 	networkID, err := a.EnsureNetwork()
@@ -658,13 +1587,50 @@ func (a *App) InspectDevice(deviceQuery string) error {
 		return fmt.Errorf("getting device: %w", err)
 	}
 
-	// Pretty print the JSON
-	var prettyJSON bytes.Buffer
-	if err := json.Indent(&prettyJSON, rawJSON, "", "  "); err != nil {
-		return fmt.Errorf("formatting JSON: %w", err)
+	return a.printRawJSON(rawJSON)
+}
+
+// WhoisDevice shows which device owns an IP or MAC address, e.g. one seen
+// in firewall logs
+func (a *App) WhoisDevice(query string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	d, err := a.findDevice(networkID, query)
+	if err != nil {
+		return err
+	}
+
+	if a.structuredOutput() {
+		return a.printData(d)
+	}
+
+	profile := "none"
+	if d.IsGuest {
+		profile = "Guest"
+	} else if d.Profile != nil {
+		profile = d.Profile.Name
+	}
+
+	deviceType := d.DeviceType
+	if deviceType == "" {
+		deviceType = "unknown"
+	}
+
+	connType := "wired"
+	if d.Wireless {
+		connType = "wireless"
 	}
 
-	fmt.Println(prettyJSON.String())
+	fmt.Printf("Name:    %s\n", d.DisplayName())
+	fmt.Printf("ID:      %s\n", api.ExtractDeviceID(d.URL))
+	fmt.Printf("IP:      %s\n", d.DisplayIP())
+	fmt.Printf("MAC:     %s\n", d.MAC)
+	fmt.Printf("Profile: %s\n", profile)
+	fmt.Printf("Type:    %s\n", deviceType)
+	fmt.Printf("Status:  %s (%s)\n", deviceStatus(*d), connType)
 
 	return nil
 }
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dorin/eero-cli/internal/api"
 )
@@ -203,6 +206,210 @@ func TestListDevicesOnlineFilter(t *testing.T) {
 	}
 }
 
+func TestListDevicesPausedAndBlockedFilters(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			devices := testDevices()
+			devices[0].Paused = true  // My Laptop
+			devices[2].Blocked = true // NAS
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--paused"}, "aabbccdd1122"},
+		{[]string{"paused"}, "aabbccdd1122"},
+		{[]string{"--blocked"}, "112233445566"},
+		{[]string{"blocked"}, "112233445566"},
+	}
+	for _, tt := range tests {
+		out := captureStdout(t, func() {
+			if err := app.Devices(tt.args); err != nil {
+				t.Fatalf("%v: unexpected error: %v", tt.args, err)
+			}
+		})
+		if ids := tableColumn(out, 0); len(ids) != 1 || ids[0] != tt.want {
+			t.Errorf("%v: IDs = %v, want [%s]", tt.args, ids, tt.want)
+		}
+		filter := strings.TrimPrefix(tt.args[0], "--")
+		if !strings.Contains(out, "filtered by "+filter) {
+			t.Errorf("%v: footer missing filter:\n%s", tt.args, out)
+		}
+	}
+}
+
+func TestMonitorDevicesBlockedFilter(t *testing.T) {
+	polls := 0
+	stopAfterPolls(t, &polls, 2)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			polls++
+			devices := testDevices()
+			if polls == 2 {
+				devices[0].Blocked = true
+				devices[0].Connected = false
+				devices[1].Connected = true // not blocked: ignored
+			}
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.MonitorDevices(MonitorOptions{DeviceFilters: DeviceFilters{Blocked: true}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "aabbccdd1122") {
+		t.Errorf("output missing newly blocked device:\n%s", out)
+	}
+	if strings.Contains(out, "eeff00112233") {
+		t.Errorf("output should not contain unblocked device:\n%s", out)
+	}
+}
+
+func TestListDevicesGrep(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	tests := []struct {
+		term string
+		want []string
+	}{
+		{"nas", []string{"112233445566"}},           // name, any case
+		{"SERVER", []string{"112233445566"}},        // hostname
+		{"ee:ff:00", []string{"eeff00112233"}},      // MAC
+		{"192.168.1.101", []string{"eeff00112233"}}, // IP
+		// A substring, so .100 and .101 match too
+		{"192.168.1.10", []string{"aabbccdd1122", "112233445566", "eeff00112233"}},
+	}
+	for _, tt := range tests {
+		out := captureStdout(t, func() {
+			if err := app.Devices([]string{"--grep", tt.term}); err != nil {
+				t.Fatalf("--grep %s: unexpected error: %v", tt.term, err)
+			}
+		})
+		if ids := tableColumn(out, 0); !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("--grep %s: IDs = %v, want %v", tt.term, ids, tt.want)
+		}
+		if !strings.Contains(out, "filtered by grep: "+tt.term) {
+			t.Errorf("--grep %s: footer missing filter:\n%s", tt.term, out)
+		}
+	}
+}
+
+func TestListDevicesPassesServerQuery(t *testing.T) {
+	var gotQuery api.DeviceQuery
+	mock := &mockClient{
+		GetDevicesWithQueryFn: func(networkID string, q api.DeviceQuery) ([]api.Device, error) {
+			gotQuery = q
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{Online: true, PageSize: 50}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if gotQuery.Connected == nil || !*gotQuery.Connected {
+		t.Errorf("Connected = %v, want true", gotQuery.Connected)
+	}
+	if gotQuery.Limit != 50 {
+		t.Errorf("Limit = %d, want 50", gotQuery.Limit)
+	}
+	// The server returned an offline device anyway; client-side filtering drops it
+	if strings.Contains(out, "phone") {
+		t.Error("output should not contain offline device 'phone'")
+	}
+}
+
+func TestDeviceFiltersServerQuery(t *testing.T) {
+	if q := (DeviceFilters{}).serverQuery(); q.Connected != nil || q.Limit != 0 {
+		t.Errorf("empty filters produced query %+v", q)
+	}
+	if q := (DeviceFilters{Offline: true}).serverQuery(); q.Connected == nil || *q.Connected {
+		t.Errorf("--offline should request connected=false, got %v", q.Connected)
+	}
+	if q := (DeviceFilters{Online: true, Offline: true}).serverQuery(); q.Connected != nil {
+		t.Error("--online with --offline should not push a connected filter")
+	}
+}
+
+func TestListDevicesJSON(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{Online: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var devices []api.Device
+	if err := json.Unmarshal([]byte(out), &devices); err != nil {
+		t.Fatalf("output is not a JSON device array: %v\n%s", err, out)
+	}
+	if len(devices) != 2 {
+		t.Errorf("len(devices) = %d, want 2 online devices", len(devices))
+	}
+}
+
+func TestListDevicesJSONEnvelope(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+	app.Envelope = true
+	app.Command = "devices"
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var env struct {
+		Meta map[string]string `json:"meta"`
+		Data []api.Device      `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		t.Fatalf("output is not an envelope: %v\n%s", err, out)
+	}
+	if env.Meta["network_id"] != "12345" {
+		t.Errorf("meta.network_id = %q, want 12345", env.Meta["network_id"])
+	}
+	if env.Meta["command"] != "devices" {
+		t.Errorf("meta.command = %q, want devices", env.Meta["command"])
+	}
+	if _, err := time.Parse(time.RFC3339, env.Meta["generated_at"]); err != nil {
+		t.Errorf("meta.generated_at = %q is not RFC3339: %v", env.Meta["generated_at"], err)
+	}
+	if len(env.Data) != len(testDevices()) {
+		t.Errorf("len(data) = %d, want %d", len(env.Data), len(testDevices()))
+	}
+}
+
 func TestListDevicesPrivateFilter(t *testing.T) {
 	mock := &mockClient{
 		GetDevicesFn: func(networkID string) ([]api.Device, error) {
@@ -253,6 +460,40 @@ func TestListDevicesProfileFilter(t *testing.T) {
 	}
 }
 
+func TestListDevicesProfileFilterList(t *testing.T) {
+	devices := testDevices()
+	devices[1].Profile = &struct {
+		URL  string `json:"url"`
+		Name string `json:"name"`
+	}{URL: "/2.2/networks/12345/profiles/prof2", Name: "Kids"}
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return devices, nil
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{Profile: "adults, prof2"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "My Laptop") || !strings.Contains(out, "phone") {
+		t.Errorf("output should contain devices from both profiles:\n%s", out)
+	}
+	if strings.Contains(out, "NAS") {
+		t.Error("output should not contain device without a listed profile")
+	}
+	if !strings.Contains(out, "profiles: Adults [prof1], Kids [prof2]") {
+		t.Errorf("footer should list every requested profile:\n%s", out)
+	}
+}
+
 func TestPauseDevice(t *testing.T) {
 	var pausedID string
 	var pauseValue bool
@@ -439,3 +680,775 @@ func TestDevicesCommandRouting(t *testing.T) {
 		t.Errorf("expected unknown error, got: %v", err)
 	}
 }
+
+func TestDevicesInspectRouting(t *testing.T) {
+	var gotID string
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		GetDeviceRawFn: func(networkID, deviceID string) (json.RawMessage, error) {
+			gotID = deviceID
+			return json.RawMessage(`{"mac":"AA:BB:CC:DD:11:22"}`), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"inspect", "My Laptop"}); err != nil {
+			t.Fatalf("Devices inspect routing: %v", err)
+		}
+	})
+	if gotID != "aabbccdd1122" {
+		t.Errorf("inspected device %q, want aabbccdd1122", gotID)
+	}
+	if !strings.Contains(out, `"mac": "AA:BB:CC:DD:11:22"`) {
+		t.Errorf("output missing device JSON:\n%s", out)
+	}
+
+	err := app.Devices([]string{"inspect"})
+	if err == nil || !strings.Contains(err.Error(), "usage: devices inspect") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
+
+func TestResolveDeviceIDs(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	ids, err := app.resolveDeviceIDs("12345", []string{"NAS", "EE:FF:00:11:22:33"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || !ids["112233445566"] || !ids["eeff00112233"] {
+		t.Errorf("ids = %v, want NAS and phone IDs", ids)
+	}
+
+	_, err = app.resolveDeviceIDs("12345", []string{"NAS", "nonexistent"})
+	if err == nil || !strings.Contains(err.Error(), "device not found") {
+		t.Errorf("expected device not found error, got: %v", err)
+	}
+}
+
+func TestAdoptDevices(t *testing.T) {
+	var calls int
+	var gotProfileID string
+	var gotURLs []string
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			devices := testDevices()
+			devices = append(devices, api.Device{
+				URL:     "/2.2/networks/12345/devices/guest00000001",
+				MAC:     "00:00:00:00:00:01",
+				IsGuest: true,
+			})
+			return devices, nil
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return []api.Profile{{URL: "/2.2/networks/12345/profiles/prof2", Name: "Kids"}}, nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			return &api.ProfileDetails{
+				Name: "Kids",
+				Devices: []struct {
+					URL string `json:"url"`
+				}{{URL: "/2.2/networks/12345/devices/existing"}},
+			}, nil
+		},
+		SetProfileDevicesFn: func(networkID, profileID string, deviceURLs []string) error {
+			calls++
+			gotProfileID = profileID
+			gotURLs = deviceURLs
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"adopt", "--profile", "Kids", "--yes"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if calls != 1 {
+		t.Fatalf("SetProfileDevices called %d times, want 1", calls)
+	}
+	if gotProfileID != "prof2" {
+		t.Errorf("profileID = %q, want %q", gotProfileID, "prof2")
+	}
+	// Existing member plus the two unprofiled, non-guest devices
+	want := []string{
+		"/2.2/networks/12345/devices/existing",
+		"/2.2/networks/12345/devices/eeff00112233",
+		"/2.2/networks/12345/devices/112233445566",
+	}
+	if strings.Join(gotURLs, ",") != strings.Join(want, ",") {
+		t.Errorf("deviceURLs = %v, want %v", gotURLs, want)
+	}
+	if !strings.Contains(out, "Added 2 devices to profile Kids") {
+		t.Errorf("output missing summary, got:\n%s", out)
+	}
+}
+
+func TestAdoptDevicesByType(t *testing.T) {
+	var gotURLs []string
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			devices := testDevices()
+			devices[1].DeviceType = "phone"
+			devices[2].DeviceType = "nas"
+			return devices, nil
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return []api.Profile{{URL: "/2.2/networks/12345/profiles/prof2", Name: "Kids"}}, nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			return &api.ProfileDetails{Name: "Kids"}, nil
+		},
+		SetProfileDevicesFn: func(networkID, profileID string, deviceURLs []string) error {
+			gotURLs = deviceURLs
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var out string
+	feedStdin(t, "y\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.Devices([]string{"adopt", "--profile", "Kids", "--type", "phone"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	if len(gotURLs) != 1 || gotURLs[0] != "/2.2/networks/12345/devices/eeff00112233" {
+		t.Errorf("deviceURLs = %v, want only the phone", gotURLs)
+	}
+	if strings.Contains(out, "NAS") {
+		t.Errorf("NAS should not be listed for --type phone, got:\n%s", out)
+	}
+}
+
+func TestAdoptDevicesRequiresProfile(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Devices([]string{"adopt"})
+	if err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30", 30 * time.Second},
+		{"1m", time.Minute},
+		{"1m30s", 90 * time.Second},
+	}
+	for _, tt := range tests {
+		got, err := parseInterval(tt.in)
+		if err != nil {
+			t.Errorf("parseInterval(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseInterval(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"soon", "0", "500ms", "-5"} {
+		if _, err := parseInterval(in); err == nil {
+			t.Errorf("parseInterval(%q) expected error", in)
+		}
+	}
+}
+
+func TestMonitorDevicesStopsWhenCancelled(t *testing.T) {
+	waits := fakePauseWait(t, context.Canceled)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.MonitorDevices(MonitorOptions{Interval: 30 * time.Second}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Stopped monitoring.") {
+		t.Errorf("output missing stop message:\n%s", out)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 30*time.Second {
+		t.Errorf("waits = %v, want [30s]", *waits)
+	}
+}
+
+func TestMonitorDevicesCancelledDuringFetch(t *testing.T) {
+	waits := fakePauseWait(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	polls := 0
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			polls++
+			cancel() // Ctrl+C while the request is in flight
+			return nil, context.Canceled
+		},
+	}
+	app := newTestApp(mock)
+	app.ctx = ctx
+
+	out := captureStdout(t, func() {
+		if err := app.MonitorDevices(MonitorOptions{Interval: 30 * time.Second}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Stopped monitoring.") || strings.Contains(out, "Error fetching") {
+		t.Errorf("output = %q, want only the stop message", out)
+	}
+	if polls != 1 || len(*waits) != 0 {
+		t.Errorf("polls = %d, waits = %v; want 1 poll and no wait", polls, *waits)
+	}
+}
+
+// stopAfterPolls replaces pauseWait for a test with one that stops the
+// monitor once *polls reaches n
+func stopAfterPolls(t *testing.T, polls *int, n int) {
+	t.Helper()
+	old := pauseWait
+	pauseWait = func(ctx context.Context, d time.Duration) error {
+		if *polls >= n {
+			return context.Canceled
+		}
+		return nil
+	}
+	t.Cleanup(func() { pauseWait = old })
+}
+
+func TestMonitorDevicesJSONLines(t *testing.T) {
+	polls := 0
+	stopAfterPolls(t, &polls, 3)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			polls++
+			devices := testDevices()
+			switch polls {
+			case 2:
+				devices[1].Connected = true // phone comes online
+			case 3:
+				devices[1].Connected = true
+				devices[0].Paused = true // laptop is paused
+			}
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	var out string
+	stderr := captureStderr(t, func() {
+		out = captureStdout(t, func() {
+			if err := app.MonitorDevices(MonitorOptions{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), out)
+	}
+	var events []MonitorEvent
+	for _, line := range lines {
+		var e MonitorEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line is not JSON: %v\n%s", err, line)
+		}
+		events = append(events, e)
+	}
+
+	if events[0].Event != "connected" || events[0].DeviceID != "eeff00112233" {
+		t.Errorf("events[0] = %+v, want phone connected", events[0])
+	}
+	if c := events[0].Changes["connected"]; c.Before != false || c.After != true {
+		t.Errorf("events[0] connected change = %+v, want false -> true", c)
+	}
+	if events[1].Event != "paused" || events[1].DeviceID != "aabbccdd1122" || events[1].PreviousStatus != "online" {
+		t.Errorf("events[1] = %+v, want laptop paused", events[1])
+	}
+	if _, ok := events[1].Changes["paused"]; !ok || len(events[1].Changes) != 1 {
+		t.Errorf("events[1] changes = %+v, want only paused", events[1].Changes)
+	}
+
+	// Notices go to stderr so stdout stays parseable
+	if !strings.Contains(stderr, "Monitoring devices") || !strings.Contains(stderr, "Stopped monitoring.") {
+		t.Errorf("stderr = %q, want monitoring notices", stderr)
+	}
+}
+
+func TestMonitorDevicesRemoved(t *testing.T) {
+	polls := 0
+	stopAfterPolls(t, &polls, 2)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			polls++
+			devices := testDevices()
+			if polls == 2 {
+				devices = devices[:2] // the NAS is forgotten
+			}
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.MonitorDevices(MonitorOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var removed []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "removed") {
+			removed = append(removed, line)
+		}
+	}
+	if len(removed) != 1 || !strings.Contains(removed[0], "112233445566") || !strings.Contains(removed[0], "NAS") {
+		t.Errorf("removed rows = %q, want one for the NAS\n%s", removed, out)
+	}
+}
+
+func TestMonitorDevicesRemovedJSON(t *testing.T) {
+	polls := 0
+	stopAfterPolls(t, &polls, 3)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			polls++
+			devices := testDevices()
+			if polls >= 2 {
+				devices = devices[:2]
+			}
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	var out string
+	captureStderr(t, func() {
+		out = captureStdout(t, func() {
+			if err := app.MonitorDevices(MonitorOptions{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	// Reported once, then no longer tracked
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want 1:\n%s", len(lines), out)
+	}
+	var e MonitorEvent
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil {
+		t.Fatalf("line is not JSON: %v\n%s", err, lines[0])
+	}
+	if e.Event != "removed" || e.DeviceID != "112233445566" || e.PreviousStatus == "" {
+		t.Errorf("event = %+v, want NAS removed", e)
+	}
+}
+
+func TestDevicesInvalidPageSize(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	for _, args := range [][]string{{"--page-size", "abc"}, {"--page-size=-5"}, {"--page-size", "0"}} {
+		err := app.Devices(args)
+		if err == nil || !strings.Contains(err.Error(), "invalid --page-size") {
+			t.Errorf("%v: err = %v, want invalid --page-size", args, err)
+		}
+	}
+}
+
+func TestDevicesInvalidInterval(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Devices([]string{"monitor", "--interval", "often"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --interval") {
+		t.Errorf("expected invalid --interval error, got: %v", err)
+	}
+}
+
+func TestDevicesRejectsUnsupportedFlags(t *testing.T) {
+	// No API calls are mocked: the flags must be rejected before any request
+	app := newTestApp(&mockClient{})
+
+	for _, args := range [][]string{
+		{"--webhook", "http://example.com/hook"},
+		{"--until", "07:00"},
+		{"paused", "--limit", "5"},
+		{"monitor", "--grep", "nas"},
+		{"top", "--since", "7d"},
+		{"churn", "--out", "snap.json"},
+		{"snapshot", "--from-file", "names.csv"},
+		{"adopt", "--profile", "Kids", "--wired"},
+		{"pause", "NAS", "--until", "07:00"},
+		{"block", "NAS", "--for", "1h"},
+		{"unpause", "NAS", "--for", "1h"},
+		{"rename", "NAS", "Storage", "--out", "x"},
+		{"inspect", "NAS", "--online"},
+		{"pause", "NAS", "--fro", "1h"},
+		{"pause", "NAS", "--for"},
+	} {
+		err := app.Devices(args)
+		if err == nil || !strings.HasPrefix(err.Error(), "usage: devices") {
+			t.Errorf("%v: err = %v, want a usage error", args, err)
+			continue
+		}
+		if got := ExitCode(err); got != ExitFailure {
+			t.Errorf("%v: exit code = %d, want %d", args, got, ExitFailure)
+		}
+	}
+}
+
+func TestDedupeDevices(t *testing.T) {
+	devices := []api.Device{
+		{URL: "/d/1", Hostname: "iphone", IP: "192.168.1.50", LastActive: "2024-01-01T10:00:00Z"},
+		{URL: "/d/2", Hostname: "nas", IP: "192.168.1.10", Connected: true},
+		{URL: "/d/3", Hostname: "iPhone", IP: "192.168.1.51", LastActive: "2024-01-02T10:00:00Z"},
+		{URL: "/d/4", MAC: "AA:BB:CC:00:00:01"},
+		{URL: "/d/5", Hostname: "iphone", IP: "192.168.1.52", LastActive: "2023-12-01T10:00:00Z"},
+	}
+
+	kept, counts := dedupeDevices(devices, dedupeHostname)
+
+	if len(kept) != 3 {
+		t.Fatalf("len(kept) = %d, want 3: %+v", len(kept), kept)
+	}
+	if kept[0].IP != "192.168.1.51" || counts[0] != 3 {
+		t.Errorf("iphone group = %s x%d, want most recent IP 192.168.1.51 x3", kept[0].IP, counts[0])
+	}
+	if kept[1].Hostname != "nas" || counts[1] != 1 {
+		t.Errorf("second row = %s x%d, want nas x1", kept[1].Hostname, counts[1])
+	}
+	if kept[2].URL != "/d/4" {
+		t.Errorf("device without a hostname should be kept as-is, got %+v", kept[2])
+	}
+}
+
+func TestListDevicesDedupe(t *testing.T) {
+	devices := testDevices()
+	dup := devices[0]
+	dup.URL = "/2.2/networks/12345/devices/aabbccdd9999"
+	dup.MAC = "AA:BB:CC:DD:99:99"
+	dup.IP = "192.168.1.150"
+	dup.Connected = false
+	devices = append(devices, dup)
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = app.Devices([]string{"--dedupe"})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(out, "192.168.1.150") {
+		t.Error("the offline duplicate should be collapsed into the online device")
+	}
+	if !strings.Contains(out, "COUNT") {
+		t.Error("output missing COUNT column")
+	}
+	if !strings.Contains(out, "Collapsed 1 duplicates into 3 rows (by name)") {
+		t.Errorf("footer should note collapsed rows:\n%s", out)
+	}
+
+	if err := app.Devices([]string{"--dedupe=color"}); err == nil || !strings.Contains(err.Error(), "invalid --dedupe") {
+		t.Errorf("expected invalid --dedupe error, got: %v", err)
+	}
+}
+
+func TestListDevicesDedupeEmptyJSON(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return []api.Device{}, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"--dedupe"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if got := strings.TrimSpace(out); got != "[]" {
+		t.Errorf("output = %q, want []", got)
+	}
+}
+
+func TestWhoisDeviceByIP(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.WhoisDevice("192.168.1.10"); err != nil {
+			t.Fatalf("WhoisDevice error: %v", err)
+		}
+	})
+
+	// 192.168.1.10 is also a prefix of 192.168.1.100, so this must be an exact match
+	for _, want := range []string{"Name:    NAS", "MAC:     11:22:33:44:55:66", "Profile: none", "Status:  online (wired)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(t, func() {
+		if err := app.WhoisDevice("aa:bb:cc:dd:11:22"); err != nil {
+			t.Fatalf("WhoisDevice error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Name:    My Laptop") || !strings.Contains(out, "Profile: Adults") {
+		t.Errorf("MAC lookup output:\n%s", out)
+	}
+
+	if err := app.WhoisDevice("10.0.0.99"); err == nil {
+		t.Error("expected error for unknown IP")
+	}
+}
+
+func TestSetDeviceSingleUpdate(t *testing.T) {
+	var calls int
+	var gotID string
+	var gotUpdates map[string]interface{}
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		UpdateDeviceFn: func(networkID, deviceID string, updates map[string]interface{}) error {
+			calls++
+			gotID, gotUpdates = deviceID, updates
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		err := app.Devices([]string{"set", "My", "Laptop", "--nickname", "Work Laptop", "--paused", "true", "--blocked=false"})
+		if err != nil {
+			t.Fatalf("devices set error: %v", err)
+		}
+	})
+
+	if calls != 1 {
+		t.Fatalf("UpdateDevice called %d times, want 1", calls)
+	}
+	if gotID != "aabbccdd1122" {
+		t.Errorf("device ID = %q, want aabbccdd1122", gotID)
+	}
+	want := map[string]interface{}{"nickname": "Work Laptop", "paused": true, "blocked": false}
+	if !reflect.DeepEqual(gotUpdates, want) {
+		t.Errorf("updates = %v, want %v", gotUpdates, want)
+	}
+	if !strings.Contains(out, "nickname='Work Laptop', paused=true, blocked=false") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestSetDeviceValidation(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Devices([]string{"set", "NAS", "--paused", "maybe"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --paused value") {
+		t.Errorf("expected invalid --paused error, got: %v", err)
+	}
+
+	err = app.Devices([]string{"set", "NAS"})
+	if err == nil || !strings.HasPrefix(err.Error(), "usage:") {
+		t.Errorf("expected usage error with no attributes, got: %v", err)
+	}
+}
+
+func TestUnprofileDevice(t *testing.T) {
+	var setCalls int
+	var gotProfileID string
+	var gotDeviceURLs []string
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			return &api.ProfileDetails{
+				URL:  "/2.2/networks/12345/profiles/prof1",
+				Name: "Adults",
+				Devices: []struct {
+					URL string `json:"url"`
+				}{
+					{URL: "/2.2/networks/12345/devices/aabbccdd1122"},
+					{URL: "/2.2/networks/12345/devices/112233445566"},
+				},
+			}, nil
+		},
+		SetProfileDevicesFn: func(networkID, profileID string, deviceURLs []string) error {
+			setCalls++
+			gotProfileID, gotDeviceURLs = profileID, deviceURLs
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	// My Laptop is in Adults (prof1)
+	out := captureStdout(t, func() {
+		if err := app.UnprofileDevice("My Laptop"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if gotProfileID != "prof1" {
+		t.Errorf("profile ID = %q, want prof1", gotProfileID)
+	}
+	if !reflect.DeepEqual(gotDeviceURLs, []string{"/2.2/networks/12345/devices/112233445566"}) {
+		t.Errorf("remaining devices = %v", gotDeviceURLs)
+	}
+	if !strings.Contains(out, "removed from profile Adults") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	// NAS has no profile, so nothing is updated
+	out = captureStdout(t, func() {
+		if err := app.UnprofileDevice("NAS"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if setCalls != 1 {
+		t.Errorf("SetProfileDevices called %d times, want 1", setCalls)
+	}
+	if !strings.Contains(out, "is not in a profile") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+// moveMock returns a mock where My Laptop is in Adults (prof1) and Kids
+// (prof2) has the phone, recording profile updates in sets
+func moveMock(sets *map[string][]string) *mockClient {
+	members := map[string][]string{
+		"prof1": {"/2.2/networks/12345/devices/aabbccdd1122"},
+		"prof2": {"/2.2/networks/12345/devices/eeff00112233"},
+	}
+	names := map[string]string{"prof1": "Adults", "prof2": "Kids"}
+	*sets = map[string][]string{}
+	return &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			details := &api.ProfileDetails{URL: "/2.2/networks/12345/profiles/" + profileID, Name: names[profileID]}
+			for _, u := range members[profileID] {
+				details.Devices = append(details.Devices, struct {
+					URL string `json:"url"`
+				}{URL: u})
+			}
+			return details, nil
+		},
+		SetProfileDevicesFn: func(networkID, profileID string, deviceURLs []string) error {
+			(*sets)[profileID] = deviceURLs
+			return nil
+		},
+	}
+}
+
+func TestMoveDeviceToProfile(t *testing.T) {
+	var sets map[string][]string
+	app := newTestApp(moveMock(&sets))
+
+	out := captureStdout(t, func() {
+		if err := app.MoveDeviceToProfile("My Laptop", "Kids"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := map[string][]string{
+		"prof1": {},
+		"prof2": {"/2.2/networks/12345/devices/eeff00112233", "/2.2/networks/12345/devices/aabbccdd1122"},
+	}
+	if !reflect.DeepEqual(sets, want) {
+		t.Errorf("profile updates = %v, want %v", sets, want)
+	}
+	for _, msg := range []string{"removed from profile Adults", "added to profile Kids"} {
+		if !strings.Contains(out, msg) {
+			t.Errorf("output missing %q:\n%s", msg, out)
+		}
+	}
+}
+
+func TestMoveDeviceWithoutProfile(t *testing.T) {
+	var sets map[string][]string
+	app := newTestApp(moveMock(&sets))
+
+	// NAS has no profile, so it is only added
+	out := captureStdout(t, func() {
+		if err := app.MoveDeviceToProfile("NAS", "prof1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := map[string][]string{
+		"prof1": {"/2.2/networks/12345/devices/aabbccdd1122", "/2.2/networks/12345/devices/112233445566"},
+	}
+	if !reflect.DeepEqual(sets, want) {
+		t.Errorf("profile updates = %v, want %v", sets, want)
+	}
+	if !strings.Contains(out, "added to profile Adults") || strings.Contains(out, "removed") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestMoveDeviceAlreadyInProfile(t *testing.T) {
+	var sets map[string][]string
+	app := newTestApp(moveMock(&sets))
+
+	out := captureStdout(t, func() {
+		if err := app.MoveDeviceToProfile("aabbccdd1122", "adults"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if len(sets) != 0 {
+		t.Errorf("profile updates = %v, want none", sets)
+	}
+	if !strings.Contains(out, "is already in profile Adults") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestDevicesMoveUsage(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	if err := app.Devices([]string{"move", "My Laptop"}); err == nil || !strings.HasPrefix(err.Error(), "usage:") {
+		t.Errorf("error = %v, want usage error", err)
+	}
+}
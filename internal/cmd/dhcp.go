@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+)
+
+// DHCP handles the dhcp command
+func (a *App) DHCP(args []string) error {
+	// Parse flags
+	var yes bool
+	var filteredArgs []string
+	for _, arg := range args {
+		if arg == "--yes" || arg == "-y" {
+			yes = true
+		} else {
+			filteredArgs = append(filteredArgs, arg)
+		}
+	}
+	args = filteredArgs
+
+	if len(args) == 0 || args[0] == "show" {
+		return a.DHCPShow()
+	}
+
+	switch args[0] {
+	case "set":
+		if len(args) < 4 {
+			return fmt.Errorf("usage: dhcp set <subnet> <start> <end> [--yes]")
+		}
+		return a.DHCPSet(args[1], args[2], args[3], yes)
+	default:
+		return fmt.Errorf("unknown dhcp subcommand: %s", args[0])
+	}
+}
+
+// DHCPShow displays the network's LAN subnet and DHCP range
+func (a *App) DHCPShow() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	dhcp, err := a.Client.GetDHCP(networkID)
+	if err != nil {
+		return fmt.Errorf("getting DHCP settings: %w", err)
+	}
+
+	fmt.Println("DHCP Settings")
+	fmt.Println("-------------")
+	fmt.Printf("Subnet: %s\n", dhcp.Subnet)
+	fmt.Printf("Range:  %s - %s\n", dhcp.Start, dhcp.End)
+
+	return nil
+}
+
+// DHCPSet validates and applies a new LAN subnet and DHCP range
+func (a *App) DHCPSet(subnet, start, end string, yes bool) error {
+	if err := validateDHCPRange(subnet, start, end); err != nil {
+		return err
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	if !yes {
+		fmt.Println("Warning: changing the DHCP range may disrupt current leases; devices may need to reconnect.")
+		if !Confirm(a.context(), fmt.Sprintf("Set DHCP range to %s - %s in %s?", start, end, subnet)) {
+			fmt.Println("DHCP change cancelled")
+			return nil
+		}
+	}
+
+	if err := a.Client.SetDHCP(networkID, subnet, start, end); err != nil {
+		return fmt.Errorf("updating DHCP settings: %w", err)
+	}
+
+	fmt.Printf("DHCP range set to %s - %s (%s)\n", start, end, subnet)
+	return nil
+}
+
+// validateDHCPRange checks that subnet is an IPv4 prefix and that start and
+// end are addresses within it with start <= end. Neither may be the subnet's
+// network or broadcast address, which can't be handed out.
+func validateDHCPRange(subnet, start, end string) error {
+	prefix, err := netip.ParsePrefix(subnet)
+	if err != nil || !prefix.Addr().Is4() {
+		return fmt.Errorf("invalid subnet: %s (expected IPv4 CIDR, e.g. 192.168.4.0/22)", subnet)
+	}
+	prefix = prefix.Masked()
+
+	startAddr, err := netip.ParseAddr(start)
+	if err != nil || !startAddr.Is4() {
+		return fmt.Errorf("invalid start address: %s", start)
+	}
+	endAddr, err := netip.ParseAddr(end)
+	if err != nil || !endAddr.Is4() {
+		return fmt.Errorf("invalid end address: %s", end)
+	}
+
+	if !prefix.Contains(startAddr) {
+		return fmt.Errorf("start address %s is outside subnet %s", start, prefix)
+	}
+	if !prefix.Contains(endAddr) {
+		return fmt.Errorf("end address %s is outside subnet %s", end, prefix)
+	}
+	if startAddr.Compare(endAddr) > 0 {
+		return fmt.Errorf("start address %s is after end address %s", start, end)
+	}
+
+	// /31 and /32 have no network or broadcast address
+	if prefix.Bits() <= 30 {
+		network, broadcast := prefix.Addr(), lastAddr(prefix)
+		for _, addr := range []netip.Addr{startAddr, endAddr} {
+			if addr == network {
+				return fmt.Errorf("%s is the network address of %s", addr, prefix)
+			}
+			if addr == broadcast {
+				return fmt.Errorf("%s is the broadcast address of %s", addr, prefix)
+			}
+		}
+	}
+
+	return nil
+}
+
+// lastAddr returns the last address of an IPv4 prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	a := prefix.Masked().Addr().As4()
+	n := binary.BigEndian.Uint32(a[:]) | (1<<(32-prefix.Bits()) - 1)
+	binary.BigEndian.PutUint32(a[:], n)
+	return netip.AddrFrom4(a)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestDHCPShow(t *testing.T) {
+	mock := &mockClient{
+		GetDHCPFn: func(networkID string) (*api.DHCPSettings, error) {
+			return &api.DHCPSettings{Subnet: "192.168.4.0/22", Start: "192.168.4.100", End: "192.168.7.200"}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.DHCP(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "192.168.4.0/22") || !strings.Contains(out, "192.168.4.100 - 192.168.7.200") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestDHCPSet(t *testing.T) {
+	var got []string
+	mock := &mockClient{
+		SetDHCPFn: func(networkID, subnet, start, end string) error {
+			got = []string{subnet, start, end}
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.DHCP([]string{"set", "10.0.0.0/24", "10.0.0.50", "10.0.0.250", "--yes"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if strings.Join(got, " ") != "10.0.0.0/24 10.0.0.50 10.0.0.250" {
+		t.Errorf("SetDHCP called with %v", got)
+	}
+	if !strings.Contains(out, "DHCP range set to 10.0.0.50 - 10.0.0.250") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestDHCPSetDeclined(t *testing.T) {
+	mock := &mockClient{
+		SetDHCPFn: func(networkID, subnet, start, end string) error {
+			t.Error("SetDHCP should not be called when declined")
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var out string
+	feedStdin(t, "n\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.DHCPSet("10.0.0.0/24", "10.0.0.50", "10.0.0.250", false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	if !strings.Contains(out, "disrupt current leases") {
+		t.Errorf("expected lease warning, got:\n%s", out)
+	}
+	if !strings.Contains(out, "cancelled") {
+		t.Errorf("expected cancellation, got:\n%s", out)
+	}
+}
+
+func TestValidateDHCPRange(t *testing.T) {
+	tests := []struct {
+		subnet, start, end string
+		wantErr            string
+	}{
+		{"192.168.4.0/22", "192.168.4.100", "192.168.7.200", ""},
+		{"10.0.0.0/24", "10.0.0.50", "10.0.0.50", ""},
+		{"10.0.0.5/24", "10.0.0.50", "10.0.0.60", ""},
+		{"10.0.0.0", "10.0.0.50", "10.0.0.60", "invalid subnet"},
+		{"fd00::/64", "fd00::1", "fd00::2", "invalid subnet"},
+		{"10.0.0.0/24", "10.0.0", "10.0.0.60", "invalid start"},
+		{"10.0.0.0/24", "10.0.0.50", "nope", "invalid end"},
+		{"10.0.0.0/24", "10.0.1.50", "10.0.0.60", "start address 10.0.1.50 is outside"},
+		{"10.0.0.0/24", "10.0.0.50", "10.0.1.60", "end address 10.0.1.60 is outside"},
+		{"10.0.0.0/24", "10.0.0.60", "10.0.0.50", "is after end"},
+		{"10.0.0.0/24", "10.0.0.0", "10.0.0.60", "10.0.0.0 is the network address"},
+		{"10.0.0.0/24", "10.0.0.50", "10.0.0.255", "10.0.0.255 is the broadcast address"},
+		{"192.168.4.0/22", "192.168.4.1", "192.168.7.255", "192.168.7.255 is the broadcast address"},
+		{"192.168.4.0/22", "192.168.4.1", "192.168.7.254", ""},
+	}
+
+	for _, tt := range tests {
+		err := validateDHCPRange(tt.subnet, tt.start, tt.end)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("validateDHCPRange(%s, %s, %s) error: %v", tt.subnet, tt.start, tt.end, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("validateDHCPRange(%s, %s, %s) = %v, want %q", tt.subnet, tt.start, tt.end, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDHCPSetInvalidDoesNotCallAPI(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	if err := app.DHCP([]string{"set", "10.0.0.0/24", "10.0.0.60", "10.0.0.50", "--yes"}); err == nil {
+		t.Error("expected validation error")
+	}
+	if err := app.DHCP([]string{"set", "10.0.0.0/24"}); err == nil || !strings.Contains(err.Error(), "usage:") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
@@ -1,63 +1,125 @@
 package cmd
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dorin/eero-cli/internal/api"
 )
 
 // Eeros handles the eeros command
 func (a *App) Eeros(args []string) error {
+	// Parse flags
+	var wide, all bool
+	var order sortSpec
+	var filteredArgs []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--wide" {
+			wide = true
+		} else if args[i] == "--all" {
+			all = true
+		} else if args[i] == "--sort" && i+1 < len(args) {
+			v, err := parseSort(args[i+1], eeroSortFields)
+			if err != nil {
+				return err
+			}
+			order = v
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--sort=") {
+			v, err := parseSort(strings.TrimPrefix(args[i], "--sort="), eeroSortFields)
+			if err != nil {
+				return err
+			}
+			order = v
+		} else {
+			filteredArgs = append(filteredArgs, args[i])
+		}
+	}
+	args = filteredArgs
+
 	if len(args) == 0 {
-		return a.ListEeros()
+		return a.ListEeros(wide, order)
 	}
 
 	switch args[0] {
 	case "list":
-		return a.ListEeros()
+		return a.ListEeros(wide, order)
+	case "topology":
+		return a.EeroTopology()
 	case "inspect":
+		if all {
+			return a.InspectAllEeros()
+		}
 		if len(args) < 2 {
-			return fmt.Errorf("usage: eeros inspect <eero>")
+			return fmt.Errorf("usage: eeros inspect <eero> | --all")
 		}
-		return a.InspectEero(args[1])
+		// Join the rest so unquoted multi-word locations resolve
+		return a.InspectEero(strings.Join(args[1:], " "))
 	case "reboot":
 		if len(args) < 2 {
 			return fmt.Errorf("usage: eeros reboot <eero>")
 		}
-		return a.RebootEero(args[1])
+		return a.RebootEero(strings.Join(args[1:], " "))
 	default:
 		return fmt.Errorf("unknown eeros subcommand: %s", args[0])
 	}
 }
 
-// ListEeros lists all eero nodes on the network
-func (a *App) ListEeros() error {
+// ListEeros lists all eero nodes on the network, sorted by order (by
+// location when unset). The wide view adds serial, firmware version, and
+// uptime columns.
+func (a *App) ListEeros(wide bool, order sortSpec) error {
 	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
 	}
 
-	eeros, err := a.Client.GetEeros(networkID)
+	eeros, err := fetchCached(a, "eeros-"+networkID, func() ([]api.Eero, error) {
+		return a.Client.GetEeros(networkID)
+	})
 	if err != nil {
 		return fmt.Errorf("getting eeros: %w", err)
 	}
 
+	switch a.outputFormat() {
+	case OutputJSON, OutputYAML:
+		if eeros == nil {
+			eeros = []api.Eero{}
+		}
+		// Without a sort, order by serial so output diffs cleanly
+		if order.Field != "" {
+			eeros = sortedEeros(eeros, order)
+		} else {
+			eeros = sortedBySerial(eeros)
+		}
+		return a.printData(eeros)
+	case OutputCSV:
+		return printEerosCSV(sortedEeros(eeros, order))
+	}
+
 	if len(eeros) == 0 {
 		fmt.Println("No eero nodes found")
 		return nil
 	}
 
-	headers := []string{"ID", "LOCATION", "STATUS", "GATEWAY", "IP", "MODEL", "CLIENTS", "SIGNAL", "TYPE"}
+	headers := []string{"ID", "LOCATION", "STATUS", "GATEWAY", "IP", "MODEL", "CLIENTS", "SIGNAL", "TYPE", "GRADE"}
+	if wide {
+		headers = append(headers, "SERIAL", "OS", "UPTIME")
+	}
 	var rows [][]string
+	now := time.Now()
 
-	for _, e := range eeros {
+	for _, e := range sortedEeros(eeros, order) {
 		eeroID := api.ExtractEeroID(e.URL)
 
 		// Format status (lowercase to match devices output)
-		status := strings.ToLower(e.State)
+		status := a.statusColor(strings.ToLower(e.State))
 
 		// Gateway indicator
 		gateway := "no"
@@ -74,7 +136,7 @@ func (a *App) ListEeros() error {
 			connType = "wired"
 		}
 
-		rows = append(rows, []string{
+		row := []string{
 			eeroID,
 			e.Location,
 			status,
@@ -84,7 +146,16 @@ func (a *App) ListEeros() error {
 			fmt.Sprintf("%d", e.ConnectedClientsCount),
 			signal,
 			connType,
-		})
+			e.Grade(),
+		}
+		if wide {
+			uptime := "unknown"
+			if d, ok := e.Uptime(now); ok {
+				uptime = humanizeDuration(d)
+			}
+			row = append(row, e.Serial, e.OSVersion, uptime)
+		}
+		rows = append(rows, row)
 	}
 
 	PrintTable(headers, rows)
@@ -93,40 +164,101 @@ func (a *App) ListEeros() error {
 	return nil
 }
 
+// printEerosCSV writes the eero inventory as CSV, one row per node
+func printEerosCSV(eeros []api.Eero) error {
+	headers := []string{
+		"id", "serial", "location", "model", "os_version", "ip_address", "status", "state",
+		"gateway", "wired", "connection_type", "connected_clients_count", "mesh_quality_bars",
+		"heartbeat_ok", "is_primary_node", "last_reboot",
+	}
+	var rows [][]string
+	for _, e := range eeros {
+		rows = append(rows, []string{
+			api.ExtractEeroID(e.URL),
+			e.Serial,
+			e.Location,
+			e.Model,
+			e.OSVersion,
+			e.IPAddress,
+			e.Status,
+			e.State,
+			strconv.FormatBool(e.Gateway),
+			strconv.FormatBool(e.Wired),
+			e.ConnectionType,
+			strconv.Itoa(e.ConnectedClientsCount),
+			strconv.Itoa(e.MeshQualityBars),
+			strconv.FormatBool(e.HeartbeatOK),
+			strconv.FormatBool(e.IsPrimaryNode),
+			e.LastReboot,
+		})
+	}
+	return printCSV(headers, rows)
+}
+
+// humanizeDuration formats a duration as a short human-readable string like
+// "3d 4h", "5h 12m", or "42m"
+func humanizeDuration(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return "<1m"
+	}
+}
+
 // findEeroID finds an eero by partial ID, serial, or location
 func (a *App) findEeroID(networkID, query string) (string, error) {
-	eeros, err := a.Client.GetEeros(networkID)
+	e, err := a.findEero(networkID, query)
+	if err != nil {
+		return "", err
+	}
+	return api.ExtractEeroID(e.URL), nil
+}
+
+// findEero finds an eero by partial ID, serial, or location
+func (a *App) findEero(networkID, query string) (*api.Eero, error) {
+	eeros, err := a.eeros(networkID)
 	if err != nil {
-		return "", fmt.Errorf("getting eeros: %w", err)
+		return nil, fmt.Errorf("getting eeros: %w", err)
 	}
 
 	query = strings.ToLower(query)
 
-	for _, e := range eeros {
+	var candidates []string
+	for i, e := range eeros {
 		eeroID := api.ExtractEeroID(e.URL)
+		candidates = append(candidates, e.Location, e.Serial)
 
 		// Exact ID match
 		if eeroID == query {
-			return eeroID, nil
+			return &eeros[i], nil
 		}
 
 		// Partial ID match
 		if strings.HasPrefix(strings.ToLower(eeroID), query) {
-			return eeroID, nil
+			return &eeros[i], nil
 		}
 
 		// Serial match
 		if strings.EqualFold(e.Serial, query) {
-			return eeroID, nil
+			return &eeros[i], nil
 		}
 
 		// Location match (case-insensitive contains)
 		if strings.Contains(strings.ToLower(e.Location), query) {
-			return eeroID, nil
+			return &eeros[i], nil
 		}
 	}
 
-	return "", fmt.Errorf("eero not found: %s", query)
+	return nil, notFoundError("eero", query, candidates)
 }
 
 // InspectEero prints the full eero state as JSON
@@ -146,47 +278,147 @@ func (a *App) InspectEero(eeroQuery string) error {
 		return fmt.Errorf("getting eero: %w", err)
 	}
 
-	var prettyJSON bytes.Buffer
-	if err := json.Indent(&prettyJSON, rawJSON, "", "  "); err != nil {
-		return fmt.Errorf("formatting JSON: %w", err)
-	}
+	return a.printRawJSON(rawJSON)
+}
 
-	fmt.Println(prettyJSON.String())
+// inspectConcurrency bounds parallel GetEeroRaw calls for inspect --all
+const inspectConcurrency = 4
 
-	return nil
+// eeroDetail pairs an eero ID with its raw API state
+type eeroDetail struct {
+	ID   string          `json:"id"`
+	Eero json.RawMessage `json:"eero"`
 }
 
-// RebootEero reboots a single eero node
-func (a *App) RebootEero(eeroQuery string) error {
+// InspectAllEeros prints the full state of every eero as a JSON array,
+// ordered by location
+func (a *App) InspectAllEeros() error {
 	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
 	}
 
-	// Get eeros to find matching one and get its location for confirmation
-	eeros, err := a.Client.GetEeros(networkID)
+	eeros, err := a.eeros(networkID)
 	if err != nil {
 		return fmt.Errorf("getting eeros: %w", err)
 	}
 
-	eeroID, err := a.findEeroID(networkID, eeroQuery)
+	// Sort a copy: the memoized list is shared with the rest of the command
+	eeros = slices.Clone(eeros)
+	sort.SliceStable(eeros, func(i, j int) bool {
+		return strings.ToLower(eeros[i].Location) < strings.ToLower(eeros[j].Location)
+	})
+
+	details := make([]eeroDetail, len(eeros))
+	errs := make([]error, len(eeros))
+	sem := make(chan struct{}, inspectConcurrency)
+	var wg sync.WaitGroup
+
+	for i, e := range eeros {
+		details[i].ID = api.ExtractEeroID(e.URL)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			raw, err := a.Client.GetEeroRaw(details[i].ID)
+			if err != nil {
+				errs[i] = fmt.Errorf("getting eero %s: %w", details[i].ID, err)
+				return
+			}
+			details[i].Eero = raw
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return a.printData(details)
+}
+
+// RebootEero reboots a single eero node after confirming
+func (a *App) RebootEero(eeroQuery string) error {
+	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
 	}
 
-	// Find the eero to get its location
-	var location string
-	for _, e := range eeros {
-		if api.ExtractEeroID(e.URL) == eeroID {
-			location = e.Location
-			break
-		}
+	e, err := a.findEero(networkID, eeroQuery)
+	if err != nil {
+		return err
+	}
+	eeroID := api.ExtractEeroID(e.URL)
+
+	result := RebootResult{Action: "reboot", NetworkID: networkID, EeroID: eeroID}
+
+	if !a.confirm(fmt.Sprintf("Reboot eero %s (%s)? Devices connected to it will reconnect to other nodes.", eeroID, e.Location)) {
+		return a.rebootCancelled(result)
 	}
 
 	if err := a.Client.RebootEero(eeroID); err != nil {
 		return fmt.Errorf("rebooting eero: %w", err)
 	}
 
-	fmt.Printf("Rebooting eero %s (%s)...\n", eeroID, location)
+	if a.structuredOutput() {
+		return a.printData(result)
+	}
+	fmt.Printf("Rebooting eero %s (%s)...\n", eeroID, e.Location)
 	return nil
 }
+
+// EeroTopology renders the mesh as a tree from the gateway to its leaves
+func (a *App) EeroTopology() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	topology, err := a.Client.GetTopology(networkID)
+	if err != nil {
+		return fmt.Errorf("getting topology: %w", err)
+	}
+
+	if a.structuredOutput() {
+		return a.printData(topology)
+	}
+
+	if len(topology.Roots) == 0 {
+		fmt.Println("No eero nodes found")
+		return nil
+	}
+
+	for _, root := range topology.Roots {
+		fmt.Println(topologyLabel(root))
+		printTopologyChildren(root.Children, "")
+	}
+	return nil
+}
+
+// printTopologyChildren prints nodes as tree branches under prefix
+func printTopologyChildren(nodes []*api.TopologyNode, prefix string) {
+	for i, n := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Println(prefix + branch + topologyLabel(n))
+		printTopologyChildren(n.Children, prefix+indent)
+	}
+}
+
+// topologyLabel describes a node and its uplink
+func topologyLabel(n *api.TopologyNode) string {
+	if n.Gateway {
+		return fmt.Sprintf("%s (%s) gateway", n.Location, n.ID)
+	}
+	if n.ConnectionType == "wired" {
+		return fmt.Sprintf("%s (%s) wired", n.Location, n.ID)
+	}
+	return fmt.Sprintf("%s (%s) %s, signal %d/5", n.Location, n.ID, n.ConnectionType, n.MeshQualityBars)
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dorin/eero-cli/internal/api"
 )
@@ -26,6 +29,7 @@ func testEeros() []api.Eero {
 			HeartbeatOK:           true,
 			IsPrimaryNode:         true,
 			ConnectionType:        "wired",
+			LastReboot:            "2023-11-10T08:00:00Z",
 		},
 		{
 			URL:                   "/2.2/eeros/8318691",
@@ -56,7 +60,7 @@ func TestListEeros(t *testing.T) {
 	app := newTestApp(mock)
 
 	out := captureStdout(t, func() {
-		if err := app.ListEeros(); err != nil {
+		if err := app.ListEeros(false, sortSpec{}); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
@@ -73,11 +77,126 @@ func TestListEeros(t *testing.T) {
 	if !strings.Contains(out, "eero Pro 6E") {
 		t.Error("output missing model")
 	}
+	if !strings.Contains(out, "GRADE") {
+		t.Error("output missing GRADE column")
+	}
 	if !strings.Contains(out, "Total: 2 eero nodes") {
 		t.Errorf("output missing total count, got:\n%s", out)
 	}
 }
 
+func TestListEerosWide(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Eeros([]string{"--wide"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "UPTIME") {
+		t.Error("output missing UPTIME column")
+	}
+	if !strings.Contains(out, "SN12345678") {
+		t.Error("output missing serial in wide view")
+	}
+	// Bedroom has no last_reboot
+	if !strings.Contains(out, "unknown") {
+		t.Errorf("expected unknown uptime for node without boot time, got:\n%s", out)
+	}
+}
+
+func TestListEerosJSON(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.ListEeros(false, sortSpec{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var eeros []api.Eero
+	if err := json.Unmarshal([]byte(out), &eeros); err != nil {
+		t.Fatalf("output is not a JSON eero array: %v\n%s", err, out)
+	}
+	if len(eeros) != 2 {
+		t.Fatalf("len(eeros) = %d, want 2", len(eeros))
+	}
+	if eeros[0].Serial != "SN12345678" || eeros[0].OSVersion != "7.2.1" {
+		t.Errorf("eeros[0] = %+v, want serial and OS version preserved", eeros[0])
+	}
+
+	// Inventory fields are exposed under their API names
+	var raw []map[string]interface{}
+	json.Unmarshal([]byte(out), &raw)
+	for _, key := range []string{"serial", "model", "os_version", "location", "ip_address", "status", "connected_clients_count", "mesh_quality_bars"} {
+		if _, ok := raw[0][key]; !ok {
+			t.Errorf("JSON output missing key %q", key)
+		}
+	}
+}
+
+func TestListEerosCSV(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			eeros := testEeros()
+			eeros[1].Location = "Office, upstairs"
+			return eeros, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputCSV
+
+	out := captureStdout(t, func() {
+		if err := app.ListEeros(false, sortSpec{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, out)
+	}
+	if len(records) != 3 {
+		t.Fatalf("len(records) = %d, want header + 2 rows", len(records))
+	}
+	if records[0][0] != "id" || records[0][1] != "serial" {
+		t.Errorf("header = %v", records[0])
+	}
+	if records[2][2] != "Office, upstairs" {
+		t.Errorf("location = %q, want comma preserved", records[2][2])
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "<1m"},
+		{42 * time.Minute, "42m"},
+		{5*time.Hour + 12*time.Minute, "5h 12m"},
+		{76 * time.Hour, "3d 4h"},
+	}
+
+	for _, tt := range tests {
+		if got := humanizeDuration(tt.d); got != tt.want {
+			t.Errorf("humanizeDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
 func TestListEerosEmpty(t *testing.T) {
 	mock := &mockClient{
 		GetEerosFn: func(networkID string) ([]api.Eero, error) {
@@ -87,7 +206,7 @@ func TestListEerosEmpty(t *testing.T) {
 	app := newTestApp(mock)
 
 	out := captureStdout(t, func() {
-		if err := app.ListEeros(); err != nil {
+		if err := app.ListEeros(false, sortSpec{}); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
@@ -217,10 +336,13 @@ func TestRebootEero(t *testing.T) {
 	}
 	app := newTestApp(mock)
 
-	out := captureStdout(t, func() {
-		if err := app.RebootEero("8318690"); err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
+	var out string
+	feedStdin(t, "y\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.RebootEero("8318690"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
 	})
 
 	if rebootedID != "8318690" {
@@ -234,6 +356,62 @@ func TestRebootEero(t *testing.T) {
 	}
 }
 
+func TestEerosRebootDeclined(t *testing.T) {
+	var rebooted []string
+	app := newTestApp(rebootNodeMock(t, &rebooted))
+
+	var out string
+	feedStdin(t, "n\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.Eeros([]string{"reboot", "bedroom"}); err != nil {
+				t.Fatalf("declining should not be an error: %v", err)
+			}
+		})
+	})
+	if len(rebooted) != 0 {
+		t.Errorf("rebooted = %v after declining", rebooted)
+	}
+	if !strings.Contains(out, "Reboot eero 8318691 (Bedroom)?") || !strings.Contains(out, "Reboot cancelled") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestEerosMultiWordLocation(t *testing.T) {
+	var inspectedID, rebootedID string
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+		GetEeroRawFn: func(eeroID string) (json.RawMessage, error) {
+			inspectedID = eeroID
+			return json.RawMessage(`{"location":"Living Room"}`), nil
+		},
+		RebootEeroFn: func(eeroID string) error {
+			rebootedID = eeroID
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	feedStdin(t, "y\n", func() {
+		captureStdout(t, func() {
+			if err := app.Eeros([]string{"inspect", "Living", "Room"}); err != nil {
+				t.Fatalf("eeros inspect Living Room: %v", err)
+			}
+			if err := app.Eeros([]string{"reboot", "Living", "Room"}); err != nil {
+				t.Fatalf("eeros reboot Living Room: %v", err)
+			}
+		})
+	})
+
+	if inspectedID != "8318690" {
+		t.Errorf("inspected %q, want %q", inspectedID, "8318690")
+	}
+	if rebootedID != "8318690" {
+		t.Errorf("rebooted %q, want %q", rebootedID, "8318690")
+	}
+}
+
 func TestEerosCommandRouting(t *testing.T) {
 	mock := &mockClient{
 		GetEerosFn: func(networkID string) ([]api.Eero, error) {
@@ -262,3 +440,83 @@ func TestEerosCommandRouting(t *testing.T) {
 		t.Errorf("expected unknown error, got: %v", err)
 	}
 }
+
+func TestInspectAllEeros(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+		GetEeroRawFn: func(eeroID string) (json.RawMessage, error) {
+			return json.RawMessage(fmt.Sprintf(`{"url":"/2.2/eeros/%s","os":"7.2.1"}`, eeroID)), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Eeros([]string{"inspect", "--all"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var details []struct {
+		ID   string                 `json:"id"`
+		Eero map[string]interface{} `json:"eero"`
+	}
+	if err := json.Unmarshal([]byte(out), &details); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out)
+	}
+	if len(details) != 2 {
+		t.Fatalf("len(details) = %d, want 2", len(details))
+	}
+
+	// Ordered by location: Bedroom before Living Room
+	if details[0].ID != "8318691" || details[1].ID != "8318690" {
+		t.Errorf("IDs = [%s %s], want [8318691 8318690]", details[0].ID, details[1].ID)
+	}
+	for _, d := range details {
+		if d.Eero["url"] != "/2.2/eeros/"+d.ID {
+			t.Errorf("eero %s has mismatched detail %v", d.ID, d.Eero)
+		}
+	}
+}
+
+func TestInspectAllEerosError(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+		GetEeroRawFn: func(eeroID string) (json.RawMessage, error) {
+			if eeroID == "8318691" {
+				return nil, fmt.Errorf("boom")
+			}
+			return json.RawMessage(`{}`), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	err := app.InspectAllEeros()
+	if err == nil || !strings.Contains(err.Error(), "8318691") {
+		t.Errorf("expected error naming the failed eero, got: %v", err)
+	}
+}
+
+func TestEeroTopology(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Eeros([]string{"topology"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := "Living Room (8318690) gateway\n" +
+		"└── Bedroom (8318691) wireless, signal 3/5\n"
+	if out != want {
+		t.Errorf("topology output:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// Exit codes, so scripts can tell why a command failed
+const (
+	ExitOK       = 0
+	ExitFailure  = 1 // usage and other errors
+	ExitAuth     = 2 // not logged in, or the token is invalid or expired
+	ExitNotFound = 3 // no device, profile, eero, or other item matched
+	ExitAPI      = 4 // the API returned an error or could not be reached
+)
+
+// codedError is an error that exits with a specific code. Its message is
+// the wrapped error's.
+type codedError struct {
+	code int
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+
+func (e *codedError) Unwrap() error { return e.err }
+
+// authError returns an error that exits with ExitAuth
+func authError(format string, args ...any) error {
+	return &codedError{code: ExitAuth, err: fmt.Errorf(format, args...)}
+}
+
+// notFoundf returns an error that exits with ExitNotFound, for lookups
+// that notFoundError's message doesn't fit
+func notFoundf(format string, args ...any) error {
+	return &codedError{code: ExitNotFound, err: fmt.Errorf(format, args...)}
+}
+
+// ExitCode maps an error returned by a command to the process exit code
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	var statusErr *api.StatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden {
+			return ExitAuth
+		}
+		return ExitAPI
+	}
+
+	// The HTTP client reports failed requests, including certificate pin
+	// mismatches, as *url.Error
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return ExitAPI
+	}
+
+	return ExitFailure
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"usage", fmt.Errorf("usage: eero-cli devices pause <id>"), ExitFailure},
+		{"auth", authError("not logged in"), ExitAuth},
+		{"not found", notFoundError("device", "tv", nil), ExitNotFound},
+		{"wrapped not found", fmt.Errorf("line 3: %w", notFoundError("device", "tv", nil)), ExitNotFound},
+		{"unauthorized", fmt.Errorf("getting devices: %w", &api.StatusError{StatusCode: 401}), ExitAuth},
+		{"forbidden", &api.StatusError{StatusCode: 403}, ExitAuth},
+		{"server error", fmt.Errorf("getting devices: %w", &api.StatusError{StatusCode: 500}), ExitAPI},
+		{"api 404", &api.StatusError{StatusCode: 404}, ExitAPI},
+		{"unreachable", fmt.Errorf("making request: %w", &url.Error{Op: "Get", URL: "https://api", Err: errors.New("connection refused")}), ExitAPI},
+	}
+
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("%s: ExitCode(%v) = %d, want %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExitCodeKeepsMessage(t *testing.T) {
+	err := notFoundError("profile", "aduls", []string{"Adults"})
+	if got := err.Error(); got != "profile not found: aduls (did you mean 'Adults'?)" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestEnsureAuthExitCode(t *testing.T) {
+	app := newTestApp(&mockClient{ValidateTokenFn: func() bool { return false }})
+	if got := ExitCode(app.EnsureAuth()); got != ExitAuth {
+		t.Errorf("invalid token: exit code = %d, want %d", got, ExitAuth)
+	}
+
+	app.Config.Token = ""
+	if got := ExitCode(app.EnsureAuth()); got != ExitAuth {
+		t.Errorf("no token: exit code = %d, want %d", got, ExitAuth)
+	}
+}
+
+func TestFindExitCodes(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return nil, &api.StatusError{StatusCode: 503}
+		},
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	_, err := app.findDeviceID("12345", "toaster")
+	if got := ExitCode(err); got != ExitNotFound {
+		t.Errorf("findDeviceID: exit code = %d, want %d (err: %v)", got, ExitNotFound, err)
+	}
+	_, err = app.findEeroID("12345", "attic")
+	if got := ExitCode(err); got != ExitNotFound {
+		t.Errorf("findEeroID: exit code = %d, want %d (err: %v)", got, ExitNotFound, err)
+	}
+	_, err = app.findProfileID("12345", "Adults")
+	if got := ExitCode(err); got != ExitAPI {
+		t.Errorf("findProfileID: exit code = %d, want %d (err: %v)", got, ExitAPI, err)
+	}
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// metricLabel is a Prometheus label name and value
+type metricLabel struct {
+	Name  string
+	Value string
+}
+
+// metricSample is one line of a metric family
+type metricSample struct {
+	Labels []metricLabel
+	Value  float64
+}
+
+// Export handles the export command: it prints Prometheus metrics once, or
+// serves them on /metrics with --listen
+func (a *App) Export(args []string) error {
+	var listen string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--listen" && i+1 < len(args) {
+			listen = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--listen=") {
+			listen = strings.TrimPrefix(args[i], "--listen=")
+		} else {
+			return fmt.Errorf("usage: export [--listen <addr>]")
+		}
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	if listen == "" {
+		return writeMetrics(a.Client, os.Stdout, networkID, time.Now())
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", a.metricsHandler(networkID))
+	srv := &http.Server{Addr: listen, Handler: mux}
+	return a.serve(srv, listen)
+}
+
+// serve runs srv until the command is cancelled, then shuts it down, giving
+// in-flight scrapes a few seconds to finish
+func (a *App) serve(srv *http.Server, listen string) error {
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", listen, err)
+	}
+	fmt.Fprintf(os.Stderr, "Serving metrics on http://%s/metrics. Press Ctrl+C to stop.\n", listen)
+
+	ctx := a.context()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// metricsHandler serves the network's metrics, fetching them again on each
+// scrape. A scraper that gives up cancels the fetch.
+func (a *App) metricsHandler(networkID string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		if err := writeMetrics(a.clientFor(r.Context()), &buf, networkID, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(buf.Bytes())
+	})
+}
+
+// writeMetrics fetches devices and eeros and writes them to w in the
+// Prometheus text exposition format. It calls the client directly so each
+// scrape sees fresh data.
+func writeMetrics(client api.EeroAPI, w io.Writer, networkID string, now time.Time) error {
+	devices, err := client.GetDevices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+	eeros, err := client.GetEeros(networkID)
+	if err != nil {
+		return fmt.Errorf("getting eeros: %w", err)
+	}
+
+	var connected, wireless, paused, blocked []metricSample
+	for _, d := range sortedByMAC(devices) {
+		labels := []metricLabel{
+			{"id", api.ExtractDeviceID(d.URL)},
+			{"name", d.DisplayName()},
+			{"mac", d.MAC},
+		}
+		connected = append(connected, metricSample{labels, boolValue(d.Connected)})
+		wireless = append(wireless, metricSample{labels, boolValue(d.Wireless)})
+		paused = append(paused, metricSample{labels, boolValue(d.Paused)})
+		blocked = append(blocked, metricSample{labels, boolValue(d.Blocked)})
+	}
+	writeGauge(w, "eero_device_connected", "Whether the device is connected to the network.", connected)
+	writeGauge(w, "eero_device_wireless", "Whether the device is connected wirelessly.", wireless)
+	writeGauge(w, "eero_device_paused", "Whether the device's internet access is paused.", paused)
+	writeGauge(w, "eero_device_blocked", "Whether the device is blocked from the network.", blocked)
+
+	var up, clients, bars, uptime []metricSample
+	for _, e := range eeros {
+		labels := []metricLabel{
+			{"id", api.ExtractEeroID(e.URL)},
+			{"location", e.Location},
+			{"serial", e.Serial},
+			{"model", e.Model},
+		}
+		up = append(up, metricSample{labels, boolValue(e.HeartbeatOK)})
+		clients = append(clients, metricSample{labels, float64(e.ConnectedClientsCount)})
+		bars = append(bars, metricSample{labels, float64(e.MeshQualityBars)})
+		if d, ok := e.Uptime(now); ok {
+			uptime = append(uptime, metricSample{labels, d.Seconds()})
+		}
+	}
+	writeGauge(w, "eero_node_up", "Whether the eero's heartbeat is OK.", up)
+	writeGauge(w, "eero_node_clients", "Number of clients connected to the eero.", clients)
+	writeGauge(w, "eero_node_mesh_bars", "Mesh signal quality of the eero, in bars (0-5).", bars)
+	writeGauge(w, "eero_node_uptime_seconds", "Seconds since the eero last rebooted.", uptime)
+	return nil
+}
+
+// writeGauge writes a gauge family with its HELP and TYPE lines. Families
+// without samples are skipped.
+func writeGauge(w io.Writer, name, help string, samples []metricSample) {
+	if len(samples) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	for _, s := range samples {
+		fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(s.Labels), strconv.FormatFloat(s.Value, 'f', -1, 64))
+	}
+}
+
+// formatLabels renders labels as {a="x",b="y"}, or "" when there are none
+func formatLabels(labels []metricLabel) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = fmt.Sprintf(`%s="%s"`, l.Name, escapeLabelValue(l.Value))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// labelEscaper escapes the characters the exposition format reserves in
+// label values
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes a label value for the exposition format
+func escapeLabelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// boolValue is 1 for true and 0 for false
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// exportMock serves the test devices and eeros, counting GetDevices calls
+func exportMock(deviceCalls *int) *mockClient {
+	return &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			*deviceCalls++
+			return testDevices(), nil
+		},
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	var calls int
+	app := newTestApp(exportMock(&calls))
+
+	var buf bytes.Buffer
+	now := time.Date(2023, 11, 11, 8, 0, 0, 0, time.UTC)
+	if err := writeMetrics(app.Client, &buf, "12345", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# HELP eero_device_connected Whether the device is connected to the network.\n# TYPE eero_device_connected gauge\n",
+		`eero_device_connected{id="112233445566",name="NAS",mac="11:22:33:44:55:66"} 1` + "\n",
+		`eero_device_connected{id="eeff00112233",name="phone",mac="EE:FF:00:11:22:33"} 0` + "\n",
+		`eero_device_wireless{id="aabbccdd1122",name="My Laptop",mac="AA:BB:CC:DD:11:22"} 1` + "\n",
+		`eero_node_clients{id="8318690",location="Living Room",serial="SN12345678",model="eero Pro 6E"} 12` + "\n",
+		`eero_node_mesh_bars{id="8318691",location="Bedroom",serial="SN87654321",model="eero 6+"} 3` + "\n",
+		`eero_node_up{id="8318690",location="Living Room",serial="SN12345678",model="eero Pro 6E"} 1` + "\n",
+		`eero_node_uptime_seconds{id="8318690",location="Living Room",serial="SN12345678",model="eero Pro 6E"} 86400` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	// The Bedroom eero has no reboot time, so it has no uptime sample
+	if strings.Contains(out, `eero_node_uptime_seconds{id="8318691"`) {
+		t.Errorf("unexpected uptime for an eero without a reboot time:\n%s", out)
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Living Room", "Living Room"},
+		{`Dad's "work" PC`, `Dad's \"work\" PC`},
+		{`C:\temp`, `C:\\temp`},
+		{"two\nlines", `two\nlines`},
+	}
+	for _, tt := range tests {
+		if got := escapeLabelValue(tt.in); got != tt.want {
+			t.Errorf("escapeLabelValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsHandlerFetchesOnEachScrape(t *testing.T) {
+	var calls int
+	app := newTestApp(exportMock(&calls))
+	handler := app.metricsHandler("12345")
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if !strings.Contains(rec.Body.String(), "eero_node_clients{") {
+			t.Errorf("body missing metrics:\n%s", rec.Body.String())
+		}
+	}
+	if calls != 2 {
+		t.Errorf("GetDevices called %d times, want once per scrape", calls)
+	}
+}
+
+func TestMetricsHandlerAPIError(t *testing.T) {
+	app := newTestApp(&mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	app.metricsHandler("12345").ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusBadGateway || !strings.Contains(rec.Body.String(), "connection refused") {
+		t.Errorf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMetricsHandlerUsesRequestContext(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	app.Client = api.NewWithOptions("test-token", api.ClientOptions{Retries: api.NoRetries, BaseURL: "http://127.0.0.1:1"})
+
+	// A scraper that has already gone away cancels the fetch
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	app.metricsHandler("12345").ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil).WithContext(ctx))
+	if rec.Code != http.StatusBadGateway || !strings.Contains(rec.Body.String(), "context canceled") {
+		t.Errorf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestExportRejectsUnknownFlag(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Export([]string{"--port", "9100"})
+	if err == nil || !strings.Contains(err.Error(), "usage: export") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
+
+func TestExportListenStopsOnCancel(t *testing.T) {
+	var calls int
+	app := newTestApp(exportMock(&calls))
+	ctx, cancel := context.WithCancel(context.Background())
+	app.ctx = ctx
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	var err error
+	stderr := captureStderr(t, func() {
+		err = app.Export([]string{"--listen", "127.0.0.1:0"})
+	})
+	if err != nil {
+		t.Errorf("expected nil after cancel, got: %v", err)
+	}
+	if !strings.Contains(stderr, "Serving metrics on http://127.0.0.1:0/metrics") {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
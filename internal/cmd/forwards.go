@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// Forwards handles the forwards command
+func (a *App) Forwards(args []string) error {
+	if len(args) == 0 {
+		return a.ListForwards()
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: forwards add <ip> <ext-port>:<int-port>/<tcp|udp> [description]")
+		}
+		return a.AddForward(args[1], args[2], strings.Join(args[3:], " "))
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: forwards remove <id|description>")
+		}
+		return a.RemoveForward(strings.Join(args[1:], " "))
+	default:
+		return fmt.Errorf("unknown forwards subcommand: %s", args[0])
+	}
+}
+
+// ListForwards lists the network's port forwarding rules by external port
+func (a *App) ListForwards() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	forwards, err := a.Client.GetForwards(networkID)
+	if err != nil {
+		return fmt.Errorf("getting forwards: %w", err)
+	}
+	sort.SliceStable(forwards, func(i, j int) bool {
+		if forwards[i].ExternalPort != forwards[j].ExternalPort {
+			return forwards[i].ExternalPort < forwards[j].ExternalPort
+		}
+		return forwards[i].Protocol < forwards[j].Protocol
+	})
+
+	headers := []string{"ID", "IP", "EXTERNAL", "INTERNAL", "PROTOCOL", "ENABLED", "DESCRIPTION"}
+	var rows [][]string
+	for _, f := range forwards {
+		enabled := "no"
+		if f.Enabled {
+			enabled = "yes"
+		}
+		rows = append(rows, []string{
+			api.ExtractForwardID(f.URL),
+			f.IP,
+			strconv.Itoa(f.ExternalPort),
+			strconv.Itoa(f.InternalPort),
+			f.Protocol,
+			enabled,
+			f.Description,
+		})
+	}
+
+	switch a.outputFormat() {
+	case OutputJSON, OutputYAML:
+		if forwards == nil {
+			forwards = []api.Forward{}
+		}
+		return a.printData(forwards)
+	case OutputCSV:
+		return printCSV(headers, rows)
+	}
+
+	if len(forwards) == 0 {
+		fmt.Println("No port forwards.")
+		return nil
+	}
+	PrintTable(headers, rows)
+	return nil
+}
+
+// AddForward creates an enabled port forwarding rule to ip from a spec like
+// "8080:80/tcp"
+func (a *App) AddForward(ip, spec, description string) error {
+	if parsed := net.ParseIP(ip); parsed == nil || parsed.To4() == nil {
+		return fmt.Errorf("invalid IP address: %s", ip)
+	}
+	external, internal, protocol, err := parseForwardSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	f := api.Forward{
+		IP:           ip,
+		ExternalPort: external,
+		InternalPort: internal,
+		Protocol:     protocol,
+		Description:  description,
+		Enabled:      true,
+	}
+	if err := a.Client.CreateForward(networkID, f); err != nil {
+		return fmt.Errorf("creating forward: %w", err)
+	}
+
+	fmt.Printf("Forward created: %d/%s -> %s:%d\n", external, protocol, ip, internal)
+	return nil
+}
+
+// RemoveForward deletes the port forwarding rule with the given ID or
+// description
+func (a *App) RemoveForward(query string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	forwards, err := a.Client.GetForwards(networkID)
+	if err != nil {
+		return fmt.Errorf("getting forwards: %w", err)
+	}
+
+	f, err := matchForward(forwards, query)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Client.DeleteForward(networkID, api.ExtractForwardID(f.URL)); err != nil {
+		return fmt.Errorf("deleting forward: %w", err)
+	}
+
+	fmt.Printf("Forward deleted: %d/%s -> %s:%d\n", f.ExternalPort, f.Protocol, f.IP, f.InternalPort)
+	return nil
+}
+
+// matchForward finds the forward with the given ID, or else the one whose
+// description matches (case-insensitive). A description shared by several
+// forwards is an error.
+func matchForward(forwards []api.Forward, query string) (api.Forward, error) {
+	for _, f := range forwards {
+		if api.ExtractForwardID(f.URL) == query {
+			return f, nil
+		}
+	}
+
+	var matched []api.Forward
+	for _, f := range forwards {
+		if f.Description != "" && strings.EqualFold(f.Description, query) {
+			matched = append(matched, f)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return api.Forward{}, notFoundError("forward", query, nil)
+	case 1:
+		return matched[0], nil
+	default:
+		return api.Forward{}, fmt.Errorf("%d forwards are described as %q; remove one by ID", len(matched), query)
+	}
+}
+
+// parseForwardSpec parses "<ext-port>:<int-port>/<tcp|udp>"
+func parseForwardSpec(spec string) (external, internal int, protocol string, err error) {
+	ports, protocol, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, "", fmt.Errorf("invalid forward spec: %s (e.g. 8080:80/tcp)", spec)
+	}
+	protocol = strings.ToLower(protocol)
+	if protocol != "tcp" && protocol != "udp" {
+		return 0, 0, "", fmt.Errorf("invalid protocol: %s (must be tcp or udp)", protocol)
+	}
+
+	ext, in, ok := strings.Cut(ports, ":")
+	if !ok {
+		return 0, 0, "", fmt.Errorf("invalid forward spec: %s (e.g. 8080:80/tcp)", spec)
+	}
+	if external, err = parsePort(ext); err != nil {
+		return 0, 0, "", err
+	}
+	if internal, err = parsePort(in); err != nil {
+		return 0, 0, "", err
+	}
+	return external, internal, protocol, nil
+}
+
+// parsePort parses a TCP or UDP port number (1-65535)
+func parsePort(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 65535 {
+		return 0, fmt.Errorf("invalid port: %s (must be 1-65535)", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func testForwards() []api.Forward {
+	return []api.Forward{
+		{URL: "/2.2/networks/12345/forwards/fwd2", IP: "192.168.1.20", ExternalPort: 51820, InternalPort: 51820, Protocol: "udp", Description: "WireGuard"},
+		{URL: "/2.2/networks/12345/forwards/fwd1", IP: "192.168.1.10", ExternalPort: 8443, InternalPort: 443, Protocol: "tcp", Description: "NAS web", Enabled: true},
+		{URL: "/2.2/networks/12345/forwards/fwd3", IP: "192.168.1.30", ExternalPort: 2222, InternalPort: 22, Protocol: "tcp", Description: "ssh", Enabled: true},
+		{URL: "/2.2/networks/12345/forwards/fwd4", IP: "192.168.1.31", ExternalPort: 2223, InternalPort: 22, Protocol: "tcp", Description: "ssh", Enabled: true},
+	}
+}
+
+func TestParseForwardSpec(t *testing.T) {
+	ext, in, proto, err := parseForwardSpec("8080:80/TCP")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ext != 8080 || in != 80 || proto != "tcp" {
+		t.Errorf("parseForwardSpec = %d, %d, %q", ext, in, proto)
+	}
+
+	tests := []struct {
+		spec, want string
+	}{
+		{"8080:80", "invalid forward spec"},
+		{"8080/tcp", "invalid forward spec"},
+		{"8080:80/icmp", "invalid protocol: icmp"},
+		{"0:80/tcp", "invalid port: 0"},
+		{"8080:65536/udp", "invalid port: 65536"},
+		{"http:80/tcp", "invalid port: http"},
+	}
+	for _, tt := range tests {
+		_, _, _, err := parseForwardSpec(tt.spec)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseForwardSpec(%q) error = %v, want %q", tt.spec, err, tt.want)
+		}
+	}
+}
+
+func TestListForwards(t *testing.T) {
+	mock := &mockClient{
+		GetForwardsFn: func(networkID string) ([]api.Forward, error) {
+			return testForwards(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Forwards(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	// Sorted by external port
+	ssh := strings.Index(out, "2222")
+	nas := strings.Index(out, "8443")
+	wg := strings.Index(out, "51820")
+	if ssh < 0 || nas < ssh || wg < nas {
+		t.Errorf("forwards not sorted by external port:\n%s", out)
+	}
+	if !strings.Contains(out, "NAS web") || !strings.Contains(out, "fwd1") {
+		t.Errorf("output missing forward details:\n%s", out)
+	}
+}
+
+func TestAddForward(t *testing.T) {
+	var got api.Forward
+	mock := &mockClient{
+		CreateForwardFn: func(networkID string, f api.Forward) error {
+			got = f
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Forwards([]string{"add", "192.168.1.10", "8443:443/tcp", "NAS", "web"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := api.Forward{IP: "192.168.1.10", ExternalPort: 8443, InternalPort: 443, Protocol: "tcp", Description: "NAS web", Enabled: true}
+	if got != want {
+		t.Errorf("created %+v, want %+v", got, want)
+	}
+	if !strings.Contains(out, "8443/tcp -> 192.168.1.10:443") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestAddForwardValidates(t *testing.T) {
+	// No mock functions are set, so any API call panics
+	app := newTestApp(&mockClient{})
+
+	if err := app.Forwards([]string{"add", "nas.local", "80:80/tcp"}); err == nil || !strings.Contains(err.Error(), "invalid IP address") {
+		t.Errorf("expected invalid IP error, got: %v", err)
+	}
+	if err := app.Forwards([]string{"add", "192.168.1.10", "80:80/sctp"}); err == nil || !strings.Contains(err.Error(), "invalid protocol") {
+		t.Errorf("expected invalid protocol error, got: %v", err)
+	}
+	if err := app.Forwards([]string{"add", "192.168.1.10"}); err == nil || !strings.Contains(err.Error(), "usage: forwards add") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
+
+func TestRemoveForward(t *testing.T) {
+	tests := []struct {
+		query, wantID string
+	}{
+		{"fwd2", "fwd2"},
+		{"nas web", "fwd1"},
+		{"fwd4", "fwd4"},
+	}
+	for _, tt := range tests {
+		var deleted string
+		mock := &mockClient{
+			GetForwardsFn: func(networkID string) ([]api.Forward, error) {
+				return testForwards(), nil
+			},
+			DeleteForwardFn: func(networkID, forwardID string) error {
+				deleted = forwardID
+				return nil
+			},
+		}
+		app := newTestApp(mock)
+
+		captureStdout(t, func() {
+			if err := app.Forwards([]string{"remove", tt.query}); err != nil {
+				t.Fatalf("remove %q: unexpected error: %v", tt.query, err)
+			}
+		})
+		if deleted != tt.wantID {
+			t.Errorf("remove %q deleted %q, want %q", tt.query, deleted, tt.wantID)
+		}
+	}
+}
+
+func TestRemoveForwardErrors(t *testing.T) {
+	mock := &mockClient{
+		GetForwardsFn: func(networkID string) ([]api.Forward, error) {
+			return testForwards(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	if err := app.Forwards([]string{"remove", "minecraft"}); err == nil || !strings.Contains(err.Error(), "forward not found: minecraft") {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	if err := app.Forwards([]string{"remove", "ssh"}); err == nil || !strings.Contains(err.Error(), "remove one by ID") {
+		t.Errorf("expected ambiguous description error, got: %v", err)
+	}
+}
@@ -1,13 +1,29 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
 )
 
 // Guest handles the guest network command
 func (a *App) Guest(args []string) error {
-	if len(args) == 0 {
-		return a.GuestStatus()
+	// Parse flags
+	var reveal bool
+	var filteredArgs []string
+	for _, arg := range args {
+		if arg == "--reveal" {
+			reveal = true
+		} else {
+			filteredArgs = append(filteredArgs, arg)
+		}
+	}
+	args = filteredArgs
+
+	if len(args) == 0 || args[0] == "status" {
+		return a.GuestStatus(reveal)
 	}
 
 	switch args[0] {
@@ -17,26 +33,68 @@ func (a *App) Guest(args []string) error {
 		return a.GuestEnable(false)
 	case "password":
 		if len(args) < 2 {
-			return fmt.Errorf("usage: guest password <new-password>")
+			password, err := promptNewPassword(a.context())
+			if err != nil {
+				return err
+			}
+			return a.GuestPassword(password)
 		}
 		return a.GuestPassword(args[1])
+	case "setup":
+		const usage = "usage: guest setup --name <name> --password <password>"
+		var name, password string
+		for i := 1; i < len(args); i++ {
+			if args[i] == "--name" && i+1 < len(args) {
+				name = args[i+1]
+				i++ // skip the value
+			} else if strings.HasPrefix(args[i], "--name=") {
+				name = strings.TrimPrefix(args[i], "--name=")
+			} else if args[i] == "--password" && i+1 < len(args) {
+				password = args[i+1]
+				i++ // skip the value
+			} else if strings.HasPrefix(args[i], "--password=") {
+				password = strings.TrimPrefix(args[i], "--password=")
+			} else {
+				return fmt.Errorf(usage)
+			}
+		}
+		if name == "" || password == "" {
+			return fmt.Errorf(usage)
+		}
+		return a.GuestSetup(name, password)
+	case "rename":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: guest rename <name>")
+		}
+		return a.GuestRename(strings.Join(args[1:], " "))
+	case "clients":
+		return a.GuestClients()
 	default:
 		return fmt.Errorf("unknown guest subcommand: %s", args[0])
 	}
 }
 
-// GuestStatus shows the guest network status
-func (a *App) GuestStatus() error {
+// GuestStatus shows the guest network status. The password is masked
+// unless reveal is set.
+func (a *App) GuestStatus(reveal bool) error {
 	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
 	}
 
 	gn, err := a.Client.GetGuestNetwork(networkID)
-	if err != nil {
+	if err != nil && !api.IsNotFound(err) {
 		return fmt.Errorf("getting guest network: %w", err)
 	}
 
+	// Networks where the guest network was never set up either 404 or
+	// return an empty object
+	if err != nil || guestUnconfigured(gn) {
+		fmt.Println("Guest network is not configured on this network.")
+		fmt.Println("Use 'eero-cli guest setup --name <name> --password <password>' to set one up.")
+		return nil
+	}
+
 	status := "disabled"
 	if gn.Enabled {
 		status = "enabled"
@@ -49,7 +107,11 @@ func (a *App) GuestStatus() error {
 		fmt.Printf("Name:     %s\n", gn.Name)
 	}
 	if gn.Enabled && gn.Password != "" {
-		fmt.Printf("Password: %s\n", gn.Password)
+		password := gn.Password
+		if !reveal {
+			password = maskSecret(password)
+		}
+		fmt.Printf("Password: %s\n", password)
 	}
 
 	return nil
@@ -90,3 +152,99 @@ func (a *App) GuestPassword(password string) error {
 
 	return nil
 }
+
+// promptNewPassword asks for a new guest password twice, without echo, and
+// returns it if both entries match
+func promptNewPassword(ctx context.Context) (string, error) {
+	password := PromptSecret(ctx, "New guest password: ")
+	if password == "" {
+		return "", fmt.Errorf("password is required")
+	}
+	if PromptSecret(ctx, "Confirm password: ") != password {
+		return "", fmt.Errorf("passwords do not match")
+	}
+	return password, nil
+}
+
+// GuestRename changes the guest network name
+func (a *App) GuestRename(name string) error {
+	if err := validateSSID(name); err != nil {
+		return err
+	}
+	warnNonASCIISSID(name)
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	if err := a.Client.UpdateGuestNetwork(networkID, map[string]interface{}{"name": name}); err != nil {
+		return fmt.Errorf("updating guest network: %w", err)
+	}
+
+	fmt.Printf("Guest network renamed to '%s'\n", name)
+
+	return nil
+}
+
+// GuestSetup configures and enables the guest network in a single update
+func (a *App) GuestSetup(name, password string) error {
+	if err := validateSSID(name); err != nil {
+		return err
+	}
+	warnNonASCIISSID(name)
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"enabled":  true,
+		"name":     name,
+		"password": password,
+	}
+	if err := a.Client.UpdateGuestNetwork(networkID, updates); err != nil {
+		return fmt.Errorf("updating guest network: %w", err)
+	}
+
+	fmt.Printf("Guest network '%s' has been set up and enabled\n", name)
+
+	return nil
+}
+
+// GuestClients lists the devices on the guest network
+func (a *App) GuestClients() error {
+	// JSON and YAML output is just the device list, so it stays parseable
+	if a.structuredOutput() {
+		return a.ListDevices(DeviceFilters{Guest: true})
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	gn, err := a.Client.GetGuestNetwork(networkID)
+	if err != nil && !api.IsNotFound(err) {
+		return fmt.Errorf("getting guest network: %w", err)
+	}
+
+	if err != nil || guestUnconfigured(gn) {
+		fmt.Println("Guest network: not configured")
+	} else {
+		status := "disabled"
+		if gn.Enabled {
+			status = "enabled"
+		}
+		fmt.Printf("Guest network: %s (%s)\n", gn.Name, status)
+	}
+	fmt.Println()
+
+	return a.ListDevices(DeviceFilters{Guest: true})
+}
+
+// guestUnconfigured reports whether a guest network response is empty
+func guestUnconfigured(gn *api.GuestNetwork) bool {
+	return gn == nil || (!gn.Enabled && gn.Name == "" && gn.Password == "")
+}
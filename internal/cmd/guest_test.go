@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -21,7 +22,7 @@ func TestGuestStatusEnabled(t *testing.T) {
 	app := newTestApp(mock)
 
 	out := captureStdout(t, func() {
-		if err := app.GuestStatus(); err != nil {
+		if err := app.GuestStatus(false); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
@@ -32,8 +33,34 @@ func TestGuestStatusEnabled(t *testing.T) {
 	if !strings.Contains(out, "Home Guest") {
 		t.Error("output missing network name")
 	}
-	if !strings.Contains(out, "guestpass123") {
-		t.Error("output missing password")
+	if !strings.Contains(out, "guest•••••23") {
+		t.Errorf("output missing masked password:\n%s", out)
+	}
+	if strings.Contains(out, "guestpass123") {
+		t.Error("password should be masked by default")
+	}
+}
+
+func TestGuestStatusReveal(t *testing.T) {
+	mock := &mockClient{
+		GetGuestNetworkFn: func(networkID string) (*api.GuestNetwork, error) {
+			return &api.GuestNetwork{
+				Enabled:  true,
+				Name:     "Home Guest",
+				Password: "guestpass123",
+			}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Guest([]string{"--reveal"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Password: guestpass123") {
+		t.Errorf("--reveal should show the full password:\n%s", out)
 	}
 }
 
@@ -50,7 +77,7 @@ func TestGuestStatusDisabled(t *testing.T) {
 	app := newTestApp(mock)
 
 	out := captureStdout(t, func() {
-		if err := app.GuestStatus(); err != nil {
+		if err := app.GuestStatus(false); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
@@ -64,6 +91,139 @@ func TestGuestStatusDisabled(t *testing.T) {
 	}
 }
 
+func TestGuestStatusNotConfigured(t *testing.T) {
+	tests := []struct {
+		name string
+		gn   *api.GuestNetwork
+		err  error
+	}{
+		{"404", nil, &api.StatusError{StatusCode: 404, Message: "not found"}},
+		{"empty", &api.GuestNetwork{}, nil},
+	}
+
+	for _, tt := range tests {
+		mock := &mockClient{
+			GetGuestNetworkFn: func(networkID string) (*api.GuestNetwork, error) {
+				return tt.gn, tt.err
+			},
+		}
+		app := newTestApp(mock)
+
+		out := captureStdout(t, func() {
+			if err := app.GuestStatus(false); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+		})
+
+		if !strings.Contains(out, "not configured") {
+			t.Errorf("%s: output missing not-configured message, got:\n%s", tt.name, out)
+		}
+		if !strings.Contains(out, "guest setup") {
+			t.Errorf("%s: output missing setup hint, got:\n%s", tt.name, out)
+		}
+	}
+}
+
+func TestGuestStatusOtherErrorsPropagate(t *testing.T) {
+	mock := &mockClient{
+		GetGuestNetworkFn: func(networkID string) (*api.GuestNetwork, error) {
+			return nil, &api.StatusError{StatusCode: 500, Message: "internal server error"}
+		},
+	}
+	app := newTestApp(mock)
+
+	err := app.GuestStatus(false)
+	if err == nil || !strings.Contains(err.Error(), "internal server error") {
+		t.Errorf("expected API error, got: %v", err)
+	}
+}
+
+func TestGuestSetup(t *testing.T) {
+	var gotUpdates map[string]interface{}
+	mock := &mockClient{
+		UpdateGuestNetworkFn: func(networkID string, updates map[string]interface{}) error {
+			gotUpdates = updates
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Guest([]string{"setup", "--name", "Visitors", "--password=welcome123"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if gotUpdates["enabled"] != true || gotUpdates["name"] != "Visitors" || gotUpdates["password"] != "welcome123" {
+		t.Errorf("updates = %v", gotUpdates)
+	}
+	if !strings.Contains(out, "Visitors") {
+		t.Error("output missing network name")
+	}
+
+	err := app.Guest([]string{"setup", "--name", "Visitors"})
+	if err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("expected usage error without password, got: %v", err)
+	}
+}
+
+func TestGuestSetupRejectsLongName(t *testing.T) {
+	mock := &mockClient{
+		UpdateGuestNetworkFn: func(networkID string, updates map[string]interface{}) error {
+			t.Error("UpdateGuestNetwork should not be called for an invalid name")
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	err := app.GuestSetup(strings.Repeat("a", 33), "welcome123")
+	if err == nil || !strings.Contains(err.Error(), "maximum is 32 bytes") {
+		t.Errorf("expected length error, got: %v", err)
+	}
+}
+
+func TestGuestSetupRejectsUnknownFlag(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	for _, args := range [][]string{
+		{"setup", "--name", "Visitors", "--pasword", "welcome123"},
+		{"setup", "--name", "Visitors", "--password", "welcome123", "extra"},
+	} {
+		err := app.Guest(args)
+		if err == nil || !strings.HasPrefix(err.Error(), "usage: guest setup") {
+			t.Errorf("%v: expected usage error, got: %v", args, err)
+		}
+	}
+}
+
+func TestGuestRename(t *testing.T) {
+	var gotUpdates map[string]interface{}
+	mock := &mockClient{
+		UpdateGuestNetworkFn: func(networkID string, updates map[string]interface{}) error {
+			gotUpdates = updates
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Guest([]string{"rename", "Cafe", "Guests"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if len(gotUpdates) != 1 || gotUpdates["name"] != "Cafe Guests" {
+		t.Errorf("updates = %v, want only name", gotUpdates)
+	}
+	if !strings.Contains(out, "renamed to 'Cafe Guests'") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	if err := app.Guest([]string{"rename"}); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
+
 func TestGuestEnable(t *testing.T) {
 	var enableValue bool
 	mock := &mockClient{
@@ -136,6 +296,45 @@ func TestGuestPassword(t *testing.T) {
 	}
 }
 
+func TestGuestPasswordPrompt(t *testing.T) {
+	var gotPassword string
+	mock := &mockClient{
+		SetGuestNetworkPasswordFn: func(networkID, password string) error {
+			gotPassword = password
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	feedStdin(t, "newpass123\nnewpass123\n", func() {
+		captureStdout(t, func() {
+			err = app.Guest([]string{"password"})
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPassword != "newpass123" {
+		t.Errorf("password = %q, want %q", gotPassword, "newpass123")
+	}
+}
+
+func TestGuestPasswordPromptMismatch(t *testing.T) {
+	// No mock functions are set, so updating the password would panic
+	app := newTestApp(&mockClient{})
+
+	var err error
+	feedStdin(t, "newpass123\nnewpass124\n", func() {
+		captureStdout(t, func() {
+			err = app.Guest([]string{"password"})
+		})
+	})
+	if err == nil || err.Error() != "passwords do not match" {
+		t.Errorf("expected mismatch error, got: %v", err)
+	}
+}
+
 func TestGuestPasswordError(t *testing.T) {
 	mock := &mockClient{
 		SetGuestNetworkPasswordFn: func(networkID, password string) error {
@@ -153,6 +352,116 @@ func TestGuestPasswordError(t *testing.T) {
 	}
 }
 
+// guestDevices returns testDevices plus two guest devices
+func guestDevices() []api.Device {
+	return append(testDevices(),
+		api.Device{
+			URL:       "/2.2/networks/12345/devices/a1a1a1a1a1a1",
+			MAC:       "A1:A1:A1:A1:A1:A1",
+			Hostname:  "visitor-phone",
+			IP:        "192.168.2.50",
+			Connected: true,
+			Wireless:  true,
+			IsGuest:   true,
+		},
+		api.Device{
+			URL:      "/2.2/networks/12345/devices/b2b2b2b2b2b2",
+			MAC:      "B2:B2:B2:B2:B2:B2",
+			Hostname: "visitor-laptop",
+			IP:       "192.168.2.51",
+			Wireless: true,
+			IsGuest:  true,
+		},
+	)
+}
+
+func TestGuestClients(t *testing.T) {
+	mock := &mockClient{
+		GetGuestNetworkFn: func(networkID string) (*api.GuestNetwork, error) {
+			return &api.GuestNetwork{Enabled: true, Name: "Home Guest"}, nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return guestDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Guest([]string{"clients"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Guest network: Home Guest (enabled)") {
+		t.Errorf("output missing guest network header:\n%s", out)
+	}
+	for _, name := range []string{"visitor-phone", "visitor-laptop", "A1:A1:A1:A1:A1:A1", "192.168.2.51"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("output missing %q:\n%s", name, out)
+		}
+	}
+	for _, name := range []string{"My Laptop", "NAS", "EE:FF:00:11:22:33"} {
+		if strings.Contains(out, name) {
+			t.Errorf("output should not contain non-guest device %q", name)
+		}
+	}
+	if !strings.Contains(out, "Total: 2 devices") {
+		t.Errorf("expected 2 guest devices, got:\n%s", out)
+	}
+}
+
+func TestGuestClientsJSON(t *testing.T) {
+	mock := &mockClient{
+		GetGuestNetworkFn: func(networkID string) (*api.GuestNetwork, error) {
+			return &api.GuestNetwork{Enabled: true, Name: "Home Guest"}, nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return guestDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Guest([]string{"clients"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var devices []api.Device
+	if err := json.Unmarshal([]byte(out), &devices); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(devices) != 2 {
+		t.Errorf("expected 2 guest devices, got %d", len(devices))
+	}
+}
+
+func TestGuestClientsNotConfigured(t *testing.T) {
+	mock := &mockClient{
+		GetGuestNetworkFn: func(networkID string) (*api.GuestNetwork, error) {
+			return nil, &api.StatusError{StatusCode: 404}
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.GuestClients(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Guest network: not configured") {
+		t.Errorf("output missing not-configured header:\n%s", out)
+	}
+	if !strings.Contains(out, "Total: 0 devices") {
+		t.Errorf("expected no guest devices, got:\n%s", out)
+	}
+}
+
 func TestGuestCommandRouting(t *testing.T) {
 	mock := &mockClient{
 		EnableGuestNetworkFn: func(networkID string, enable bool) error {
@@ -177,10 +486,15 @@ func TestGuestCommandRouting(t *testing.T) {
 		}
 	})
 
-	// Test missing password argument
-	err := app.Guest([]string{"password"})
-	if err == nil || !strings.Contains(err.Error(), "usage") {
-		t.Errorf("expected usage error, got: %v", err)
+	// Without an argument, password prompts; no input is an error
+	var err error
+	feedStdin(t, "", func() {
+		captureStdout(t, func() {
+			err = app.Guest([]string{"password"})
+		})
+	})
+	if err == nil || !strings.Contains(err.Error(), "password is required") {
+		t.Errorf("expected required error, got: %v", err)
 	}
 
 	// Test unknown subcommand
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// Internet handles the internet command
+func (a *App) Internet(args []string) error {
+	if len(args) == 0 || args[0] == "status" {
+		return a.InternetStatus()
+	}
+
+	switch args[0] {
+	case "pause":
+		return a.InternetPause(true)
+	case "resume", "unpause":
+		return a.InternetPause(false)
+	default:
+		return fmt.Errorf("unknown internet subcommand: %s", args[0])
+	}
+}
+
+// InternetStatus shows whether internet access is paused
+func (a *App) InternetStatus() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	paused, err := a.Client.GetInternetPause(networkID)
+	if err == nil {
+		state := "active"
+		if paused {
+			state = "paused"
+		}
+		fmt.Printf("Internet: %s (network-wide pause)\n", state)
+		return nil
+	}
+	if !api.IsNotFound(err) {
+		return fmt.Errorf("getting internet pause: %w", err)
+	}
+
+	// No network-wide switch; report the profile pause state instead
+	profiles, err := a.profiles(networkID)
+	if err != nil {
+		return fmt.Errorf("getting profiles: %w", err)
+	}
+
+	var pausedCount int
+	for _, p := range profiles {
+		if p.Paused {
+			pausedCount++
+		}
+	}
+
+	switch {
+	case len(profiles) > 0 && pausedCount == len(profiles):
+		fmt.Printf("Internet: paused (all %d profiles paused)\n", len(profiles))
+	case pausedCount > 0:
+		fmt.Printf("Internet: partially paused (%d of %d profiles paused)\n", pausedCount, len(profiles))
+	default:
+		fmt.Println("Internet: active (no profiles paused)")
+	}
+	fmt.Println("This network has no network-wide pause; status is based on profiles.")
+
+	return nil
+}
+
+// InternetPause pauses or resumes internet access. The network-wide pause
+// switch is used when the firmware supports it; otherwise every profile is
+// paused or resumed individually.
+func (a *App) InternetPause(pause bool) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	err = a.Client.SetInternetPause(networkID, pause)
+	if err == nil {
+		fmt.Printf("Internet %s (network-wide pause)\n", pausedLabel(pause))
+		return nil
+	}
+	if !api.IsNotFound(err) {
+		return fmt.Errorf("updating internet pause: %w", err)
+	}
+
+	profiles, err := a.profiles(networkID)
+	if err != nil {
+		return fmt.Errorf("getting profiles: %w", err)
+	}
+	if len(profiles) == 0 {
+		return fmt.Errorf("this network has no network-wide pause and no profiles to pause")
+	}
+
+	done, err := a.pauseEachProfile(networkID, profiles, pause)
+	fmt.Printf("Internet %s via per-profile pause (%d of %d profiles)\n", pausedLabel(pause), done, len(profiles))
+	if pause {
+		fmt.Println("Note: devices without a profile are not paused.")
+	}
+	return err
+}
+
+// pausedLabel formats a pause state for display
+func pausedLabel(paused bool) string {
+	if paused {
+		return "paused"
+	}
+	return "resumed"
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestInternetPauseDirect(t *testing.T) {
+	var gotPaused *bool
+	mock := &mockClient{
+		SetInternetPauseFn: func(networkID string, paused bool) error {
+			gotPaused = &paused
+			return nil
+		},
+		PauseProfileFn: func(networkID, profileID string, pause bool) error {
+			t.Error("profiles should not be paused when the network-wide switch works")
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Internet([]string{"pause"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if gotPaused == nil || !*gotPaused {
+		t.Errorf("SetInternetPause called with %v, want true", gotPaused)
+	}
+	if !strings.Contains(out, "Internet paused (network-wide pause)") {
+		t.Errorf("output should name the mechanism used:\n%s", out)
+	}
+
+	captureStdout(t, func() {
+		if err := app.Internet([]string{"resume"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if *gotPaused {
+		t.Error("resume should call SetInternetPause(false)")
+	}
+}
+
+func TestInternetPauseFallsBackToProfiles(t *testing.T) {
+	paused := make(map[string]bool)
+	mock := &mockClient{
+		SetInternetPauseFn: func(networkID string, p bool) error {
+			return &api.StatusError{StatusCode: 404}
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		PauseProfileFn: func(networkID, profileID string, pause bool) error {
+			paused[profileID] = pause
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.InternetPause(true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !paused["prof1"] || !paused["prof2"] {
+		t.Errorf("paused = %v, want both profiles paused", paused)
+	}
+	if !strings.Contains(out, "via per-profile pause (2 of 2 profiles)") {
+		t.Errorf("output should name the fallback mechanism:\n%s", out)
+	}
+	if !strings.Contains(out, "devices without a profile are not paused") {
+		t.Errorf("output should warn about unprofiled devices:\n%s", out)
+	}
+}
+
+func TestInternetPauseFallbackContinuesPastFailure(t *testing.T) {
+	var calls []string
+	mock := &mockClient{
+		SetInternetPauseFn: func(networkID string, p bool) error {
+			return &api.StatusError{StatusCode: 404}
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		PauseProfileFn: func(networkID, profileID string, pause bool) error {
+			calls = append(calls, profileID)
+			if profileID == "prof1" {
+				return fmt.Errorf("server error")
+			}
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = app.InternetPause(true)
+	})
+
+	if len(calls) != 2 {
+		t.Errorf("PauseProfile calls = %v, want both profiles tried", calls)
+	}
+	if err == nil || err.Error() != "failed to pause 1 of 2 profiles" {
+		t.Errorf("error = %v, want failed to pause 1 of 2 profiles", err)
+	}
+	if !strings.Contains(out, "Failed to pause profile ") || !strings.Contains(out, "(1 of 2 profiles)") {
+		t.Errorf("output should report the failure and count:\n%s", out)
+	}
+}
+
+func TestInternetPauseOtherErrorsDoNotFallBack(t *testing.T) {
+	mock := &mockClient{
+		SetInternetPauseFn: func(networkID string, p bool) error {
+			return &api.StatusError{StatusCode: 500}
+		},
+	}
+	app := newTestApp(mock)
+
+	if err := app.InternetPause(true); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestInternetStatus(t *testing.T) {
+	mock := &mockClient{
+		GetInternetPauseFn: func(networkID string) (bool, error) {
+			return true, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Internet(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Internet: paused (network-wide pause)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	mock.GetInternetPauseFn = func(networkID string) (bool, error) {
+		return false, &api.StatusError{StatusCode: 404}
+	}
+	mock.GetProfilesFn = func(networkID string) ([]api.Profile, error) {
+		return testProfiles(), nil
+	}
+	out = captureStdout(t, func() {
+		if err := app.InternetStatus(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "partially paused (1 of 2 profiles paused)") {
+		t.Errorf("unexpected fallback output: %s", out)
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// deviceLocation is where devices locate found a device
+type deviceLocation struct {
+	NetworkID   string     `json:"network_id"`
+	NetworkName string     `json:"network_name"`
+	Node        string     `json:"node,omitempty"`
+	Device      api.Device `json:"device"`
+}
+
+// LocateDevice searches every network on the account for a MAC address and
+// reports which network, and which eero node, the device is on
+func (a *App) LocateDevice(mac string) error {
+	if err := a.EnsureAuth(); err != nil {
+		return err
+	}
+
+	account, err := a.Client.GetAccount()
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+	networks := account.Networks.Data
+	if len(networks) == 0 {
+		return fmt.Errorf("no networks found on this account")
+	}
+
+	// Networks are independent, so fetch their devices concurrently
+	results := make([][]api.Device, len(networks))
+	errs := make([]error, len(networks))
+	var wg sync.WaitGroup
+	for i, n := range networks {
+		wg.Add(1)
+		go func(i int, networkID string) {
+			defer wg.Done()
+			results[i], errs[i] = a.Client.GetDevices(networkID)
+		}(i, api.ExtractNetworkID(n.URL))
+	}
+	wg.Wait()
+
+	want := normalizeMAC(mac)
+	found := []deviceLocation{}
+	failed := 0
+	for i, n := range networks {
+		if errs[i] != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "Warning: could not search network %s: %v\n", n.Name, errs[i])
+			continue
+		}
+		for _, d := range results[i] {
+			if normalizeMAC(d.MAC) != want {
+				continue
+			}
+			loc := deviceLocation{
+				NetworkID:   api.ExtractNetworkID(n.URL),
+				NetworkName: n.Name,
+				Device:      d,
+			}
+			if d.Source != nil {
+				loc.Node = d.Source.Location
+			}
+			found = append(found, loc)
+		}
+	}
+
+	if len(found) == 0 {
+		if failed == len(networks) {
+			return fmt.Errorf("could not search any network for %s", mac)
+		}
+		if failed > 0 {
+			return fmt.Errorf("device %s not found on the %d networks searched (%d could not be searched)", mac, len(networks)-failed, failed)
+		}
+		return notFoundf("device %s not found on any network", mac)
+	}
+
+	if a.structuredOutput() {
+		return a.printData(found)
+	}
+
+	headers := []string{"NETWORK", "NETWORK ID", "DEVICE", "IP", "NODE", "STATUS"}
+	var rows [][]string
+	for _, loc := range found {
+		node := loc.Node
+		if node == "" {
+			node = "-"
+		}
+		rows = append(rows, []string{
+			loc.NetworkName,
+			loc.NetworkID,
+			loc.Device.DisplayName(),
+			loc.Device.DisplayIP(),
+			node,
+			a.statusColor(deviceStatus(loc.Device)),
+		})
+	}
+	PrintTable(headers, rows)
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// twoNetworkLocateMock has a Home network (12345) with the usual test devices
+// and a Cabin network (67890) with one device behind the Loft eero
+func twoNetworkLocateMock() *mockClient {
+	mock := multiNetworkMock()
+	mock.GetDevicesFn = func(networkID string) ([]api.Device, error) {
+		switch networkID {
+		case "12345":
+			return testDevices(), nil
+		case "67890":
+			return []api.Device{{
+				URL:       "/2.2/networks/67890/devices/c0ffee000001",
+				MAC:       "C0:FF:EE:00:00:01",
+				Nickname:  "Cabin Camera",
+				IP:        "10.0.0.20",
+				Connected: true,
+				Source:    &api.DeviceSource{URL: "/2.2/eeros/777", Location: "Loft"},
+			}}, nil
+		}
+		return nil, fmt.Errorf("unexpected network %s", networkID)
+	}
+	return mock
+}
+
+func TestLocateDeviceOnSecondNetwork(t *testing.T) {
+	app := newTestApp(twoNetworkLocateMock())
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"locate", "c0-ff-ee-00-00-01"}); err != nil {
+			t.Fatalf("Devices locate error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"Cabin", "67890", "Cabin Camera", "Loft"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Home") {
+		t.Errorf("device reported on the wrong network:\n%s", out)
+	}
+}
+
+func TestLocateDeviceJSON(t *testing.T) {
+	app := newTestApp(twoNetworkLocateMock())
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.LocateDevice("aabbccdd1122"); err != nil {
+			t.Fatalf("LocateDevice error: %v", err)
+		}
+	})
+
+	var found []deviceLocation
+	if err := json.Unmarshal([]byte(out), &found); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(found) != 1 || found[0].NetworkID != "12345" || found[0].Device.Nickname != "My Laptop" {
+		t.Errorf("found = %+v", found)
+	}
+}
+
+func TestLocateDeviceNotFound(t *testing.T) {
+	app := newTestApp(twoNetworkLocateMock())
+
+	err := app.LocateDevice("de:ad:be:ef:00:00")
+	if err == nil || !strings.Contains(err.Error(), "not found on any network") {
+		t.Errorf("error = %v, want not found on any network", err)
+	}
+	if got := ExitCode(err); got != ExitNotFound {
+		t.Errorf("exit code = %d, want %d", got, ExitNotFound)
+	}
+}
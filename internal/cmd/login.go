@@ -2,63 +2,242 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/dorin/eero-cli/internal/api"
 	"github.com/dorin/eero-cli/internal/config"
 )
 
-// Login handles the login command
-func (a *App) Login() error {
-	identity := Prompt("Enter your email or phone number: ")
+// Login handles the login command. --identity and --code supply the email or
+// phone number and the verification code instead of prompting for them.
+func (a *App) Login(args []string) error {
+	var identity, code string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--identity" && i+1 < len(args) {
+			identity = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--identity=") {
+			identity = strings.TrimPrefix(args[i], "--identity=")
+		} else if args[i] == "--code" && i+1 < len(args) {
+			code = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--code=") {
+			code = strings.TrimPrefix(args[i], "--code=")
+		} else {
+			return fmt.Errorf("usage: login [--identity <email|phone>] [--code <code>]")
+		}
+	}
+
+	if config.ReadOnly() {
+		return fmt.Errorf("login saves the token: %w; set %s instead", config.ErrReadOnly, config.EnvToken)
+	}
+
+	if identity == "" {
+		identity = Prompt(a.context(), "Enter your email or phone number: ")
+	}
 	if identity == "" {
 		return fmt.Errorf("email or phone number is required")
 	}
 
-	fmt.Println("Requesting verification code...")
+	identity, kind, err := parseIdentity(identity)
+	if err != nil {
+		return err
+	}
+
+	a.progressf("Requesting verification code...\n")
 
 	loginResp, err := a.Client.Login(identity)
 	if err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
 
-	fmt.Println("A verification code has been sent to your email/phone.")
-	code := Prompt("Enter verification code: ")
+	a.progressf("A verification code has been sent to your %s.\n", kind)
+	if code == "" {
+		code = PromptSecret(a.context(), "Enter verification code: ")
+	}
 	if code == "" {
 		return fmt.Errorf("verification code is required")
 	}
 
-	fmt.Println("Verifying...")
+	a.progressf("Verifying...\n")
 
 	if err := a.Client.LoginVerify(loginResp.UserToken, code); err != nil {
 		return fmt.Errorf("verification failed: %w", err)
 	}
 
-	// Save the token
-	a.Config.Token = loginResp.UserToken
-	a.Client.SetToken(loginResp.UserToken)
+	token := loginResp.UserToken
+	a.Client.SetToken(token)
 
-	// Fetch and save network ID
+	// Fetch the network ID to save with the token
+	var networkID string
 	account, err := a.Client.GetAccount()
 	if err != nil {
 		// Token is saved, but couldn't get network
-		if err := a.Config.Save(); err != nil {
-			return fmt.Errorf("saving config: %w", err)
+		account = nil
+	} else if len(account.Networks.Data) > 0 {
+		networkID = api.ExtractNetworkID(account.Networks.Data[0].URL)
+	}
+
+	// Saving from the file, not a.Config, keeps --network and
+	// EERO_NETWORK_ID out of it when no network was found
+	active := a.Config.ActiveAccount()
+	unsaved := a.Config.Accounts
+	err = a.updateConfig(func(c *config.Config) {
+		// account add switches accounts in memory only, and may have stashed
+		// the previous one there
+		for name, acct := range unsaved {
+			if !c.HasAccount(name) {
+				if c.Accounts == nil {
+					c.Accounts = make(map[string]config.Account)
+				}
+				c.Accounts[name] = acct
+			}
+		}
+		if c.ActiveAccount() != active {
+			c.UseAccount(active)
+		}
+		c.Token = token
+		if networkID != "" {
+			c.NetworkID = networkID
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	return a.reportLogin(account)
+}
+
+// AuthStatus is the machine-readable result of login and status
+type AuthStatus struct {
+	Authenticated bool   `json:"authenticated"`
+	NetworkID     string `json:"network_id,omitempty"`
+	NetworkName   string `json:"network_name,omitempty"`
+}
+
+// authStatus describes an authenticated session, naming the configured
+// network when the account (which may be nil) lists it
+func (a *App) authStatus(account *api.Account) AuthStatus {
+	status := AuthStatus{Authenticated: true, NetworkID: a.Config.NetworkID}
+	if account != nil {
+		for _, n := range account.Networks.Data {
+			if api.ExtractNetworkID(n.URL) == status.NetworkID {
+				status.NetworkName = n.Name
+				break
+			}
 		}
+	}
+	return status
+}
+
+// reportLogin prints the result of a successful login. account is nil when
+// the account details couldn't be fetched.
+func (a *App) reportLogin(account *api.Account) error {
+	if a.structuredOutput() {
+		return a.printData(a.authStatus(account))
+	}
+
+	if account == nil {
 		fmt.Println("Login successful! (Warning: couldn't fetch network info)")
 		return nil
 	}
-
 	if len(account.Networks.Data) > 0 {
-		a.Config.NetworkID = api.ExtractNetworkID(account.Networks.Data[0].URL)
 		fmt.Printf("Logged in to network: %s\n", account.Networks.Data[0].Name)
 	}
+	fmt.Println("Login successful! Token saved.")
+	return nil
+}
 
-	if err := a.Config.Save(); err != nil {
-		return fmt.Errorf("saving config: %w", err)
+// progressf prints a progress line, on stderr in JSON and YAML modes so
+// stdout holds only the result
+func (a *App) progressf(format string, args ...interface{}) {
+	w := os.Stdout
+	if a.structuredOutput() {
+		w = os.Stderr
 	}
+	fmt.Fprintf(w, format, args...)
+}
 
-	fmt.Println("Login successful! Token saved.")
-	return nil
+// Identity kinds accepted by login
+const (
+	identityEmail = "email"
+	identityPhone = "phone"
+)
+
+// parseIdentity validates a login identity, returning the normalized value
+// and whether it is an email address or a phone number. Phone numbers are
+// normalized to E.164 where the country can be inferred.
+func parseIdentity(input string) (string, string, error) {
+	input = strings.TrimSpace(input)
+
+	if strings.Contains(input, "@") {
+		if !validEmail(input) {
+			return "", "", fmt.Errorf("invalid email address: %s", input)
+		}
+		return input, identityEmail, nil
+	}
+
+	phone, ok := normalizePhone(input)
+	if !ok {
+		return "", "", fmt.Errorf("invalid email or phone number: %s (phone numbers should contain 7-15 digits, e.g. +15551234567)", input)
+	}
+	return phone, identityPhone, nil
+}
+
+// validEmail performs a light syntactic check of an email address
+func validEmail(email string) bool {
+	if strings.ContainsAny(email, " \t") {
+		return false
+	}
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || strings.Contains(domain, "@") {
+		return false
+	}
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, l := range labels {
+		if l == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizePhone strips formatting from a phone number and returns it in
+// E.164 form. Ten-digit numbers without a country code are assumed to be
+// North American; other numbers without a '+' are passed through as digits.
+func normalizePhone(phone string) (string, bool) {
+	plus := strings.HasPrefix(phone, "+")
+	var digits strings.Builder
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", false
+		}
+	}
+
+	d := digits.String()
+	if len(d) < 7 || len(d) > 15 {
+		return "", false
+	}
+
+	switch {
+	case plus:
+		return "+" + d, true
+	case len(d) == 10:
+		return "+1" + d, true
+	case len(d) == 11 && d[0] == '1':
+		return "+" + d, true
+	default:
+		return d, true
+	}
 }
 
 // Logout handles the logout command
@@ -66,6 +245,14 @@ func (a *App) Logout() error {
 	if err := a.Config.Clear(); err != nil {
 		return fmt.Errorf("clearing config: %w", err)
 	}
+	if a.Config.TokenFromEnv {
+		fmt.Fprintf(os.Stderr, "Note: the token comes from %s; logout only clears the config file. Unset the variable to log out completely.\n", config.EnvToken)
+	}
+	if a.structuredOutput() {
+		return a.printData(struct {
+			LoggedOut bool `json:"logged_out"`
+		}{true})
+	}
 	fmt.Println("Logged out. Token cleared.")
 	return nil
 }
@@ -74,6 +261,10 @@ func (a *App) Logout() error {
 func (a *App) Status() error {
 	path, _ := config.ConfigPath()
 
+	if a.structuredOutput() {
+		return a.statusJSON(path)
+	}
+
 	if !a.Config.HasToken() {
 		fmt.Println("Status: Not logged in")
 		fmt.Printf("Config: %s\n", path)
@@ -116,3 +307,47 @@ func (a *App) Status() error {
 
 	return nil
 }
+
+// AccountStatus is the machine-readable result of status. It never includes
+// the token.
+type AccountStatus struct {
+	AuthStatus
+	Email      string           `json:"email,omitempty"`
+	Phone      string           `json:"phone,omitempty"`
+	Name       string           `json:"name,omitempty"`
+	Networks   []NetworkSummary `json:"networks,omitempty"`
+	ConfigPath string           `json:"config_path"`
+}
+
+// NetworkSummary is a network listed in AccountStatus
+type NetworkSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// statusJSON prints the authentication status as an AccountStatus
+func (a *App) statusJSON(path string) error {
+	status := AccountStatus{ConfigPath: path}
+	if !a.Config.HasToken() || !a.Client.ValidateToken() {
+		return a.printData(status)
+	}
+
+	// Account details are optional; the token is still valid without them
+	account, err := a.Client.GetAccount()
+	if err != nil {
+		account = nil
+	}
+	status.AuthStatus = a.authStatus(account)
+	if account != nil {
+		status.Email = account.Email.Value
+		status.Phone = account.Phone.Value
+		status.Name = account.Name
+		for _, n := range account.Networks.Data {
+			status.Networks = append(status.Networks, NetworkSummary{
+				ID:   api.ExtractNetworkID(n.URL),
+				Name: n.Name,
+			})
+		}
+	}
+	return a.printData(status)
+}
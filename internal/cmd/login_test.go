@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+func TestParseIdentityEmail(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"me@example.com", true},
+		{"  first.last+tag@mail.example.co.uk ", true},
+		{"me@example", false},
+		{"@example.com", false},
+		{"me@@example.com", false},
+		{"me@example..com", false},
+		{"me @example.com", false},
+	}
+
+	for _, tt := range tests {
+		value, kind, err := parseIdentity(tt.input)
+		if tt.valid {
+			if err != nil {
+				t.Errorf("parseIdentity(%q) error: %v", tt.input, err)
+				continue
+			}
+			if kind != identityEmail {
+				t.Errorf("parseIdentity(%q) kind = %q, want email", tt.input, kind)
+			}
+			if value != strings.TrimSpace(tt.input) {
+				t.Errorf("parseIdentity(%q) = %q", tt.input, value)
+			}
+		} else if err == nil {
+			t.Errorf("parseIdentity(%q) = %q, want error", tt.input, value)
+		}
+	}
+}
+
+func TestParseIdentityPhone(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"+15551234567", "+15551234567"},
+		{"(555) 123-4567", "+15551234567"},
+		{"1-555-123-4567", "+15551234567"},
+		{"+44 7700 900123", "+447700900123"},
+		{"555.123.4567", "+15551234567"},
+	}
+
+	for _, tt := range tests {
+		value, kind, err := parseIdentity(tt.input)
+		if err != nil {
+			t.Errorf("parseIdentity(%q) error: %v", tt.input, err)
+			continue
+		}
+		if kind != identityPhone {
+			t.Errorf("parseIdentity(%q) kind = %q, want phone", tt.input, kind)
+		}
+		if value != tt.want {
+			t.Errorf("parseIdentity(%q) = %q, want %q", tt.input, value, tt.want)
+		}
+	}
+}
+
+func TestParseIdentityInvalid(t *testing.T) {
+	for _, input := range []string{"john", "12345", "+1 555 CALL NOW", "1234567890123456", "555+1234567"} {
+		if value, _, err := parseIdentity(input); err == nil {
+			t.Errorf("parseIdentity(%q) = %q, want error", input, value)
+		}
+	}
+}
+
+func TestLoginRejectsInvalidIdentity(t *testing.T) {
+	mock := &mockClient{
+		LoginFn: func(identity string) (*api.LoginResponse, error) {
+			t.Errorf("Login called with invalid identity %q", identity)
+			return nil, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	feedStdin(t, "not-an-identity\n", func() {
+		captureStdout(t, func() {
+			err = app.Login(nil)
+		})
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "invalid email or phone number") {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+// loginMock accepts any identity and code, recording what it was sent
+func loginMock(gotIdentity, gotCode *string) *mockClient {
+	return &mockClient{
+		LoginFn: func(identity string) (*api.LoginResponse, error) {
+			*gotIdentity = identity
+			return &api.LoginResponse{UserToken: "tok-123"}, nil
+		},
+		LoginVerifyFn: func(userToken, code string) error {
+			*gotCode = code
+			return nil
+		},
+		GetAccountFn: func() (*api.Account, error) {
+			return homeAccount(), nil
+		},
+	}
+}
+
+func TestLoginWithIdentityAndCodeFlags(t *testing.T) {
+	useTempConfigDir(t)
+	var gotIdentity, gotCode string
+	app := newTestApp(loginMock(&gotIdentity, &gotCode))
+
+	// Nothing on stdin: any prompt would read an empty answer and fail
+	var err error
+	feedStdin(t, "", func() {
+		captureStdout(t, func() {
+			err = app.Login([]string{"--identity", "me@example.com", "--code=123456"})
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotIdentity != "me@example.com" || gotCode != "123456" {
+		t.Errorf("identity = %q, code = %q", gotIdentity, gotCode)
+	}
+
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if saved.Token != "tok-123" {
+		t.Errorf("saved token = %q, want tok-123", saved.Token)
+	}
+}
+
+func TestLoginDoesNotSaveNetworkOverride(t *testing.T) {
+	useTempConfigDir(t)
+	var gotIdentity, gotCode string
+	mock := loginMock(&gotIdentity, &gotCode)
+	mock.GetAccountFn = func() (*api.Account, error) {
+		return nil, &api.StatusError{StatusCode: 503}
+	}
+	app := newTestApp(mock)
+	// As set by --network or EERO_NETWORK_ID
+	app.Config.NetworkID = "override"
+
+	var err error
+	captureStdout(t, func() {
+		err = app.Login([]string{"--identity", "me@example.com", "--code", "123456"})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if saved.Token != "tok-123" || saved.NetworkID != "" {
+		t.Errorf("saved token = %q, network = %q; want tok-123 and no network", saved.Token, saved.NetworkID)
+	}
+	if app.Config.NetworkID != "override" {
+		t.Errorf("in-memory network = %q, want the override kept for this run", app.Config.NetworkID)
+	}
+}
+
+func TestLoginWithIdentityReadsCodeFromStdin(t *testing.T) {
+	useTempConfigDir(t)
+	var gotIdentity, gotCode string
+	app := newTestApp(loginMock(&gotIdentity, &gotCode))
+
+	var err error
+	feedStdin(t, "654321\n", func() {
+		captureStdout(t, func() {
+			err = app.Login([]string{"--identity=(555) 123-4567"})
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotIdentity != "+15551234567" || gotCode != "654321" {
+		t.Errorf("identity = %q, code = %q", gotIdentity, gotCode)
+	}
+}
+
+func TestPromptSecretFallsBackWithoutTerminal(t *testing.T) {
+	// feedStdin uses a pipe, which is not a terminal
+	var got string
+	feedStdin(t, "  s3cret \n", func() {
+		captureStdout(t, func() {
+			got = PromptSecret(context.Background(), "Password: ")
+		})
+	})
+	if got != "s3cret" {
+		t.Errorf("PromptSecret = %q, want s3cret", got)
+	}
+}
+
+func TestLoginRejectsUnknownFlag(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Login([]string{"--password", "x"})
+	if err == nil || !strings.Contains(err.Error(), "usage: login") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
+
+func TestLoginReadOnly(t *testing.T) {
+	useReadOnly(t)
+	// No mock functions are set, so any API call panics
+	app := newTestApp(&mockClient{})
+
+	err := app.Login(nil)
+	if !errors.Is(err, config.ErrReadOnly) || !strings.Contains(err.Error(), "EERO_TOKEN") {
+		t.Errorf("error = %v, want ErrReadOnly suggesting EERO_TOKEN", err)
+	}
+}
+
+func TestLogoutReadOnly(t *testing.T) {
+	useReadOnly(t)
+	app := newTestApp(&mockClient{})
+
+	if err := app.Logout(); !errors.Is(err, config.ErrReadOnly) {
+		t.Errorf("error = %v, want ErrReadOnly", err)
+	}
+	if !app.Config.HasToken() {
+		t.Error("token cleared in memory despite read-only mode")
+	}
+}
+
+func homeAccount() *api.Account {
+	account := &api.Account{Name: "Test User"}
+	account.Networks.Data = []api.Network{{URL: "/2.2/networks/12345", Name: "Home"}}
+	return account
+}
+
+func TestReportLoginJSON(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.reportLogin(homeAccount()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := `{"authenticated":true,"network_id":"12345","network_name":"Home"}`
+	if got := compactJSON(t, out); got != want {
+		t.Errorf("login result = %s, want %s", got, want)
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	path, _ := config.ConfigPath()
+	mock := &mockClient{
+		GetAccountFn: func() (*api.Account, error) {
+			account := homeAccount()
+			account.Email.Value = "user@example.com"
+			account.Phone.Value = "+15555550100"
+			account.Networks.Data = append(account.Networks.Data, api.Network{URL: "/2.2/networks/67890", Name: "Cabin"})
+			return account, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Status(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.Contains(out, app.Config.Token) {
+		t.Errorf("status output includes the token: %s", out)
+	}
+
+	var got AccountStatus
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !got.Authenticated {
+		t.Error("authenticated = false, want true")
+	}
+	if got.NetworkID != "12345" || got.NetworkName != "Home" {
+		t.Errorf("network = %q (%q), want 12345 (Home)", got.NetworkID, got.NetworkName)
+	}
+	if got.Email != "user@example.com" || got.Phone != "+15555550100" || got.Name != "Test User" {
+		t.Errorf("account = %q %q %q", got.Email, got.Phone, got.Name)
+	}
+	wantNetworks := []NetworkSummary{{ID: "12345", Name: "Home"}, {ID: "67890", Name: "Cabin"}}
+	if !reflect.DeepEqual(got.Networks, wantNetworks) {
+		t.Errorf("networks = %+v, want %+v", got.Networks, wantNetworks)
+	}
+	if got.ConfigPath != path {
+		t.Errorf("config_path = %q, want %q", got.ConfigPath, path)
+	}
+
+	mock.ValidateTokenFn = func() bool { return false }
+	out = captureStdout(t, func() {
+		if err := app.Status(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want, _ := json.Marshal(path)
+	if got := compactJSON(t, out); got != `{"authenticated":false,"config_path":`+string(want)+`}` {
+		t.Errorf("status with invalid token = %s", got)
+	}
+}
+
+func TestLogoutJSON(t *testing.T) {
+	useTempConfigDir(t)
+	app := newTestApp(&mockClient{})
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Logout(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if got := compactJSON(t, out); got != `{"logged_out":true}` {
+		t.Errorf("logout result = %s", got)
+	}
+}
+
+func TestLogoutWithEnvTokenNotes(t *testing.T) {
+	useTempConfigDir(t)
+	app := newTestApp(&mockClient{})
+	app.Config.Token = "env-token"
+	app.Config.TokenFromEnv = true
+
+	var stderr string
+	captureStdout(t, func() {
+		stderr = captureStderr(t, func() {
+			if err := app.Logout(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(stderr, "EERO_TOKEN") {
+		t.Errorf("expected a note about EERO_TOKEN, got %q", stderr)
+	}
+}
+
+// compactJSON strips insignificant whitespace from JSON output
+func compactJSON(t *testing.T, s string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(s)); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, s)
+	}
+	return buf.String()
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import "github.com/dorin/eero-cli/internal/api"
+
+// commandMemo holds API reads shared by the steps of a single command, so
+// finders and bulk operations don't re-fetch the same lists. An App runs one
+// command, so the memo lives exactly as long as the command.
+type commandMemo struct {
+	profiles map[string][]api.Profile
+	devices  map[string][]api.Device
+	eeros    map[string][]api.Eero
+}
+
+// profiles returns the network's profiles, fetching them once per command
+func (a *App) profiles(networkID string) ([]api.Profile, error) {
+	if p, ok := a.memo.profiles[networkID]; ok {
+		return p, nil
+	}
+	p, err := a.Client.GetProfiles(networkID)
+	if err != nil {
+		return nil, err
+	}
+	if a.memo.profiles == nil {
+		a.memo.profiles = make(map[string][]api.Profile)
+	}
+	a.memo.profiles[networkID] = p
+	return p, nil
+}
+
+// devices returns the network's devices, fetching them once per command.
+// Polling loops such as monitor must call the client directly.
+func (a *App) devices(networkID string) ([]api.Device, error) {
+	if d, ok := a.memo.devices[networkID]; ok {
+		return d, nil
+	}
+	d, err := a.Client.GetDevices(networkID)
+	if err != nil {
+		return nil, err
+	}
+	if a.memo.devices == nil {
+		a.memo.devices = make(map[string][]api.Device)
+	}
+	a.memo.devices[networkID] = d
+	return d, nil
+}
+
+// eeros returns the network's eeros, fetching them once per command
+func (a *App) eeros(networkID string) ([]api.Eero, error) {
+	if e, ok := a.memo.eeros[networkID]; ok {
+		return e, nil
+	}
+	e, err := a.Client.GetEeros(networkID)
+	if err != nil {
+		return nil, err
+	}
+	if a.memo.eeros == nil {
+		a.memo.eeros = make(map[string][]api.Eero)
+	}
+	a.memo.eeros[networkID] = e
+	return e, nil
+}
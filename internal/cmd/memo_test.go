@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// countingMock counts GetProfiles and GetDevices calls
+func countingMock(profileCalls, deviceCalls *int) *mockClient {
+	return &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			*profileCalls++
+			return testProfiles(), nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			*deviceCalls++
+			return testDevices(), nil
+		},
+	}
+}
+
+func TestPauseFetchesDevicesOnce(t *testing.T) {
+	var profileCalls, deviceCalls int
+	mock := countingMock(&profileCalls, &deviceCalls)
+	mock.PauseDeviceFn = func(networkID, deviceID string, pause bool) error { return nil }
+	app := newTestApp(mock)
+	app.LocalAddrs = func() (localAddrs, error) { return localAddrs{}, nil }
+
+	// The self-check and the pause both resolve the device
+	captureStdout(t, func() {
+		if err := app.Devices([]string{"pause", "phone"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if deviceCalls != 1 {
+		t.Errorf("GetDevices called %d times, want 1", deviceCalls)
+	}
+}
+
+func TestResolveDeviceIDsFetchesOnce(t *testing.T) {
+	var profileCalls, deviceCalls int
+	app := newTestApp(countingMock(&profileCalls, &deviceCalls))
+
+	ids, err := app.resolveDeviceIDs("12345", []string{"My Laptop", "phone", "NAS"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 3 {
+		t.Errorf("len(ids) = %d, want 3", len(ids))
+	}
+	if deviceCalls != 1 {
+		t.Errorf("GetDevices called %d times, want 1", deviceCalls)
+	}
+}
+
+func TestProfilesAddFetchesProfilesOnce(t *testing.T) {
+	var profileCalls, deviceCalls int
+	mock := countingMock(&profileCalls, &deviceCalls)
+	mock.GetProfileDetailsFn = func(networkID, profileID string) (*api.ProfileDetails, error) {
+		return &api.ProfileDetails{Name: "Kids"}, nil
+	}
+	mock.SetProfileDevicesFn = func(networkID, profileID string, deviceURLs []string) error { return nil }
+	app := newTestApp(mock)
+
+	captureStdout(t, func() {
+		if err := app.Profiles([]string{"add", "Kids", "phone"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// A second lookup in the same command is served from the memo
+		if _, err := app.findProfileID("12345", "Adults"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if profileCalls != 1 {
+		t.Errorf("GetProfiles called %d times, want 1", profileCalls)
+	}
+}
+
+func TestRebootEeroFetchesEerosOnce(t *testing.T) {
+	eeroCalls := 0
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			eeroCalls++
+			return testEeros(), nil
+		},
+		RebootEeroFn: func(eeroID string) error { return nil },
+	}
+	app := newTestApp(mock)
+
+	// Looking up the eero and its location share one fetch
+	feedStdin(t, "y\n", func() {
+		captureStdout(t, func() {
+			if err := app.RebootEero("8318690"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+	if eeroCalls != 1 {
+		t.Errorf("GetEeros called %d times, want 1", eeroCalls)
+	}
+
+	// Clearing the memo sends the next read to the client again
+	app.memo = commandMemo{}
+	if _, err := app.findEeroID("12345", "8318690"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eeroCalls != 2 {
+		t.Errorf("GetEeros called %d times after clearing the memo, want 2", eeroCalls)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/dorin/eero-cli/internal/api"
@@ -21,6 +23,8 @@ type mockClient struct {
 	SetTokenFn              func(token string)
 	GetAccountFn            func() (*api.Account, error)
 	GetDevicesFn            func(networkID string) ([]api.Device, error)
+	GetDevicesWithQueryFn   func(networkID string, q api.DeviceQuery) ([]api.Device, error)
+	GetDeviceUsageFn        func(networkID string) ([]api.Device, error)
 	GetDeviceRawFn          func(networkID, deviceID string) (json.RawMessage, error)
 	UpdateDeviceFn          func(networkID, deviceID string, updates map[string]interface{}) error
 	PauseDeviceFn           func(networkID, deviceID string, pause bool) error
@@ -32,7 +36,9 @@ type mockClient struct {
 	UpdateProfileFn         func(networkID, profileID string, updates map[string]interface{}) error
 	SetProfileDevicesFn     func(networkID, profileID string, deviceURLs []string) error
 	PauseProfileFn          func(networkID, profileID string, pause bool) error
+	SetProfileContentFilterFn func(networkID, profileID string, filters map[string]bool) error
 	GetEerosFn              func(networkID string) ([]api.Eero, error)
+	GetTopologyFn           func(networkID string) (*api.Topology, error)
 	GetEeroRawFn            func(eeroID string) (json.RawMessage, error)
 	RebootEeroFn            func(eeroID string) error
 	GetGuestNetworkFn       func(networkID string) (*api.GuestNetwork, error)
@@ -40,10 +46,24 @@ type mockClient struct {
 	EnableGuestNetworkFn    func(networkID string, enable bool) error
 	SetGuestNetworkPasswordFn func(networkID, password string) error
 	RebootFn                func(networkID string) error
+	GetRadioSettingsFn      func(networkID string) (*api.RadioSettings, error)
+	GetNetworkSettingsFn    func(networkID string) (*api.NetworkSettings, error)
+	SetRadioSettingFn       func(networkID, name string, on bool) error
+	GetDHCPFn               func(networkID string) (*api.DHCPSettings, error)
+	SetDHCPFn               func(networkID, subnet, start, end string) error
+	GetInternetPauseFn      func(networkID string) (bool, error)
+	SetInternetPauseFn      func(networkID string, paused bool) error
+	StartSpeedtestFn        func(networkID string) error
+	GetSpeedtestFn          func(networkID string) (*api.Speedtest, error)
 	GetReservationsFn       func(networkID string) ([]api.Reservation, error)
 	GetReservationRawFn     func(networkID, reservationID string) (json.RawMessage, error)
 	CreateReservationFn     func(networkID, ip, mac, description string) error
 	DeleteReservationFn     func(networkID, reservationID string) error
+	GetForwardsFn           func(networkID string) ([]api.Forward, error)
+	CreateForwardFn         func(networkID string, f api.Forward) error
+	DeleteForwardFn         func(networkID, forwardID string) error
+	GetUpdateStatusFn       func(networkID string) (*api.UpdateStatus, error)
+	ApplyUpdateFn           func(networkID string) error
 }
 
 func (m *mockClient) Login(identity string) (*api.LoginResponse, error) {
@@ -87,6 +107,25 @@ func (m *mockClient) GetDevices(networkID string) ([]api.Device, error) {
 	panic("mockClient.GetDevices not set")
 }
 
+// GetDevicesWithQuery falls back to GetDevicesFn, mimicking a server that
+// ignores the query parameters
+func (m *mockClient) GetDevicesWithQuery(networkID string, q api.DeviceQuery) ([]api.Device, error) {
+	if m.GetDevicesWithQueryFn != nil {
+		return m.GetDevicesWithQueryFn(networkID, q)
+	}
+	if m.GetDevicesFn != nil {
+		return m.GetDevicesFn(networkID)
+	}
+	panic("mockClient.GetDevicesWithQuery not set")
+}
+
+func (m *mockClient) GetDeviceUsage(networkID string) ([]api.Device, error) {
+	if m.GetDeviceUsageFn != nil {
+		return m.GetDeviceUsageFn(networkID)
+	}
+	panic("mockClient.GetDeviceUsage not set")
+}
+
 func (m *mockClient) GetDeviceRaw(networkID, deviceID string) (json.RawMessage, error) {
 	if m.GetDeviceRawFn != nil {
 		return m.GetDeviceRawFn(networkID, deviceID)
@@ -164,6 +203,13 @@ func (m *mockClient) PauseProfile(networkID, profileID string, pause bool) error
 	panic("mockClient.PauseProfile not set")
 }
 
+func (m *mockClient) SetProfileContentFilter(networkID, profileID string, filters map[string]bool) error {
+	if m.SetProfileContentFilterFn != nil {
+		return m.SetProfileContentFilterFn(networkID, profileID, filters)
+	}
+	panic("mockClient.SetProfileContentFilter not set")
+}
+
 func (m *mockClient) GetEeros(networkID string) ([]api.Eero, error) {
 	if m.GetEerosFn != nil {
 		return m.GetEerosFn(networkID)
@@ -171,6 +217,22 @@ func (m *mockClient) GetEeros(networkID string) ([]api.Eero, error) {
 	panic("mockClient.GetEeros not set")
 }
 
+// GetTopology falls back to deriving the tree from GetEerosFn, as the real
+// client does
+func (m *mockClient) GetTopology(networkID string) (*api.Topology, error) {
+	if m.GetTopologyFn != nil {
+		return m.GetTopologyFn(networkID)
+	}
+	if m.GetEerosFn != nil {
+		eeros, err := m.GetEerosFn(networkID)
+		if err != nil {
+			return nil, err
+		}
+		return api.BuildTopology(eeros), nil
+	}
+	panic("mockClient.GetTopology not set")
+}
+
 func (m *mockClient) GetEeroRaw(eeroID string) (json.RawMessage, error) {
 	if m.GetEeroRawFn != nil {
 		return m.GetEeroRawFn(eeroID)
@@ -220,6 +282,69 @@ func (m *mockClient) Reboot(networkID string) error {
 	panic("mockClient.Reboot not set")
 }
 
+func (m *mockClient) GetRadioSettings(networkID string) (*api.RadioSettings, error) {
+	if m.GetRadioSettingsFn != nil {
+		return m.GetRadioSettingsFn(networkID)
+	}
+	panic("mockClient.GetRadioSettings not set")
+}
+
+func (m *mockClient) GetNetworkSettings(networkID string) (*api.NetworkSettings, error) {
+	if m.GetNetworkSettingsFn != nil {
+		return m.GetNetworkSettingsFn(networkID)
+	}
+	panic("mockClient.GetNetworkSettings not set")
+}
+
+func (m *mockClient) SetRadioSetting(networkID, name string, on bool) error {
+	if m.SetRadioSettingFn != nil {
+		return m.SetRadioSettingFn(networkID, name, on)
+	}
+	panic("mockClient.SetRadioSetting not set")
+}
+
+func (m *mockClient) GetDHCP(networkID string) (*api.DHCPSettings, error) {
+	if m.GetDHCPFn != nil {
+		return m.GetDHCPFn(networkID)
+	}
+	panic("mockClient.GetDHCP not set")
+}
+
+func (m *mockClient) SetDHCP(networkID, subnet, start, end string) error {
+	if m.SetDHCPFn != nil {
+		return m.SetDHCPFn(networkID, subnet, start, end)
+	}
+	panic("mockClient.SetDHCP not set")
+}
+
+func (m *mockClient) GetInternetPause(networkID string) (bool, error) {
+	if m.GetInternetPauseFn != nil {
+		return m.GetInternetPauseFn(networkID)
+	}
+	panic("mockClient.GetInternetPause not set")
+}
+
+func (m *mockClient) SetInternetPause(networkID string, paused bool) error {
+	if m.SetInternetPauseFn != nil {
+		return m.SetInternetPauseFn(networkID, paused)
+	}
+	panic("mockClient.SetInternetPause not set")
+}
+
+func (m *mockClient) StartSpeedtest(networkID string) error {
+	if m.StartSpeedtestFn != nil {
+		return m.StartSpeedtestFn(networkID)
+	}
+	panic("mockClient.StartSpeedtest not set")
+}
+
+func (m *mockClient) GetSpeedtest(networkID string) (*api.Speedtest, error) {
+	if m.GetSpeedtestFn != nil {
+		return m.GetSpeedtestFn(networkID)
+	}
+	panic("mockClient.GetSpeedtest not set")
+}
+
 func (m *mockClient) GetReservations(networkID string) ([]api.Reservation, error) {
 	if m.GetReservationsFn != nil {
 		return m.GetReservationsFn(networkID)
@@ -248,6 +373,41 @@ func (m *mockClient) DeleteReservation(networkID, reservationID string) error {
 	panic("mockClient.DeleteReservation not set")
 }
 
+func (m *mockClient) GetForwards(networkID string) ([]api.Forward, error) {
+	if m.GetForwardsFn != nil {
+		return m.GetForwardsFn(networkID)
+	}
+	panic("mockClient.GetForwards not set")
+}
+
+func (m *mockClient) CreateForward(networkID string, f api.Forward) error {
+	if m.CreateForwardFn != nil {
+		return m.CreateForwardFn(networkID, f)
+	}
+	panic("mockClient.CreateForward not set")
+}
+
+func (m *mockClient) DeleteForward(networkID, forwardID string) error {
+	if m.DeleteForwardFn != nil {
+		return m.DeleteForwardFn(networkID, forwardID)
+	}
+	panic("mockClient.DeleteForward not set")
+}
+
+func (m *mockClient) GetUpdateStatus(networkID string) (*api.UpdateStatus, error) {
+	if m.GetUpdateStatusFn != nil {
+		return m.GetUpdateStatusFn(networkID)
+	}
+	panic("mockClient.GetUpdateStatus not set")
+}
+
+func (m *mockClient) ApplyUpdate(networkID string) error {
+	if m.ApplyUpdateFn != nil {
+		return m.ApplyUpdateFn(networkID)
+	}
+	panic("mockClient.ApplyUpdate not set")
+}
+
 // newTestApp creates an App with the given mock client and a pre-configured
 // network ID, bypassing EnsureAuth / EnsureNetwork.
 func newTestApp(mock *mockClient) *App {
@@ -260,6 +420,18 @@ func newTestApp(mock *mockClient) *App {
 	}
 }
 
+// useTempConfigDir points the config (and cache) directory at a temp
+// directory for the test, returning the eero-cli config directory
+func useTempConfigDir(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("config directory override requires XDG_CONFIG_HOME (linux)")
+	}
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	return filepath.Join(dir, "eero-cli")
+}
+
 // captureStdout redirects os.Stdout for the duration of fn and returns
 // whatever was written. This avoids refactoring all commands to accept
 // an io.Writer.
@@ -286,6 +458,53 @@ func captureStdout(t *testing.T, fn func()) string {
 	return string(out)
 }
 
+// captureStderr captures stderr output during fn execution
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	os.Stderr = w
+
+	fn()
+
+	w.Close()
+	os.Stderr = old
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading captured stderr: %v", err)
+	}
+	return string(out)
+}
+
+// feedStdin replaces os.Stdin with a pipe containing input for the duration
+// of fn, so interactive prompts can be exercised in tests.
+func feedStdin(t *testing.T, input string, fn func()) {
+	t.Helper()
+
+	old := os.Stdin
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	if _, err := w.WriteString(input); err != nil {
+		t.Fatalf("writing stdin: %v", err)
+	}
+	w.Close()
+	os.Stdin = r
+
+	defer func() {
+		os.Stdin = old
+		r.Close()
+	}()
+
+	fn()
+}
+
 // testDevices returns a standard set of devices for testing
 func testDevices() []api.Device {
 	return []api.Device{
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// Networks handles the networks command
+func (a *App) Networks(args []string) error {
+	if len(args) == 0 {
+		return a.ListNetworks()
+	}
+
+	switch args[0] {
+	case "list":
+		return a.ListNetworks()
+	case "use":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: networks use <network>")
+		}
+		return a.UseNetwork(strings.Join(args[1:], " "))
+	default:
+		return fmt.Errorf("unknown networks subcommand: %s", args[0])
+	}
+}
+
+// ListNetworks lists the account's networks, marking the one in use
+func (a *App) ListNetworks() error {
+	if err := a.EnsureAuth(); err != nil {
+		return err
+	}
+
+	account, err := a.Client.GetAccount()
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if len(account.Networks.Data) == 0 {
+		fmt.Println("No networks found")
+		return nil
+	}
+
+	headers := []string{"ID", "NAME", "CURRENT"}
+	var rows [][]string
+	for _, n := range account.Networks.Data {
+		networkID := api.ExtractNetworkID(n.URL)
+		current := ""
+		if networkID == a.Config.NetworkID {
+			current = "*"
+		}
+		rows = append(rows, []string{networkID, n.Name, current})
+	}
+
+	PrintTable(headers, rows)
+	return nil
+}
+
+// UseNetwork saves the network (by ID or name) that commands operate on
+func (a *App) UseNetwork(query string) error {
+	if err := a.EnsureAuth(); err != nil {
+		return err
+	}
+
+	account, err := a.Client.GetAccount()
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	var candidates []string
+	for _, n := range account.Networks.Data {
+		networkID := api.ExtractNetworkID(n.URL)
+		candidates = append(candidates, n.Name)
+		if networkID == query || strings.EqualFold(n.Name, query) {
+			if err := a.saveNetworkID(networkID); err != nil {
+				return fmt.Errorf("saving config: %w", err)
+			}
+			fmt.Printf("Using network '%s' (%s)\n", n.Name, networkID)
+			return nil
+		}
+	}
+
+	return notFoundError("network", query, candidates)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// multiNetworkMock returns a mock account with two networks
+func multiNetworkMock() *mockClient {
+	return &mockClient{
+		GetAccountFn: func() (*api.Account, error) {
+			account := &api.Account{Name: "Test User"}
+			account.Networks.Data = []api.Network{
+				{URL: "/2.2/networks/12345", Name: "Home"},
+				{URL: "/2.2/networks/67890", Name: "Cabin"},
+			}
+			return account, nil
+		},
+	}
+}
+
+func TestEnsureNetworkAutoSelectNotice(t *testing.T) {
+	useTempConfigDir(t)
+	app := newTestApp(multiNetworkMock())
+	app.Config.NetworkID = ""
+
+	var networkID string
+	stderr := captureStderr(t, func() {
+		var err error
+		networkID, err = app.EnsureNetwork()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if networkID != "12345" {
+		t.Errorf("networkID = %q, want first network", networkID)
+	}
+	want := "Using network 'Home' (12345); you have 2 networks"
+	if !strings.Contains(stderr, want) {
+		t.Errorf("stderr = %q, want notice containing %q", stderr, want)
+	}
+
+	// Once a network is saved, no notice is printed
+	stderr = captureStderr(t, func() {
+		app.EnsureNetwork()
+	})
+	if stderr != "" {
+		t.Errorf("unexpected notice after network was chosen: %q", stderr)
+	}
+}
+
+func TestEnsureNetworkAutoSelectQuiet(t *testing.T) {
+	useTempConfigDir(t)
+	app := newTestApp(multiNetworkMock())
+	app.Config.NetworkID = ""
+	app.Quiet = true
+
+	stderr := captureStderr(t, func() {
+		if _, err := app.EnsureNetwork(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Errorf("--quiet should suppress the notice, got %q", stderr)
+	}
+}
+
+func TestUseNetwork(t *testing.T) {
+	useTempConfigDir(t)
+	app := newTestApp(multiNetworkMock())
+
+	out := captureStdout(t, func() {
+		if err := app.Networks([]string{"use", "cabin"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if app.Config.NetworkID != "67890" {
+		t.Errorf("NetworkID = %q, want %q", app.Config.NetworkID, "67890")
+	}
+	if !strings.Contains(out, "Using network 'Cabin' (67890)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if saved.NetworkID != "67890" {
+		t.Errorf("saved NetworkID = %q, want %q", saved.NetworkID, "67890")
+	}
+
+	err = app.UseNetwork("Cabn")
+	if err == nil || !strings.Contains(err.Error(), "did you mean 'Cabin'") {
+		t.Errorf("expected suggestion error, got: %v", err)
+	}
+}
+
+func TestListNetworks(t *testing.T) {
+	app := newTestApp(multiNetworkMock())
+
+	out := captureStdout(t, func() {
+		if err := app.Networks(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"Home", "Cabin", "*"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// useReadOnly turns on read-only mode for the test
+func useReadOnly(t *testing.T) {
+	t.Helper()
+	config.SetReadOnly(true)
+	t.Cleanup(func() { config.SetReadOnly(false) })
+}
+
+func TestEnsureNetworkReadOnlyDoesNotSave(t *testing.T) {
+	dir := useTempConfigDir(t)
+	useReadOnly(t)
+	app := newTestApp(multiNetworkMock())
+	app.Config.NetworkID = ""
+	app.Quiet = true
+
+	networkID, err := app.EnsureNetwork()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if networkID != "12345" || app.Config.NetworkID != "12345" {
+		t.Errorf("networkID = %q, config = %q; want 12345 for this run", networkID, app.Config.NetworkID)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("config directory written in read-only mode: %v", err)
+	}
+}
+
+func TestUseNetworkReadOnly(t *testing.T) {
+	dir := useTempConfigDir(t)
+	useReadOnly(t)
+	app := newTestApp(multiNetworkMock())
+
+	err := app.UseNetwork("Cabin")
+	if !errors.Is(err, config.ErrReadOnly) {
+		t.Errorf("error = %v, want ErrReadOnly", err)
+	}
+	if app.Config.NetworkID != "12345" {
+		t.Errorf("NetworkID = %q, want unchanged", app.Config.NetworkID)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("config directory written in read-only mode: %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// Color modes accepted by --color
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// Options holds global flags that apply to every command
+type Options struct {
+	Color      string
+	EnvFile    string
+	Network    string
+	Output     string
+	Envelope   bool
+	Compact    bool
+	OnlyFields []string
+	StaleOK    bool
+	Debug      bool
+	Quiet      bool
+	Stats      bool
+	ReadOnly   bool
+	Keyring    bool
+
+	// Timeout and Retries override the config's client settings when set.
+	// Retries is -1 when --retries was not given.
+	Timeout time.Duration
+	Retries int
+
+	// Pin is a normalized certificate public key pin (see api.ParsePin)
+	Pin string
+
+	// APIURL replaces the eero API base URL when set
+	APIURL string
+
+	// Context is cancelled to stop the command, such as on Ctrl+C. API
+	// requests, waits, and prompts honor it. Nil means never cancelled.
+	Context context.Context
+}
+
+// ParseGlobalFlags extracts global flags from args, returning the options
+// and the remaining (command-specific) arguments
+func ParseGlobalFlags(args []string) (Options, []string, error) {
+	opts := Options{Color: ColorAuto, Output: OutputTable, Retries: -1}
+	var rest []string
+	var timeout, retries, pin, apiURL string
+
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--color" && i+1 < len(args) {
+			opts.Color = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--color=") {
+			opts.Color = strings.TrimPrefix(args[i], "--color=")
+		} else if args[i] == "--env-file" && i+1 < len(args) {
+			opts.EnvFile = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--env-file=") {
+			opts.EnvFile = strings.TrimPrefix(args[i], "--env-file=")
+		} else if args[i] == "--network" && i+1 < len(args) {
+			opts.Network = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--network=") {
+			opts.Network = strings.TrimPrefix(args[i], "--network=")
+		} else if args[i] == "--no-color" {
+			opts.Color = ColorNever
+		} else if (args[i] == "--output" || args[i] == "-o") && i+1 < len(args) {
+			opts.Output = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--output=") {
+			opts.Output = strings.TrimPrefix(args[i], "--output=")
+		} else if args[i] == "--json" {
+			opts.Output = OutputJSON
+		} else if args[i] == "--compact" {
+			opts.Compact = true
+		} else if args[i] == "--pretty" {
+			opts.Compact = false
+		} else if args[i] == "--envelope" {
+			opts.Envelope = true
+		} else if args[i] == "--stale-ok" {
+			opts.StaleOK = true
+		} else if args[i] == "--debug" {
+			opts.Debug = true
+		} else if args[i] == "--read-only" {
+			opts.ReadOnly = true
+		} else if args[i] == "--keyring" {
+			opts.Keyring = true
+		} else if args[i] == "--stats" {
+			opts.Stats = true
+		} else if args[i] == "--quiet" || args[i] == "-q" {
+			opts.Quiet = true
+		} else if args[i] == "--only-fields" && i+1 < len(args) {
+			opts.OnlyFields = splitFields(args[i+1])
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--only-fields=") {
+			opts.OnlyFields = splitFields(strings.TrimPrefix(args[i], "--only-fields="))
+		} else if args[i] == "--timeout" && i+1 < len(args) {
+			timeout = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--timeout=") {
+			timeout = strings.TrimPrefix(args[i], "--timeout=")
+		} else if args[i] == "--retries" && i+1 < len(args) {
+			retries = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--retries=") {
+			retries = strings.TrimPrefix(args[i], "--retries=")
+		} else if args[i] == "--pin" && i+1 < len(args) {
+			pin = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--pin=") {
+			pin = strings.TrimPrefix(args[i], "--pin=")
+		} else if args[i] == "--api-url" && i+1 < len(args) {
+			apiURL = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--api-url=") {
+			apiURL = strings.TrimPrefix(args[i], "--api-url=")
+		} else {
+			rest = append(rest, args[i])
+		}
+	}
+
+	switch opts.Color {
+	case ColorAuto, ColorAlways, ColorNever:
+	default:
+		return opts, nil, fmt.Errorf("invalid --color value: %s (must be always, auto, or never)", opts.Color)
+	}
+
+	if !validOutput(opts.Output) {
+		return opts, nil, fmt.Errorf("invalid --output value: %s (must be table, json, yaml, csv, or hosts)", opts.Output)
+	}
+
+	if timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return opts, nil, fmt.Errorf("invalid --timeout value: %s (e.g. 10s, 1m)", timeout)
+		}
+		opts.Timeout = d
+	}
+
+	if retries != "" {
+		n, err := strconv.Atoi(retries)
+		if err != nil || n < 0 {
+			return opts, nil, fmt.Errorf("invalid --retries value: %s (must be a non-negative integer)", retries)
+		}
+		opts.Retries = n
+	}
+
+	if pin != "" {
+		p, err := api.ParsePin(pin)
+		if err != nil {
+			return opts, nil, fmt.Errorf("invalid --pin value: %s (must be a hex SHA-256 hash)", pin)
+		}
+		opts.Pin = p
+	}
+
+	if apiURL != "" {
+		u, err := parseAPIURL(apiURL)
+		if err != nil {
+			return opts, nil, fmt.Errorf("invalid --api-url value: %s (%w)", apiURL, err)
+		}
+		opts.APIURL = u
+	}
+
+	return opts, rest, nil
+}
+
+// parseAPIURL validates an API base URL override and drops any trailing
+// slash. The token is sent with every request, so plain http is only
+// allowed to this machine, e.g. for a local debugging proxy.
+func parseAPIURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("not an http or https URL: %s", s)
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if host := u.Hostname(); host != "localhost" && host != "127.0.0.1" && host != "::1" {
+			return "", fmt.Errorf("must use https unless the host is localhost: %s", s)
+		}
+	default:
+		return "", fmt.Errorf("not an http or https URL: %s", s)
+	}
+	return strings.TrimSuffix(s, "/"), nil
+}
+
+// splitFields parses a comma-separated field list, dropping empty entries
+func splitFields(s string) []string {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// clientOptions builds API client options from the config's client
+// settings and EERO_API_URL, with command-line flags taking precedence
+func clientOptions(settings config.ClientSettings, opts Options) (api.ClientOptions, error) {
+	co := api.ClientOptions{
+		Timeout:       time.Duration(settings.Timeout),
+		Retries:       settings.Retries,
+		RetryDelay:    time.Duration(settings.RetryDelay),
+		RateLimitWait: time.Duration(settings.RateLimitWait),
+	}
+	if settings.PinSHA256 != "" {
+		pin, err := api.ParsePin(settings.PinSHA256)
+		if err != nil {
+			return co, fmt.Errorf("config client.pin_sha256: %w", err)
+		}
+		co.PinSHA256 = pin
+	}
+	if opts.Timeout > 0 {
+		co.Timeout = opts.Timeout
+	}
+	if opts.Retries > 0 {
+		co.Retries = opts.Retries
+	} else if opts.Retries == 0 {
+		co.Retries = api.NoRetries
+	}
+	if opts.Pin != "" {
+		co.PinSHA256 = opts.Pin
+	}
+	if env := os.Getenv(config.EnvAPIURL); env != "" {
+		u, err := parseAPIURL(env)
+		if err != nil {
+			return co, fmt.Errorf("%s: %w", config.EnvAPIURL, err)
+		}
+		co.BaseURL = u
+	}
+	if opts.APIURL != "" {
+		co.BaseURL = opts.APIURL
+	}
+	return co, nil
+}
+
+// resolveColor decides whether colored output should be used for the given
+// mode. In auto mode, setting NO_COLOR (see no-color.org) disables color.
+func resolveColor(mode string) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+	}
+}
+
+// isTerminal reports whether f refers to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
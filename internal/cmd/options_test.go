@@ -0,0 +1,556 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+func TestParseGlobalFlagsDefaults(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"devices", "--online"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Color != ColorAuto {
+		t.Errorf("Color = %q, want %q", opts.Color, ColorAuto)
+	}
+	if !reflect.DeepEqual(rest, []string{"devices", "--online"}) {
+		t.Errorf("rest = %v", rest)
+	}
+}
+
+func TestParseGlobalFlagsColor(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--color", "always", "devices"}, ColorAlways},
+		{[]string{"devices", "--color=never"}, ColorNever},
+		{[]string{"devices", "monitor", "--color", "auto"}, ColorAuto},
+	}
+
+	for _, tt := range tests {
+		opts, rest, err := ParseGlobalFlags(tt.args)
+		if err != nil {
+			t.Fatalf("ParseGlobalFlags(%v): %v", tt.args, err)
+		}
+		if opts.Color != tt.want {
+			t.Errorf("ParseGlobalFlags(%v).Color = %q, want %q", tt.args, opts.Color, tt.want)
+		}
+		for _, a := range rest {
+			if strings.HasPrefix(a, "--color") || a == tt.want {
+				t.Errorf("ParseGlobalFlags(%v) left %q in remaining args", tt.args, a)
+			}
+		}
+	}
+}
+
+func TestParseGlobalFlagsInvalidColor(t *testing.T) {
+	_, _, err := ParseGlobalFlags([]string{"--color", "sometimes"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --color") {
+		t.Errorf("expected invalid --color error, got: %v", err)
+	}
+}
+
+func TestResolveColor(t *testing.T) {
+	if !resolveColor(ColorAlways) {
+		t.Error("resolveColor(always) = false, want true")
+	}
+	if resolveColor(ColorNever) {
+		t.Error("resolveColor(never) = true, want false")
+	}
+}
+
+func TestParseGlobalFlagsNoColor(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"devices", "--no-color"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Color != ColorNever || !reflect.DeepEqual(rest, []string{"devices"}) {
+		t.Errorf("Color = %q, rest = %v; want never and [devices]", opts.Color, rest)
+	}
+}
+
+func TestResolveColorNoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if resolveColor(ColorAuto) {
+		t.Error("resolveColor(auto) = true with NO_COLOR set")
+	}
+	if !resolveColor(ColorAlways) {
+		t.Error("--color always should win over NO_COLOR")
+	}
+}
+
+func TestBoldRespectsColor(t *testing.T) {
+	app := &App{Color: false}
+	if got := app.bold("x"); got != "x" {
+		t.Errorf("bold with color disabled = %q, want %q", got, "x")
+	}
+
+	app.Color = true
+	if got := app.bold("x"); got != boldStart+"x"+boldEnd {
+		t.Errorf("bold with color enabled = %q", got)
+	}
+	if got := app.boldIf("x", false); got != "x" {
+		t.Errorf("boldIf(false) = %q, want %q", got, "x")
+	}
+}
+
+func TestParseGlobalFlagsEnvFile(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"--env-file", "dev.env", "status"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.EnvFile != "dev.env" {
+		t.Errorf("EnvFile = %q, want %q", opts.EnvFile, "dev.env")
+	}
+	if !reflect.DeepEqual(rest, []string{"status"}) {
+		t.Errorf("rest = %v, want [status]", rest)
+	}
+}
+
+func TestParseGlobalFlagsOutput(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"eeros", "-o", "json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Output != OutputJSON {
+		t.Errorf("Output = %q, want %q", opts.Output, OutputJSON)
+	}
+	if !reflect.DeepEqual(rest, []string{"eeros"}) {
+		t.Errorf("rest = %v, want [eeros]", rest)
+	}
+
+	_, _, err = ParseGlobalFlags([]string{"--output=xml"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --output") {
+		t.Errorf("expected invalid --output error, got: %v", err)
+	}
+}
+
+func TestParseGlobalFlagsJSONShorthand(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"reservations", "--json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Output != OutputJSON {
+		t.Errorf("Output = %q, want %q", opts.Output, OutputJSON)
+	}
+	if !reflect.DeepEqual(rest, []string{"reservations"}) {
+		t.Errorf("rest = %v, want [reservations]", rest)
+	}
+}
+
+func TestParseGlobalFlagsCompact(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"devices", "--json", "--compact"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.Compact {
+		t.Error("Compact = false, want true")
+	}
+	if !reflect.DeepEqual(rest, []string{"devices"}) {
+		t.Errorf("rest = %v, want [devices]", rest)
+	}
+
+	// The last of --compact and --pretty wins
+	opts, _, _ = ParseGlobalFlags([]string{"--compact", "devices", "--pretty"})
+	if opts.Compact {
+		t.Error("Compact = true after --pretty, want false")
+	}
+}
+
+func TestParseGlobalFlagsEnvelope(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"devices", "-o", "json", "--envelope"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.Envelope {
+		t.Error("Envelope = false, want true")
+	}
+	if !reflect.DeepEqual(rest, []string{"devices"}) {
+		t.Errorf("rest = %v, want [devices]", rest)
+	}
+}
+
+func TestParseGlobalFlagsTimeoutRetries(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"--timeout", "10s", "devices", "--retries=2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Timeout != 10*time.Second || opts.Retries != 2 {
+		t.Errorf("Timeout = %v, Retries = %d, want 10s and 2", opts.Timeout, opts.Retries)
+	}
+	if !reflect.DeepEqual(rest, []string{"devices"}) {
+		t.Errorf("rest = %v, want [devices]", rest)
+	}
+
+	opts, _, _ = ParseGlobalFlags([]string{"devices"})
+	if opts.Retries != -1 {
+		t.Errorf("Retries = %d, want -1 when unset", opts.Retries)
+	}
+
+	for _, args := range [][]string{{"--timeout", "soon"}, {"--timeout=-1s"}, {"--retries", "x"}, {"--retries=-2"}} {
+		if _, _, err := ParseGlobalFlags(args); err == nil {
+			t.Errorf("ParseGlobalFlags(%v) expected error", args)
+		}
+	}
+}
+
+func TestClientOptionsFlagsOverrideConfig(t *testing.T) {
+	settings := config.ClientSettings{
+		Timeout:    config.Duration(time.Minute),
+		Retries:    5,
+		RetryDelay: config.Duration(time.Second),
+	}
+
+	co, err := clientOptions(settings, Options{Retries: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if co.Timeout != time.Minute || co.Retries != 5 || co.RetryDelay != time.Second {
+		t.Errorf("config values not applied: %+v", co)
+	}
+
+	co, _ = clientOptions(settings, Options{Timeout: 5 * time.Second, Retries: 0})
+	if co.Timeout != 5*time.Second || co.Retries != api.NoRetries {
+		t.Errorf("flags should override config: %+v", co)
+	}
+}
+
+func TestNewAppUsesConfigClientSettings(t *testing.T) {
+	dir := useTempConfigDir(t)
+	t.Chdir(t.TempDir()) // keep a stray .eero.env out of the test
+
+	path := filepath.Join(dir, "config.json")
+	os.MkdirAll(filepath.Dir(path), 0700)
+	data := `{"token": "tok", "client": {"timeout": "12s", "retries": 2, "rate_limit_wait": "5s"}}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	app, err := NewApp(Options{Color: ColorNever, Retries: -1})
+	if err != nil {
+		t.Fatalf("NewApp error: %v", err)
+	}
+
+	client, ok := app.Client.(*api.Client)
+	if !ok {
+		t.Fatalf("Client is %T, want *api.Client", app.Client)
+	}
+	opts := client.Options()
+	if opts.Timeout != 12*time.Second || opts.Retries != 2 || opts.RateLimitWait != 5*time.Second {
+		t.Errorf("client options = %+v, want config values", opts)
+	}
+	if opts.RetryDelay != api.DefaultRetryDelay {
+		t.Errorf("RetryDelay = %v, want built-in default", opts.RetryDelay)
+	}
+}
+
+func TestNewAppUsesEnvToken(t *testing.T) {
+	useTempConfigDir(t)
+	t.Chdir(t.TempDir()) // keep a stray .eero.env out of the test
+	t.Setenv("EERO_TOKEN", "env-token")
+	t.Setenv("EERO_NETWORK_ID", "67890")
+
+	app, err := NewApp(Options{Color: ColorNever, Retries: -1})
+	if err != nil {
+		t.Fatalf("NewApp error: %v", err)
+	}
+	if app.Config.Token != "env-token" || app.Config.NetworkID != "67890" {
+		t.Errorf("config = %+v, want values from the environment", app.Config)
+	}
+	if !app.Config.HasToken() {
+		t.Error("HasToken() = false with EERO_TOKEN set and no config file")
+	}
+}
+
+func TestNewAppNetworkFlagIsNotSaved(t *testing.T) {
+	dir := useTempConfigDir(t)
+	t.Chdir(t.TempDir()) // keep a stray .eero.env out of the test
+	t.Setenv("EERO_NETWORK_ID", "11111")
+
+	opts, rest, err := ParseGlobalFlags([]string{"--network", "67890", "devices"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Network != "67890" || !reflect.DeepEqual(rest, []string{"devices"}) {
+		t.Fatalf("Network = %q, rest = %v", opts.Network, rest)
+	}
+
+	path := filepath.Join(dir, "config.json")
+	os.MkdirAll(filepath.Dir(path), 0700)
+	if err := os.WriteFile(path, []byte(`{"token": "tok", "network_id": "12345"}`), 0600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	opts.Color, opts.Retries = ColorNever, -1
+	app, err := NewApp(opts)
+	if err != nil {
+		t.Fatalf("NewApp error: %v", err)
+	}
+	if app.Config.NetworkID != "67890" {
+		t.Errorf("NetworkID = %q, want the --network value over config and env", app.Config.NetworkID)
+	}
+
+	// A config update during the run must not persist the override
+	if err := app.updateConfig(func(c *config.Config) {}); err != nil {
+		t.Fatalf("updateConfig error: %v", err)
+	}
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if saved.NetworkID != "12345" {
+		t.Errorf("saved NetworkID = %q, want 12345", saved.NetworkID)
+	}
+}
+
+func TestNewAppReadOnly(t *testing.T) {
+	dir := useTempConfigDir(t)
+	t.Chdir(t.TempDir()) // keep a stray .eero.env out of the test
+	t.Cleanup(func() { config.SetReadOnly(false) })
+
+	opts, _, err := ParseGlobalFlags([]string{"--read-only", "devices"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.ReadOnly {
+		t.Fatal("ReadOnly = false after --read-only")
+	}
+
+	opts.Color, opts.Retries = ColorNever, -1
+	app, err := NewApp(opts)
+	if err != nil {
+		t.Fatalf("NewApp error: %v", err)
+	}
+	if !config.ReadOnly() || app.DiskCache || app.TrackIPs {
+		t.Errorf("ReadOnly = %v, DiskCache = %v, TrackIPs = %v; want on, off, off",
+			config.ReadOnly(), app.DiskCache, app.TrackIPs)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("config directory written in read-only mode: %v", err)
+	}
+}
+
+func TestNewAppKeyring(t *testing.T) {
+	useTempConfigDir(t)
+	t.Chdir(t.TempDir()) // keep a stray .eero.env out of the test
+
+	opts, rest, err := ParseGlobalFlags([]string{"login", "--keyring"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.Keyring || len(rest) != 1 || rest[0] != "login" {
+		t.Fatalf("Keyring = %v, rest = %v", opts.Keyring, rest)
+	}
+
+	opts.Color, opts.Retries = ColorNever, -1
+	app, err := NewApp(opts)
+	if err != nil {
+		t.Fatalf("NewApp error: %v", err)
+	}
+	if !app.Config.UseKeyring {
+		t.Error("UseKeyring = false after --keyring")
+	}
+}
+
+func TestParseGlobalFlagsOnlyFields(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"devices", "--only-fields", "mac, ip,,nickname"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(opts.OnlyFields, []string{"mac", "ip", "nickname"}) {
+		t.Errorf("OnlyFields = %v", opts.OnlyFields)
+	}
+	if !reflect.DeepEqual(rest, []string{"devices"}) {
+		t.Errorf("rest = %v, want [devices]", rest)
+	}
+}
+
+func TestParseGlobalFlagsStaleOK(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"--stale-ok", "eeros"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.StaleOK {
+		t.Error("StaleOK = false, want true")
+	}
+	if !reflect.DeepEqual(rest, []string{"eeros"}) {
+		t.Errorf("rest = %v, want [eeros]", rest)
+	}
+}
+
+func TestParseGlobalFlagsDebug(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"devices", "--debug"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.Debug {
+		t.Error("Debug = false, want true")
+	}
+	if !reflect.DeepEqual(rest, []string{"devices"}) {
+		t.Errorf("rest = %v, want [devices]", rest)
+	}
+}
+
+func TestParseGlobalFlagsStats(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"--stats", "profiles"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.Stats {
+		t.Error("Stats = false, want true")
+	}
+	if !reflect.DeepEqual(rest, []string{"profiles"}) {
+		t.Errorf("rest = %v, want [profiles]", rest)
+	}
+}
+
+func TestParseGlobalFlagsQuiet(t *testing.T) {
+	for _, flag := range []string{"--quiet", "-q"} {
+		opts, rest, err := ParseGlobalFlags([]string{flag, "devices"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !opts.Quiet {
+			t.Errorf("%s: Quiet = false, want true", flag)
+		}
+		if !reflect.DeepEqual(rest, []string{"devices"}) {
+			t.Errorf("%s: rest = %v, want [devices]", flag, rest)
+		}
+	}
+}
+
+func TestParseGlobalFlagsPin(t *testing.T) {
+	pin := strings.Repeat("AB", 32)
+	opts, rest, err := ParseGlobalFlags([]string{"--pin", pin, "status"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Pin != strings.ToLower(pin) {
+		t.Errorf("Pin = %q, want normalized lowercase hash", opts.Pin)
+	}
+	if !reflect.DeepEqual(rest, []string{"status"}) {
+		t.Errorf("rest = %v, want [status]", rest)
+	}
+
+	if _, _, err := ParseGlobalFlags([]string{"--pin=abc"}); err == nil || !strings.Contains(err.Error(), "invalid --pin") {
+		t.Errorf("expected invalid --pin error, got: %v", err)
+	}
+}
+
+func TestClientOptionsPin(t *testing.T) {
+	configPin := strings.Repeat("ab:", 31) + "ab"
+	co, err := clientOptions(config.ClientSettings{PinSHA256: configPin}, Options{Retries: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if co.PinSHA256 != strings.Repeat("ab", 32) {
+		t.Errorf("PinSHA256 = %q, want config pin without colons", co.PinSHA256)
+	}
+
+	flagPin := strings.Repeat("cd", 32)
+	co, _ = clientOptions(config.ClientSettings{PinSHA256: configPin}, Options{Retries: -1, Pin: flagPin})
+	if co.PinSHA256 != flagPin {
+		t.Errorf("PinSHA256 = %q, want --pin to override config", co.PinSHA256)
+	}
+
+	if _, err := clientOptions(config.ClientSettings{PinSHA256: "nope"}, Options{Retries: -1}); err == nil {
+		t.Error("expected error for invalid config pin")
+	}
+}
+
+func TestParseGlobalFlagsAPIURL(t *testing.T) {
+	opts, rest, err := ParseGlobalFlags([]string{"--api-url", "http://localhost:8080/", "status"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.APIURL != "http://localhost:8080" {
+		t.Errorf("APIURL = %q, want trailing slash dropped", opts.APIURL)
+	}
+	if !reflect.DeepEqual(rest, []string{"status"}) {
+		t.Errorf("rest = %v, want [status]", rest)
+	}
+
+	for _, bad := range []string{"localhost:8080", "ftp://proxy", "http://", "http://proxy.example:8080"} {
+		if _, _, err := ParseGlobalFlags([]string{"--api-url=" + bad}); err == nil || !strings.Contains(err.Error(), "invalid --api-url") {
+			t.Errorf("%s: expected invalid --api-url error, got: %v", bad, err)
+		}
+	}
+}
+
+func TestClientOptionsAPIURL(t *testing.T) {
+	t.Setenv(config.EnvAPIURL, "https://proxy.example:8443")
+
+	co, err := clientOptions(config.ClientSettings{}, Options{Retries: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if co.BaseURL != "https://proxy.example:8443" {
+		t.Errorf("BaseURL = %q, want %s", co.BaseURL, config.EnvAPIURL)
+	}
+
+	co, _ = clientOptions(config.ClientSettings{}, Options{Retries: -1, APIURL: "http://localhost:8080"})
+	if co.BaseURL != "http://localhost:8080" {
+		t.Errorf("BaseURL = %q, want --api-url to override the environment", co.BaseURL)
+	}
+
+	t.Setenv(config.EnvAPIURL, "not a url")
+	if _, err := clientOptions(config.ClientSettings{}, Options{Retries: -1}); err == nil || !strings.Contains(err.Error(), config.EnvAPIURL) {
+		t.Errorf("expected %s error, got: %v", config.EnvAPIURL, err)
+	}
+}
+
+func TestParseAPIURLAllowsLocalHTTP(t *testing.T) {
+	for _, ok := range []string{"https://proxy.example", "http://localhost:8080", "http://127.0.0.1:9000/", "http://[::1]:8080"} {
+		if _, err := parseAPIURL(ok); err != nil {
+			t.Errorf("parseAPIURL(%q) error: %v", ok, err)
+		}
+	}
+}
+
+func TestNewAppWarnsAboutAPIURL(t *testing.T) {
+	useTempConfigDir(t)
+	t.Chdir(t.TempDir())
+
+	stderr := captureStderr(t, func() {
+		if _, err := NewApp(Options{Color: ColorNever, Retries: -1, APIURL: "http://localhost:8080"}); err != nil {
+			t.Fatalf("NewApp error: %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "Warning: sending API requests, including the session token, to http://localhost:8080") {
+		t.Errorf("stderr = %q, want a warning naming the URL", stderr)
+	}
+}
+
+func TestNewAppIgnoresAPIURLFromDefaultEnvFile(t *testing.T) {
+	useTempConfigDir(t)
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv(config.EnvAPIURL, "")
+	os.Unsetenv(config.EnvAPIURL)
+	if err := os.WriteFile(filepath.Join(dir, config.DefaultEnvFile), []byte("EERO_API_URL=https://evil.example\n"), 0600); err != nil {
+		t.Fatalf("writing env file: %v", err)
+	}
+
+	var app *App
+	stderr := captureStderr(t, func() {
+		var err error
+		if app, err = NewApp(Options{Color: ColorNever, Retries: -1}); err != nil {
+			t.Fatalf("NewApp error: %v", err)
+		}
+	})
+	if got := app.Client.(*api.Client).Options().BaseURL; got != "" {
+		t.Errorf("BaseURL = %q, want the default", got)
+	}
+	if !strings.Contains(stderr, "ignoring EERO_API_URL in .eero.env") {
+		t.Errorf("stderr = %q, want a warning about the ignored variable", stderr)
+	}
+}
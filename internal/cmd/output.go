@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputCSV   = "csv"
+	OutputHosts = "hosts"
+)
+
+// validOutput reports whether format is a supported --output value
+func validOutput(format string) bool {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputHosts:
+		return true
+	}
+	return false
+}
+
+// outputFormat returns the active output format, defaulting to a table
+func (a *App) outputFormat() string {
+	if a.Output == "" {
+		return OutputTable
+	}
+	return a.Output
+}
+
+// structuredOutput reports whether the active format is JSON or YAML
+func (a *App) structuredOutput() bool {
+	format := a.outputFormat()
+	return format == OutputJSON || format == OutputYAML
+}
+
+// EnvelopeMeta describes the context of an enveloped JSON response
+type EnvelopeMeta struct {
+	NetworkID   string `json:"network_id"`
+	GeneratedAt string `json:"generated_at"`
+	Command     string `json:"command"`
+}
+
+// Envelope wraps JSON output with metadata when --envelope is set
+type Envelope struct {
+	Meta EnvelopeMeta `json:"meta"`
+	Data interface{}  `json:"data"`
+}
+
+// printData writes v to stdout as indented JSON, or as YAML with --output
+// yaml, projected to --only-fields and wrapped in an Envelope when
+// --envelope is set
+func (a *App) printData(v interface{}) error {
+	if len(a.OnlyFields) > 0 {
+		projected, err := projectFields(v, a.OnlyFields)
+		if err != nil {
+			return err
+		}
+		v = projected
+	}
+
+	if a.Envelope {
+		v = Envelope{
+			Meta: EnvelopeMeta{
+				NetworkID:   a.Config.NetworkID,
+				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+				Command:     a.Command,
+			},
+			Data: v,
+		}
+	}
+
+	if a.outputFormat() == OutputYAML {
+		data, err := marshalYAML(v)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if a.Compact {
+		data, err = json.Marshal(v)
+	} else {
+		data, err = json.MarshalIndent(v, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("formatting JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// printRawJSON writes an API response body to stdout, indented unless
+// --compact is set
+func (a *App) printRawJSON(raw []byte) error {
+	var buf bytes.Buffer
+	var err error
+	if a.Compact {
+		err = json.Compact(&buf, raw)
+	} else {
+		err = json.Indent(&buf, raw, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("formatting JSON: %w", err)
+	}
+	fmt.Println(buf.String())
+	return nil
+}
+
+// marshalYAML renders v as YAML with the same keys, order, and omitted
+// fields as its JSON form. JSON is valid YAML, so v is encoded as JSON and
+// decoded into a yaml.Node, then restyled as block YAML.
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("formatting YAML: %w", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("formatting YAML: %w", err)
+	}
+	clearStyle(&node)
+
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return nil, fmt.Errorf("formatting YAML: %w", err)
+	}
+	return out, nil
+}
+
+// clearStyle resets the flow and quoting styles carried over from JSON so
+// the encoder picks plain block style, quoting only where needed
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+// projectFields reduces a struct, or a slice of structs, to the given JSON
+// fields. Field names are validated against the struct's json tags.
+func projectFields(v interface{}, fields []string) (interface{}, error) {
+	t := reflect.TypeOf(v)
+	isSlice := t != nil && t.Kind() == reflect.Slice
+	if isSlice {
+		t = t.Elem()
+	}
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("--only-fields is not supported for this output")
+	}
+
+	available := jsonFieldNames(t)
+	for _, f := range fields {
+		if !available[f] {
+			names := make([]string, 0, len(available))
+			for name := range available {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown field: %s (available: %s)", f, strings.Join(names, ", "))
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("formatting JSON: %w", err)
+	}
+
+	if !isSlice {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("formatting JSON: %w", err)
+		}
+		return selectKeys(m, fields), nil
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("formatting JSON: %w", err)
+	}
+	projected := make([]projection, len(items))
+	for i, m := range items {
+		projected[i] = selectKeys(m, fields)
+	}
+	return projected, nil
+}
+
+// jsonFieldNames returns the JSON names of a struct type's exported fields
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// projectedField is one key and its JSON value in a projection
+type projectedField struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// projection is a JSON object whose keys are written in the order given to
+// --only-fields, rather than a map's, so projected output is stable
+type projection []projectedField
+
+// MarshalJSON writes the fields as a JSON object in order
+func (p projection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(f.Value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// selectKeys returns the given keys of m, in order, skipping absent ones
+func selectKeys(m map[string]json.RawMessage, keys []string) projection {
+	out := make(projection, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			out = append(out, projectedField{Key: k, Value: v})
+		}
+	}
+	return out
+}
+
+// sortedByMAC returns a copy of devices ordered by MAC address, so JSON and
+// YAML output doesn't depend on the order the API returned them in
+func sortedByMAC(devices []api.Device) []api.Device {
+	sorted := slices.Clone(devices)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return normalizeMAC(sorted[i].MAC) < normalizeMAC(sorted[j].MAC)
+	})
+	return sorted
+}
+
+// sortedBySerial returns a copy of eeros ordered by serial number, for the
+// same reason as sortedByMAC
+func sortedBySerial(eeros []api.Eero) []api.Eero {
+	sorted := slices.Clone(eeros)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Serial < sorted[j].Serial
+	})
+	return sorted
+}
+
+// printCSV writes headers and rows to stdout as CSV
+func printCSV(headers []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(headers); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
+}
+
+// printHosts writes devices as /etc/hosts lines ("<ip>  <hostname>"),
+// skipping devices without an IPv4 address or a usable name
+func printHosts(devices []api.Device) {
+	for _, d := range devices {
+		name := hostsName(d.DisplayName())
+		if d.IP == "" || name == "" {
+			continue
+		}
+		fmt.Printf("%s  %s\n", d.IP, name)
+	}
+}
+
+// hostsName turns a device name into a valid hostname label: lowercase,
+// spaces and underscores become dashes, other invalid characters are
+// dropped, and the result is at most 63 characters
+func hostsName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-' || r == ' ' || r == '_' || r == '.':
+			// Collapse runs of separators into a single dash
+			if s := b.String(); s != "" && !strings.HasSuffix(s, "-") {
+				b.WriteByte('-')
+			}
+		}
+	}
+
+	label := b.String()
+	if len(label) > 63 {
+		label = label[:63]
+	}
+	return strings.TrimRight(label, "-")
+}
+
+// maskSecret hides the middle of a password, keeping the first five and
+// last two characters (e.g. "guestpass123" becomes "guest•••••23"). Short
+// secrets are masked entirely.
+func maskSecret(secret string) string {
+	r := []rune(secret)
+	if len(r) <= 8 {
+		return strings.Repeat("•", len(r))
+	}
+	return string(r[:5]) + strings.Repeat("•", len(r)-7) + string(r[len(r)-2:])
+}
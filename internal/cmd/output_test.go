@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		secret string
+		want   string
+	}{
+		{"guestpass123", "guest•••••23"},
+		{"correcthorse", "corre•••••se"},
+		{"abcdefghi", "abcde••hi"},
+		{"short", "•••••"},
+		{"12345678", "••••••••"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := maskSecret(tt.secret); got != tt.want {
+			t.Errorf("maskSecret(%q) = %q, want %q", tt.secret, got, tt.want)
+		}
+	}
+}
+
+func TestProjectFieldsSlice(t *testing.T) {
+	projected, err := projectFields(testDevices(), []string{"mac", "ip", "nickname"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, ok := projected.([]projection)
+	if !ok || len(items) != 3 {
+		t.Fatalf("projected = %#v, want 3 projections", projected)
+	}
+	for _, p := range items {
+		if len(p) != 3 || p[0].Key != "mac" || p[1].Key != "ip" || p[2].Key != "nickname" {
+			t.Errorf("keys = %v, want mac, ip, nickname in order", p)
+		}
+	}
+	data, err := json.Marshal(items[0])
+	if err != nil {
+		t.Fatalf("marshaling projection: %v", err)
+	}
+	want := `{"mac":"AA:BB:CC:DD:11:22","ip":"192.168.1.100","nickname":"My Laptop"}`
+	if string(data) != want {
+		t.Errorf("items[0] = %s, want %s", data, want)
+	}
+}
+
+func TestProjectFieldsUnknown(t *testing.T) {
+	_, err := projectFields(testDevices(), []string{"mac", "password"})
+	if err == nil || !strings.Contains(err.Error(), "unknown field: password") {
+		t.Errorf("expected unknown field error, got: %v", err)
+	}
+
+	if _, err := projectFields(map[string]int{"a": 1}, []string{"a"}); err == nil {
+		t.Error("expected error for non-struct output")
+	}
+}
+
+func TestListDevicesOnlyFields(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+	app.OnlyFields = []string{"mac", "ip", "nickname"}
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(items) != 3 {
+		t.Fatalf("len(items) = %d, want 3", len(items))
+	}
+	for _, m := range items {
+		for key := range m {
+			if key != "mac" && key != "ip" && key != "nickname" {
+				t.Errorf("unexpected key %q in %v", key, m)
+			}
+		}
+	}
+}
+
+func TestListDevicesYAML(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputYAML
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("ListDevices error: %v", err)
+		}
+	})
+
+	if !strings.HasPrefix(out, "- url: /2.2/networks/12345/devices/112233445566\n") {
+		t.Errorf("expected block-style YAML list, got:\n%s", out)
+	}
+
+	var items []map[string]interface{}
+	if err := yaml.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, out)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d devices, want 3", len(items))
+	}
+	for _, key := range []string{"mac", "ip", "is_private", "connection_type", "last_active"} {
+		if _, ok := items[0][key]; !ok {
+			t.Errorf("YAML device missing JSON-named key %q: %v", key, items[0])
+		}
+	}
+	// Devices are sorted by MAC, so the laptop comes second
+	if items[1]["mac"] != "AA:BB:CC:DD:11:22" || items[1]["connected"] != true {
+		t.Errorf("unexpected values: %v", items[1])
+	}
+	profile, _ := items[1]["profile"].(map[string]interface{})
+	if profile["name"] != "Adults" {
+		t.Errorf("profile = %v, want nested name Adults", items[1]["profile"])
+	}
+}
+
+func TestListCommandsJSON(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return testReservations(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("ListDevices error: %v", err)
+		}
+	})
+	var devices []api.Device
+	if err := json.Unmarshal([]byte(out), &devices); err != nil {
+		t.Fatalf("devices output is not a JSON array: %v\n%s", err, out)
+	}
+	if len(devices) != 3 || devices[0].MAC != "11:22:33:44:55:66" || devices[1].MAC != "AA:BB:CC:DD:11:22" {
+		t.Errorf("devices = %+v", devices)
+	}
+	if strings.Contains(out, "Total:") {
+		t.Error("JSON output should not include the table footer")
+	}
+
+	out = captureStdout(t, func() {
+		if err := app.ListProfiles(); err != nil {
+			t.Fatalf("ListProfiles error: %v", err)
+		}
+	})
+	var profiles []api.Profile
+	if err := json.Unmarshal([]byte(out), &profiles); err != nil || len(profiles) != 2 {
+		t.Errorf("profiles output = %s (err %v)", out, err)
+	}
+
+	out = captureStdout(t, func() {
+		if err := app.ListReservations(false); err != nil {
+			t.Fatalf("ListReservations error: %v", err)
+		}
+	})
+	var reservations []api.Reservation
+	if err := json.Unmarshal([]byte(out), &reservations); err != nil || len(reservations) != 2 {
+		t.Errorf("reservations output = %s (err %v)", out, err)
+	}
+}
+
+func TestListCommandsJSONEmpty(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return nil, nil
+		},
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return nil, nil
+		},
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return nil, nil
+		},
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return nil, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	for name, fn := range map[string]func() error{
+		"devices":      func() error { return app.ListDevices(DeviceFilters{}) },
+		"profiles":     app.ListProfiles,
+		"reservations": func() error { return app.ListReservations(false) },
+		"eeros":        func() error { return app.ListEeros(false, sortSpec{}) },
+	} {
+		out := captureStdout(t, func() {
+			if err := fn(); err != nil {
+				t.Fatalf("%s error: %v", name, err)
+			}
+		})
+		if strings.TrimSpace(out) != "[]" {
+			t.Errorf("%s with no results printed %q, want []", name, out)
+		}
+	}
+}
+
+func TestHostsName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"My Laptop", "my-laptop"},
+		{"Dad's iPhone", "dads-iphone"},
+		{"living_room  TV", "living-room-tv"},
+		{"  --NAS--  ", "nas"},
+		{"host.local", "host-local"},
+		{"Café ☕", "caf"},
+		{"☕", ""},
+		{strings.Repeat("a", 70), strings.Repeat("a", 63)},
+	}
+
+	for _, tt := range tests {
+		if got := hostsName(tt.name); got != tt.want {
+			t.Errorf("hostsName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestListDevicesHosts(t *testing.T) {
+	devices := testDevices()
+	devices = append(devices, api.Device{
+		URL:      "/2.2/networks/12345/devices/ffffffffffff",
+		MAC:      "FF:FF:FF:FF:FF:FF",
+		Nickname: "No Address",
+	})
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputHosts
+
+	out := captureStdout(t, func() {
+		if err := app.ListDevices(DeviceFilters{}); err != nil {
+			t.Fatalf("ListDevices error: %v", err)
+		}
+	})
+
+	want := "192.168.1.100  my-laptop\n192.168.1.10  nas\n192.168.1.101  phone\n"
+	if out != want {
+		t.Errorf("hosts output = %q, want %q", out, want)
+	}
+}
+
+func TestPrintDataCompactAndPretty(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	app.Output = OutputJSON
+	v := []api.Profile{{URL: "/2.2/networks/12345/profiles/prof1", Name: "Adults"}}
+
+	pretty := captureStdout(t, func() {
+		if err := app.printData(v); err != nil {
+			t.Fatalf("printData error: %v", err)
+		}
+	})
+	if !strings.Contains(pretty, "\n  {\n    \"url\"") {
+		t.Errorf("default output should be indented:\n%s", pretty)
+	}
+
+	app.Compact = true
+	compact := captureStdout(t, func() {
+		if err := app.printData(v); err != nil {
+			t.Fatalf("printData error: %v", err)
+		}
+	})
+	want := `[{"url":"/2.2/networks/12345/profiles/prof1","name":"Adults","paused":false}]` + "\n"
+	if compact != want {
+		t.Errorf("compact output = %q, want %q", compact, want)
+	}
+}
+
+func TestPrintRawJSONCompact(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	raw := []byte(`{"a": 1,
+  "b": [1, 2]}`)
+
+	out := captureStdout(t, func() {
+		if err := app.printRawJSON(raw); err != nil {
+			t.Fatalf("printRawJSON error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "\n  \"b\": [\n") {
+		t.Errorf("default output should be indented:\n%s", out)
+	}
+
+	app.Compact = true
+	out = captureStdout(t, func() {
+		if err := app.printRawJSON(raw); err != nil {
+			t.Fatalf("printRawJSON error: %v", err)
+		}
+	})
+	if out != `{"a":1,"b":[1,2]}`+"\n" {
+		t.Errorf("compact output = %q", out)
+	}
+}
+
+func TestListDevicesJSONIsStable(t *testing.T) {
+	for _, fields := range [][]string{nil, {"nickname", "mac", "ip"}} {
+		var outs []string
+		for run := range 2 {
+			mock := &mockClient{
+				GetDevicesFn: func(networkID string) ([]api.Device, error) {
+					devices := testDevices()
+					if run == 1 {
+						slices.Reverse(devices)
+					}
+					return devices, nil
+				},
+			}
+			app := newTestApp(mock)
+			app.Output = OutputJSON
+			app.OnlyFields = fields
+
+			outs = append(outs, captureStdout(t, func() {
+				if err := app.ListDevices(DeviceFilters{}); err != nil {
+					t.Fatalf("ListDevices error: %v", err)
+				}
+			}))
+		}
+		if outs[0] != outs[1] {
+			t.Errorf("output differs between runs with fields %v:\n%s\n---\n%s", fields, outs[0], outs[1])
+		}
+		if fields != nil && !strings.Contains(outs[0], "{\n    \"nickname\": \"NAS\",\n    \"mac\"") {
+			t.Errorf("projected keys should keep the --only-fields order:\n%s", outs[0])
+		}
+	}
+}
+
+func TestListEerosJSONIsStable(t *testing.T) {
+	var outs []string
+	for run := range 2 {
+		mock := &mockClient{
+			GetEerosFn: func(networkID string) ([]api.Eero, error) {
+				eeros := testEeros()
+				if run == 1 {
+					slices.Reverse(eeros)
+				}
+				return eeros, nil
+			},
+		}
+		app := newTestApp(mock)
+		app.Output = OutputJSON
+
+		outs = append(outs, captureStdout(t, func() {
+			if err := app.ListEeros(false, sortSpec{}); err != nil {
+				t.Fatalf("ListEeros error: %v", err)
+			}
+		}))
+	}
+	if outs[0] != outs[1] {
+		t.Errorf("output differs between runs:\n%s\n---\n%s", outs[0], outs[1])
+	}
+}
@@ -1,16 +1,43 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dorin/eero-cli/internal/api"
 )
 
 // Profiles handles the profiles command
 func (a *App) Profiles(args []string) error {
+	// --verify re-reads a profile after add or remove; --for unpauses a
+	// paused profile after a while
+	verify := false
+	var pauseFor time.Duration
+	var filteredArgs []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--verify" {
+			verify = true
+		} else if args[i] == "--for" && i+1 < len(args) {
+			d, err := parseFor(args[i+1])
+			if err != nil {
+				return err
+			}
+			pauseFor = d
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--for=") {
+			d, err := parseFor(strings.TrimPrefix(args[i], "--for="))
+			if err != nil {
+				return err
+			}
+			pauseFor = d
+		} else {
+			filteredArgs = append(filteredArgs, args[i])
+		}
+	}
+	args = filteredArgs
+
 	if len(args) == 0 {
 		return a.ListProfiles()
 	}
@@ -21,9 +48,25 @@ func (a *App) Profiles(args []string) error {
 			return fmt.Errorf("usage: profiles inspect <profile>")
 		}
 		return a.InspectProfile(args[1])
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: profiles show <profile>")
+		}
+		return a.ShowProfile(args[1])
+	case "devices":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: profiles devices <profile>")
+		}
+		return a.ProfileDevices(args[1])
 	case "pause":
 		if len(args) < 2 {
-			return fmt.Errorf("usage: profiles pause <profile-id>")
+			return fmt.Errorf("usage: profiles pause <profile-id> [--for <duration>]")
+		}
+		if pauseFor > 0 {
+			profile := args[1]
+			return a.pauseFor("profile "+profile, "profiles unpause "+profile, pauseFor, func(pause bool) error {
+				return a.PauseProfile(profile, pause)
+			})
 		}
 		return a.PauseProfile(args[1], true)
 	case "unpause":
@@ -31,16 +74,35 @@ func (a *App) Profiles(args []string) error {
 			return fmt.Errorf("usage: profiles unpause <profile-id>")
 		}
 		return a.PauseProfile(args[1], false)
+	case "pause-all":
+		return a.PauseAllProfiles(true)
+	case "resume-all":
+		return a.PauseAllProfiles(false)
+	case "filter":
+		if len(args) < 4 {
+			return fmt.Errorf("usage: profiles filter <profile> <%s> on|off", strings.Join(filterCategoryNames(), "|"))
+		}
+		on, err := parseOnOff(args[3])
+		if err != nil {
+			return err
+		}
+		return a.SetProfileFilter(args[1], args[2], on)
 	case "add":
 		if len(args) < 3 {
-			return fmt.Errorf("usage: profiles add <profile> <device>")
+			return fmt.Errorf("usage: profiles add <profile> <device> [--verify]")
 		}
-		return a.AddDeviceToProfile(args[1], args[2])
+		if err := a.AddDeviceToProfile(args[1], args[2]); err != nil || !verify {
+			return err
+		}
+		return a.VerifyProfileDevices(args[1], args[2], true)
 	case "remove":
 		if len(args) < 3 {
-			return fmt.Errorf("usage: profiles remove <profile> <device>")
+			return fmt.Errorf("usage: profiles remove <profile> <device> [--verify]")
+		}
+		if err := a.RemoveDeviceFromProfile(args[1], args[2]); err != nil || !verify {
+			return err
 		}
-		return a.RemoveDeviceFromProfile(args[1], args[2])
+		return a.VerifyProfileDevices(args[1], args[2], false)
 	default:
 		return fmt.Errorf("unknown profiles subcommand: %s", args[0])
 	}
@@ -53,11 +115,18 @@ func (a *App) ListProfiles() error {
 		return err
 	}
 
-	profiles, err := a.Client.GetProfiles(networkID)
+	profiles, err := a.profiles(networkID)
 	if err != nil {
 		return fmt.Errorf("getting profiles: %w", err)
 	}
 
+	if a.structuredOutput() {
+		if profiles == nil {
+			profiles = []api.Profile{}
+		}
+		return a.printData(profiles)
+	}
+
 	if len(profiles) == 0 {
 		fmt.Println("No profiles configured")
 		return nil
@@ -89,15 +158,17 @@ func (a *App) ListProfiles() error {
 
 // findProfileID finds a profile by partial ID or name
 func (a *App) findProfileID(networkID, query string) (string, error) {
-	profiles, err := a.Client.GetProfiles(networkID)
+	profiles, err := a.profiles(networkID)
 	if err != nil {
 		return "", fmt.Errorf("getting profiles: %w", err)
 	}
 
 	query = strings.ToLower(query)
 
+	var candidates []string
 	for _, p := range profiles {
 		profileID := api.ExtractProfileID(p.URL)
+		candidates = append(candidates, p.Name)
 
 		// Exact ID match
 		if profileID == query {
@@ -115,7 +186,7 @@ func (a *App) findProfileID(networkID, query string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("profile not found: %s", query)
+	return "", notFoundError("profile", query, candidates)
 }
 
 // PauseProfile pauses or unpauses a profile
@@ -143,6 +214,58 @@ func (a *App) PauseProfile(profileQuery string, pause bool) error {
 	return nil
 }
 
+// PauseAllProfiles pauses or unpauses every profile. A failure doesn't stop
+// the rest; it is reported, and counted in the returned error.
+func (a *App) PauseAllProfiles(pause bool) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	profiles, err := a.profiles(networkID)
+	if err != nil {
+		return fmt.Errorf("getting profiles: %w", err)
+	}
+	if len(profiles) == 0 {
+		fmt.Println("No profiles configured")
+		return nil
+	}
+
+	done, err := a.pauseEachProfile(networkID, profiles, pause)
+	action := "paused"
+	if !pause {
+		action = "unpaused"
+	}
+	fmt.Printf("%d of %d profiles %s\n", done, len(profiles), action)
+	return err
+}
+
+// pauseEachProfile pauses or unpauses each profile, printing the result of
+// each, and returns how many succeeded. A failure doesn't stop the rest; the
+// returned error counts them.
+func (a *App) pauseEachProfile(networkID string, profiles []api.Profile, pause bool) (int, error) {
+	verb, action := "pause", "paused"
+	if !pause {
+		verb, action = "unpause", "unpaused"
+	}
+
+	var failed int
+	for _, p := range profiles {
+		profileID := api.ExtractProfileID(p.URL)
+		if err := a.Client.PauseProfile(networkID, profileID, pause); err != nil {
+			fmt.Printf("Failed to %s profile %s (%s): %v\n", verb, p.Name, profileID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Profile %s (%s) has been %s\n", p.Name, profileID, action)
+	}
+
+	if failed > 0 {
+		return len(profiles) - failed, fmt.Errorf("failed to %s %d of %d profiles", verb, failed, len(profiles))
+	}
+	return len(profiles), nil
+}
+
 // InspectProfile prints the full profile state as JSON
 func (a *App) InspectProfile(profileQuery string) error {
 	networkID, err := a.EnsureNetwork()
@@ -160,13 +283,191 @@ func (a *App) InspectProfile(profileQuery string) error {
 		return fmt.Errorf("getting profile: %w", err)
 	}
 
-	var prettyJSON bytes.Buffer
-	if err := json.Indent(&prettyJSON, rawJSON, "", "  "); err != nil {
-		return fmt.Errorf("formatting JSON: %w", err)
+	return a.printRawJSON(rawJSON)
+}
+
+// ShowProfile prints a profile's configuration, including its schedules and
+// content filters, in human form
+func (a *App) ShowProfile(profileQuery string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	profileID, err := a.findProfileID(networkID, profileQuery)
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.Client.GetProfileDetails(networkID, profileID)
+	if err != nil {
+		return fmt.Errorf("getting profile: %w", err)
+	}
+
+	if a.structuredOutput() {
+		return a.printData(profile)
+	}
+
+	status := "active"
+	if profile.Paused {
+		status = "paused"
+	}
+	fmt.Printf("Profile: %s (%s)\n", profile.Name, profileID)
+	fmt.Printf("Status:  %s\n", status)
+	fmt.Printf("Devices: %d\n", len(profile.Devices))
+
+	fmt.Println("\nSchedule:")
+	if len(profile.Schedules) == 0 {
+		fmt.Println("  No schedules")
+	} else {
+		headers := []string{"NAME", "DAYS", "START", "END", "ENABLED"}
+		var rows [][]string
+		for _, s := range profile.Schedules {
+			enabled := "no"
+			if s.Enabled {
+				enabled = "yes"
+			}
+			rows = append(rows, []string{s.Name, strings.Join(s.Days, ","), s.Start, s.End, enabled})
+		}
+		PrintTable(headers, rows)
+	}
+
+	fmt.Println("\nFilters:")
+	if len(profile.Filters) == 0 {
+		fmt.Println("  No filters")
+		return nil
+	}
+	names := make([]string, 0, len(profile.Filters))
+	for name := range profile.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		mark := " "
+		if profile.Filters[name] {
+			mark = "x"
+		}
+		fmt.Printf("  [%s] %s\n", mark, name)
+	}
+	return nil
+}
+
+// filterCategories maps profiles filter categories to the API's filter names
+var filterCategories = map[string]string{
+	"adult":      "block_adult_content",
+	"illegal":    "block_illegal_content",
+	"violent":    "block_violent_content",
+	"safesearch": "safe_search",
+}
+
+// filterCategoryNames returns the accepted filter categories, sorted
+func filterCategoryNames() []string {
+	names := make([]string, 0, len(filterCategories))
+	for name := range filterCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetProfileFilter turns one of a profile's content filters on or off
+func (a *App) SetProfileFilter(profileQuery, category string, on bool) error {
+	filter, ok := filterCategories[strings.ToLower(category)]
+	if !ok {
+		return fmt.Errorf("unknown filter category: %s (valid: %s)", category, strings.Join(filterCategoryNames(), ", "))
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	profileID, err := a.findProfileID(networkID, profileQuery)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Client.SetProfileContentFilter(networkID, profileID, map[string]bool{filter: on}); err != nil {
+		return fmt.Errorf("updating profile filter: %w", err)
+	}
+
+	fmt.Printf("Filter %s turned %s for profile %s\n", strings.ToLower(category), onOff(on), profileQuery)
+	return nil
+}
+
+// ProfileDevices lists the devices in a profile with their current state
+func (a *App) ProfileDevices(profileQuery string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	profileID, err := a.findProfileID(networkID, profileQuery)
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.Client.GetProfileDetails(networkID, profileID)
+	if err != nil {
+		return fmt.Errorf("getting profile: %w", err)
+	}
+
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+
+	byID := make(map[string]api.Device, len(devices))
+	for _, d := range devices {
+		byID[api.ExtractDeviceID(d.URL)] = d
+	}
+
+	// Profiles can reference devices the network no longer reports
+	matched := []api.Device{}
+	missing := 0
+	for _, pd := range profile.Devices {
+		d, ok := byID[api.ExtractDeviceID(pd.URL)]
+		if !ok {
+			missing++
+			continue
+		}
+		matched = append(matched, d)
+	}
+
+	headers := []string{"ID", "NAME", "IP", "MAC", "STATUS", "TYPE"}
+	var rows [][]string
+	for _, d := range matched {
+		connType := "wired"
+		if d.Wireless {
+			connType = "wireless"
+		}
+		rows = append(rows, []string{
+			api.ExtractDeviceID(d.URL),
+			d.DisplayName(),
+			d.DisplayIP(),
+			d.MAC,
+			deviceStatus(d),
+			connType,
+		})
+	}
+
+	switch a.outputFormat() {
+	case OutputJSON, OutputYAML:
+		return a.printData(sortedByMAC(matched))
+	case OutputCSV:
+		return printCSV(headers, rows)
 	}
 
-	fmt.Println(prettyJSON.String())
+	if len(matched) == 0 {
+		fmt.Printf("No devices in profile %s\n", profile.Name)
+		return nil
+	}
 
+	PrintTable(headers, rows)
+	fmt.Printf("\n%d devices in profile %s\n", len(matched), profile.Name)
+	if missing > 0 {
+		fmt.Printf("(%d profile devices not found on the network)\n", missing)
+	}
 	return nil
 }
 
@@ -262,3 +563,58 @@ func (a *App) RemoveDeviceFromProfile(profileQuery, deviceQuery string) error {
 	fmt.Printf("Device %s has been removed from profile %s\n", deviceID, profile.Name)
 	return nil
 }
+
+// VerifyProfileDevices re-reads a profile after a membership edit and lists
+// its devices, failing if the device's membership doesn't match wantMember
+// (the API accepted the update but didn't apply it)
+func (a *App) VerifyProfileDevices(profileQuery, deviceQuery string, wantMember bool) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	profileID, err := a.findProfileID(networkID, profileQuery)
+	if err != nil {
+		return err
+	}
+	deviceID, err := a.findDeviceID(networkID, deviceQuery)
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.Client.GetProfileDetails(networkID, profileID)
+	if err != nil {
+		return fmt.Errorf("getting profile: %w", err)
+	}
+
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+	names := make(map[string]string, len(devices))
+	for _, d := range devices {
+		names[api.ExtractDeviceID(d.URL)] = d.DisplayName()
+	}
+
+	isMember := false
+	fmt.Printf("Profile %s now has %d devices:\n", profile.Name, len(profile.Devices))
+	for _, pd := range profile.Devices {
+		id := api.ExtractDeviceID(pd.URL)
+		if id == deviceID {
+			isMember = true
+		}
+		name := names[id]
+		if name == "" {
+			name = id
+		}
+		fmt.Printf("  - %s\n", name)
+	}
+
+	if isMember != wantMember {
+		if wantMember {
+			return fmt.Errorf("device %s is missing from profile %s after the update", deviceID, profile.Name)
+		}
+		return fmt.Errorf("device %s is still in profile %s after the update", deviceID, profile.Name)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -185,6 +186,178 @@ func TestInspectProfile(t *testing.T) {
 	}
 }
 
+func TestProfileDevicesJSON(t *testing.T) {
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			return &api.ProfileDetails{
+				URL:  "/2.2/networks/12345/profiles/prof1",
+				Name: "Adults",
+				Devices: []struct {
+					URL string `json:"url"`
+				}{
+					{URL: "/2.2/networks/12345/devices/aabbccdd1122"},
+					{URL: "/2.2/networks/12345/devices/112233445566"},
+					{URL: "/2.2/networks/12345/devices/gone"},
+				},
+			}, nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Profiles([]string{"devices", "Adults"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var devices []api.Device
+	if err := json.Unmarshal([]byte(out), &devices); err != nil {
+		t.Fatalf("output is not a JSON device array: %v\n%s", err, out)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("len(devices) = %d, want 2 (unknown devices skipped)", len(devices))
+	}
+	if devices[0].Nickname != "NAS" || devices[1].MAC != "AA:BB:CC:DD:11:22" {
+		t.Errorf("unexpected devices: %+v", devices)
+	}
+}
+
+func TestShowProfileScheduleAndFilters(t *testing.T) {
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			return &api.ProfileDetails{
+				URL:  "/2.2/networks/12345/profiles/prof2",
+				Name: "Kids",
+				Schedules: []api.ProfileSchedule{
+					{Name: "Bedtime", Days: []string{"mon", "tue"}, Start: "21:00", End: "07:00", Enabled: true},
+					{Name: "Homework", Days: []string{"fri"}, Start: "16:00", End: "18:00"},
+				},
+				Filters: map[string]bool{"safe_search": true, "block_gaming": false},
+			}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Profiles([]string{"show", "Kids"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{
+		"Profile: Kids (prof2)",
+		"NAME",
+		"Bedtime",
+		"mon,tue",
+		"21:00",
+		"Homework",
+		"  [ ] block_gaming\n  [x] safe_search\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestShowProfileWithoutScheduleOrFilters(t *testing.T) {
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			return &api.ProfileDetails{Name: "Adults", Paused: true}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.ShowProfile("Adults"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"Status:  paused", "No schedules", "No filters"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestProfilesFilter(t *testing.T) {
+	tests := []struct {
+		category, value string
+		filter          string
+		want            bool
+	}{
+		{"adult", "on", "block_adult_content", true},
+		{"illegal", "on", "block_illegal_content", true},
+		{"violent", "off", "block_violent_content", false},
+		{"SafeSearch", "on", "safe_search", true},
+		{"safesearch", "off", "safe_search", false},
+	}
+
+	for _, tt := range tests {
+		var gotProfileID string
+		var gotFilters map[string]bool
+		mock := &mockClient{
+			GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+				return testProfiles(), nil
+			},
+			SetProfileContentFilterFn: func(networkID, profileID string, filters map[string]bool) error {
+				gotProfileID = profileID
+				gotFilters = filters
+				return nil
+			},
+		}
+		app := newTestApp(mock)
+
+		out := captureStdout(t, func() {
+			if err := app.Profiles([]string{"filter", "Kids", tt.category, tt.value}); err != nil {
+				t.Fatalf("%s %s: unexpected error: %v", tt.category, tt.value, err)
+			}
+		})
+		if gotProfileID != "prof2" {
+			t.Errorf("%s: profile = %q, want prof2", tt.category, gotProfileID)
+		}
+		if len(gotFilters) != 1 || gotFilters[tt.filter] != tt.want {
+			t.Errorf("%s %s: filters = %v, want %s=%t", tt.category, tt.value, gotFilters, tt.filter, tt.want)
+		}
+		if !strings.Contains(out, "turned "+tt.value) {
+			t.Errorf("%s: output = %q", tt.category, out)
+		}
+	}
+}
+
+func TestProfilesFilterUnknownCategory(t *testing.T) {
+	// No mock functions are set, so any API call panics
+	app := newTestApp(&mockClient{})
+
+	err := app.Profiles([]string{"filter", "Kids", "gambling", "on"})
+	if err == nil || !strings.Contains(err.Error(), "unknown filter category: gambling (valid: adult, illegal, safesearch, violent)") {
+		t.Errorf("expected unknown category error, got: %v", err)
+	}
+
+	err = app.Profiles([]string{"filter", "Kids", "adult", "maybe"})
+	if err == nil || !strings.Contains(err.Error(), "must be on or off") {
+		t.Errorf("expected on/off error, got: %v", err)
+	}
+
+	err = app.Profiles([]string{"filter", "Kids"})
+	if err == nil || !strings.Contains(err.Error(), "usage: profiles filter") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
+
 func TestAddDeviceToProfile(t *testing.T) {
 	var gotDeviceURLs []string
 	mock := &mockClient{
@@ -382,3 +555,136 @@ func TestPauseProfileAPIError(t *testing.T) {
 		t.Errorf("error = %q", err.Error())
 	}
 }
+
+// membershipMock returns a mock whose Adults profile (prof1) starts with the
+// laptop. SetProfileDevices updates it only when apply is true.
+func membershipMock(apply bool) *mockClient {
+	urls := []string{"/2.2/networks/12345/devices/aabbccdd1122"}
+	return &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		GetProfileDetailsFn: func(networkID, profileID string) (*api.ProfileDetails, error) {
+			p := &api.ProfileDetails{URL: "/2.2/networks/12345/profiles/prof1", Name: "Adults"}
+			for _, u := range urls {
+				p.Devices = append(p.Devices, struct {
+					URL string `json:"url"`
+				}{URL: u})
+			}
+			return p, nil
+		},
+		SetProfileDevicesFn: func(networkID, profileID string, deviceURLs []string) error {
+			if apply {
+				urls = deviceURLs
+			}
+			return nil
+		},
+	}
+}
+
+func TestProfilesAddVerify(t *testing.T) {
+	app := newTestApp(membershipMock(true))
+
+	out := captureStdout(t, func() {
+		if err := app.Profiles([]string{"add", "prof1", "eeff00112233", "--verify"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"Profile Adults now has 2 devices:", "  - My Laptop", "  - phone"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestProfilesRemoveVerifyCatchesNoOp(t *testing.T) {
+	app := newTestApp(membershipMock(false))
+
+	var err error
+	out := captureStdout(t, func() {
+		err = app.Profiles([]string{"remove", "prof1", "aabbccdd1122", "--verify"})
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "still in profile Adults") {
+		t.Errorf("error = %v, want still-in-profile error", err)
+	}
+	if !strings.Contains(out, "Profile Adults now has 1 devices:") {
+		t.Errorf("output missing post-edit listing:\n%s", out)
+	}
+}
+
+func TestPauseAllProfilesContinuesAfterFailure(t *testing.T) {
+	profiles := append(testProfiles(), api.Profile{URL: "/2.2/networks/12345/profiles/prof3", Name: "Guests"})
+	var paused []string
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return profiles, nil
+		},
+		PauseProfileFn: func(networkID, profileID string, pause bool) error {
+			if !pause {
+				t.Errorf("profile %s unpaused, want paused", profileID)
+			}
+			paused = append(paused, profileID)
+			if profileID == "prof2" {
+				return fmt.Errorf("server error")
+			}
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = app.Profiles([]string{"pause-all"})
+	})
+
+	if want := []string{"prof1", "prof2", "prof3"}; !reflect.DeepEqual(paused, want) {
+		t.Errorf("paused = %v, want %v", paused, want)
+	}
+	if err == nil || err.Error() != "failed to pause 1 of 3 profiles" {
+		t.Errorf("error = %v, want one failure of three", err)
+	}
+	for _, want := range []string{
+		"Profile Adults (prof1) has been paused",
+		"Failed to pause profile Kids (prof2): server error",
+		"Profile Guests (prof3) has been paused",
+		"2 of 3 profiles paused",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestResumeAllProfiles(t *testing.T) {
+	var resumed []string
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		PauseProfileFn: func(networkID, profileID string, pause bool) error {
+			if pause {
+				t.Errorf("profile %s paused, want unpaused", profileID)
+			}
+			resumed = append(resumed, profileID)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Profiles([]string{"resume-all"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if len(resumed) != 2 {
+		t.Errorf("resumed = %v, want both profiles", resumed)
+	}
+	if !strings.Contains(out, "2 of 2 profiles unpaused") {
+		t.Errorf("output missing summary:\n%s", out)
+	}
+}
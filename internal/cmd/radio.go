@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// radioSetting maps a CLI setting name to its API field and display label
+type radioSetting struct {
+	Name  string
+	Field string
+	Label string
+}
+
+// radioSettings lists the settings supported by the radio command
+var radioSettings = []radioSetting{
+	{Name: "band-steering", Field: api.RadioBandSteering, Label: "Band steering"},
+	{Name: "legacy", Field: api.RadioLegacyMode, Label: "Legacy (b/g) mode"},
+}
+
+// Radio handles the radio command
+func (a *App) Radio(args []string) error {
+	if len(args) == 0 || args[0] == "show" {
+		return a.RadioShow()
+	}
+
+	switch args[0] {
+	case "set":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: radio set <band-steering|legacy> <on|off>")
+		}
+		on, err := parseOnOff(args[2])
+		if err != nil {
+			return err
+		}
+		return a.RadioSet(args[1], on)
+	default:
+		return fmt.Errorf("unknown radio subcommand: %s", args[0])
+	}
+}
+
+// RadioShow displays the network's radio settings
+func (a *App) RadioShow() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	settings, err := a.Client.GetRadioSettings(networkID)
+	if err != nil {
+		return fmt.Errorf("getting radio settings: %w", err)
+	}
+
+	fmt.Println("Radio Settings")
+	fmt.Println("--------------")
+	for _, rs := range radioSettings {
+		value, supported := settings.Get(rs.Field)
+		state := "unsupported"
+		if supported {
+			state = onOff(value)
+		}
+		fmt.Printf("%-18s %s\n", rs.Label+":", state)
+	}
+
+	return nil
+}
+
+// RadioSet turns a radio setting on or off
+func (a *App) RadioSet(name string, on bool) error {
+	var setting *radioSetting
+	var names []string
+	for i, rs := range radioSettings {
+		names = append(names, rs.Name)
+		if strings.EqualFold(rs.Name, name) {
+			setting = &radioSettings[i]
+		}
+	}
+	if setting == nil {
+		return fmt.Errorf("unknown radio setting: %s (must be %s)", name, strings.Join(names, " or "))
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	settings, err := a.Client.GetRadioSettings(networkID)
+	if err != nil {
+		return fmt.Errorf("getting radio settings: %w", err)
+	}
+	if _, supported := settings.Get(setting.Field); !supported {
+		return fmt.Errorf("%s is unsupported on this hardware", strings.ToLower(setting.Label))
+	}
+
+	if err := a.Client.SetRadioSetting(networkID, setting.Field, on); err != nil {
+		return fmt.Errorf("updating radio settings: %w", err)
+	}
+
+	fmt.Printf("%s turned %s\n", setting.Label, onOff(on))
+	return nil
+}
+
+// parseOnOff parses an on/off argument
+func parseOnOff(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "on", "true", "yes", "enable":
+		return true, nil
+	case "off", "false", "no", "disable":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid value: %s (must be on or off)", s)
+}
+
+// onOff formats a boolean as "on" or "off"
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestRadioShow(t *testing.T) {
+	mock := &mockClient{
+		GetRadioSettingsFn: func(networkID string) (*api.RadioSettings, error) {
+			return &api.RadioSettings{BandSteering: boolPtr(true)}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Radio(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Band steering:") || !strings.Contains(out, "on") {
+		t.Errorf("expected band steering on, got:\n%s", out)
+	}
+	if !strings.Contains(out, "unsupported") {
+		t.Errorf("expected legacy mode unsupported, got:\n%s", out)
+	}
+}
+
+func TestRadioSet(t *testing.T) {
+	var gotName string
+	var gotOn bool
+	mock := &mockClient{
+		GetRadioSettingsFn: func(networkID string) (*api.RadioSettings, error) {
+			return &api.RadioSettings{BandSteering: boolPtr(true), LegacyMode: boolPtr(false)}, nil
+		},
+		SetRadioSettingFn: func(networkID, name string, on bool) error {
+			gotName, gotOn = name, on
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Radio([]string{"set", "legacy", "on"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if gotName != api.RadioLegacyMode || !gotOn {
+		t.Errorf("SetRadioSetting(%q, %v), want (%q, true)", gotName, gotOn, api.RadioLegacyMode)
+	}
+	if !strings.Contains(out, "Legacy (b/g) mode turned on") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	captureStdout(t, func() {
+		if err := app.Radio([]string{"set", "band-steering", "off"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if gotName != api.RadioBandSteering || gotOn {
+		t.Errorf("SetRadioSetting(%q, %v), want (%q, false)", gotName, gotOn, api.RadioBandSteering)
+	}
+}
+
+func TestRadioSetUnsupported(t *testing.T) {
+	mock := &mockClient{
+		GetRadioSettingsFn: func(networkID string) (*api.RadioSettings, error) {
+			return &api.RadioSettings{BandSteering: boolPtr(true)}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	err := app.RadioSet("legacy", true)
+	if err == nil || !strings.Contains(err.Error(), "unsupported on this hardware") {
+		t.Errorf("expected unsupported error, got: %v", err)
+	}
+}
+
+func TestRadioSetInvalidArgs(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	if err := app.Radio([]string{"set", "turbo", "on"}); err == nil || !strings.Contains(err.Error(), "unknown radio setting") {
+		t.Errorf("expected unknown setting error, got: %v", err)
+	}
+	if err := app.Radio([]string{"set", "legacy", "maybe"}); err == nil || !strings.Contains(err.Error(), "invalid value") {
+		t.Errorf("expected invalid value error, got: %v", err)
+	}
+	if err := app.Radio([]string{"set", "legacy"}); err == nil || !strings.Contains(err.Error(), "usage:") {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
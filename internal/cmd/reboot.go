@@ -2,27 +2,74 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 )
 
-// Reboot handles the reboot command
-func (a *App) Reboot() error {
+// RebootResult is the machine-readable result of reboot. Declining the
+// confirmation is a result too, not an error.
+type RebootResult struct {
+	Action    string `json:"action"`
+	NetworkID string `json:"network_id"`
+	EeroID    string `json:"eero_id,omitempty"`
+	Cancelled bool   `json:"cancelled"`
+}
+
+// Reboot handles the reboot command: it reboots the whole network, or with
+// --eero a single node
+func (a *App) Reboot(args []string) error {
+	var eeroQuery string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--eero" && i+1 < len(args) {
+			eeroQuery = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--eero=") {
+			eeroQuery = strings.TrimPrefix(args[i], "--eero=")
+		} else {
+			return fmt.Errorf("usage: reboot [--eero <id|location|serial>]")
+		}
+	}
+
+	if eeroQuery != "" {
+		return a.RebootEero(eeroQuery)
+	}
+	return a.RebootNetwork()
+}
+
+// RebootNetwork reboots every eero on the network after confirming
+func (a *App) RebootNetwork() error {
 	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
 	}
 
-	if !Confirm("Are you sure you want to reboot the network? This will disconnect all devices temporarily.") {
-		fmt.Println("Reboot cancelled")
-		return nil
+	result := RebootResult{Action: "reboot", NetworkID: networkID}
+
+	if !a.confirm("Are you sure you want to reboot the network? This will disconnect all devices temporarily.") {
+		return a.rebootCancelled(result)
 	}
 
-	fmt.Println("Rebooting network...")
+	if !a.Quiet {
+		a.progressf("Rebooting network...\n")
+	}
 
 	if err := a.Client.Reboot(networkID); err != nil {
 		return fmt.Errorf("rebooting network: %w", err)
 	}
 
+	if a.structuredOutput() {
+		return a.printData(result)
+	}
 	fmt.Println("Network reboot initiated. Devices will reconnect automatically.")
 
 	return nil
 }
+
+// rebootCancelled reports a declined reboot confirmation
+func (a *App) rebootCancelled(result RebootResult) error {
+	result.Cancelled = true
+	if a.structuredOutput() {
+		return a.printData(result)
+	}
+	fmt.Println("Reboot cancelled")
+	return nil
+}
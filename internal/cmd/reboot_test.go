@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestRebootDeclinedJSON(t *testing.T) {
+	mock := &mockClient{
+		RebootFn: func(networkID string) error {
+			t.Fatal("Reboot should not be called when declined")
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	var out string
+	feedStdin(t, "n\n", func() {
+		// The question goes to stderr so stdout holds only the result
+		captureStderr(t, func() {
+			out = captureStdout(t, func() {
+				if err := app.Reboot(nil); err != nil {
+					t.Fatalf("declining should not be an error: %v", err)
+				}
+			})
+		})
+	})
+
+	var result RebootResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+	}
+	want := RebootResult{Action: "reboot", NetworkID: "12345", Cancelled: true}
+	if result != want {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+}
+
+func TestRebootDeclinedTable(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	var out string
+	feedStdin(t, "\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.Reboot(nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	if !strings.Contains(out, "Reboot cancelled") {
+		t.Errorf("output = %q, want cancellation notice", out)
+	}
+}
+
+func TestRebootInterruptedAtConfirmation(t *testing.T) {
+	mock := &mockClient{
+		RebootFn: func(networkID string) error {
+			t.Fatal("Reboot should not be called after Ctrl+C")
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+	ctx, cancel := context.WithCancel(context.Background())
+	app.ctx = ctx
+
+	// Nothing is ever typed; Ctrl+C arrives while the prompt waits
+	old := os.Stdin
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	os.Stdin = r
+	defer func() {
+		os.Stdin = old
+		w.Close()
+		r.Close()
+	}()
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	out := captureStdout(t, func() {
+		if err := app.Reboot(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Reboot cancelled") {
+		t.Errorf("output = %q, want cancellation notice", out)
+	}
+}
+
+func TestRebootConfirmedJSON(t *testing.T) {
+	rebooted := ""
+	mock := &mockClient{
+		RebootFn: func(networkID string) error {
+			rebooted = networkID
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+	app.Quiet = true
+
+	var out string
+	feedStdin(t, "y\n", func() {
+		// The question goes to stderr so stdout holds only the result
+		captureStderr(t, func() {
+			out = captureStdout(t, func() {
+				if err := app.Reboot(nil); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			})
+		})
+	})
+
+	if rebooted != "12345" {
+		t.Errorf("rebooted = %q, want 12345", rebooted)
+	}
+	var result RebootResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+	}
+	if result.Cancelled {
+		t.Errorf("result = %+v, want not cancelled", result)
+	}
+}
+
+// rebootNodeMock returns a mock with testEeros that records single-node
+// reboots and fails on whole-network ones
+func rebootNodeMock(t *testing.T, rebooted *[]string) *mockClient {
+	return &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+		RebootEeroFn: func(eeroID string) error {
+			*rebooted = append(*rebooted, eeroID)
+			return nil
+		},
+		RebootFn: func(networkID string) error {
+			t.Fatal("reboot --eero must not reboot the network")
+			return nil
+		},
+	}
+}
+
+func TestRebootEeroFlag(t *testing.T) {
+	for _, args := range [][]string{
+		{"--eero", "bedroom"},
+		{"--eero=SN87654321"},
+		{"--eero", "8318691"},
+	} {
+		var rebooted []string
+		app := newTestApp(rebootNodeMock(t, &rebooted))
+
+		var out string
+		feedStdin(t, "y\n", func() {
+			out = captureStdout(t, func() {
+				if err := app.Reboot(args); err != nil {
+					t.Fatalf("%v: unexpected error: %v", args, err)
+				}
+			})
+		})
+		if len(rebooted) != 1 || rebooted[0] != "8318691" {
+			t.Errorf("%v: rebooted = %v, want [8318691]", args, rebooted)
+		}
+		if !strings.Contains(out, "Reboot eero 8318691 (Bedroom)?") || !strings.Contains(out, "Rebooting eero 8318691 (Bedroom)") {
+			t.Errorf("%v: output = %q", args, out)
+		}
+	}
+}
+
+func TestRebootEeroDeclinedJSON(t *testing.T) {
+	var rebooted []string
+	app := newTestApp(rebootNodeMock(t, &rebooted))
+	app.Output = OutputJSON
+
+	var out string
+	feedStdin(t, "n\n", func() {
+		captureStderr(t, func() {
+			out = captureStdout(t, func() {
+				if err := app.Reboot([]string{"--eero", "Living Room"}); err != nil {
+					t.Fatalf("declining should not be an error: %v", err)
+				}
+			})
+		})
+	})
+
+	if len(rebooted) != 0 {
+		t.Errorf("rebooted = %v after declining", rebooted)
+	}
+	var result RebootResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+	}
+	want := RebootResult{Action: "reboot", NetworkID: "12345", EeroID: "8318690", Cancelled: true}
+	if result != want {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+}
+
+func TestRebootUsage(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	for _, args := range [][]string{{"now"}, {"--eero"}} {
+		if err := app.Reboot(args); err == nil || !strings.HasPrefix(err.Error(), "usage:") {
+			t.Errorf("Reboot(%v) error = %v, want usage error", args, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// NicknameRow is one line of a rename mapping file
+type NicknameRow struct {
+	Line     int
+	MAC      string
+	Nickname string
+	// Err is set for a row that can't be applied, such as one without a
+	// nickname
+	Err error
+}
+
+// parseNicknameMapping reads mac,nickname rows from CSV, or TSV when the
+// first line has a tab. A leading "mac" header row is skipped, as are blank
+// lines.
+func parseNicknameMapping(r io.Reader) ([]NicknameRow, error) {
+	br := bufio.NewReader(r)
+	first, err := br.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	if line, _, _ := strings.Cut(string(first), "\n"); strings.Contains(line, "\t") {
+		cr.Comma = '\t'
+	}
+
+	var rows []NicknameRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		mac := strings.TrimSpace(record[0])
+		if len(rows) == 0 && strings.EqualFold(mac, "mac") {
+			continue // header
+		}
+		if mac == "" && len(record) == 1 {
+			continue // blank line
+		}
+
+		row := NicknameRow{Line: line, MAC: mac}
+		switch {
+		case !isMAC(mac):
+			row.Err = fmt.Errorf("invalid MAC address: %q", mac)
+		case len(record) < 2 || strings.TrimSpace(record[1]) == "":
+			row.Err = fmt.Errorf("missing nickname")
+		case len(record) > 2:
+			row.Err = fmt.Errorf("expected 2 columns (mac,nickname), got %d", len(record))
+		default:
+			row.Nickname = strings.TrimSpace(record[1])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// isMAC reports whether s is a MAC address, with or without : or -
+// separators
+func isMAC(s string) bool {
+	n := normalizeMAC(s)
+	_, err := hex.DecodeString(n)
+	return len(n) == 12 && err == nil
+}
+
+// colonMAC formats a MAC address as lowercase colon-separated pairs
+func colonMAC(mac string) string {
+	n := normalizeMAC(mac)
+	var pairs []string
+	for i := 0; i+2 <= len(n); i += 2 {
+		pairs = append(pairs, n[i:i+2])
+	}
+	return strings.Join(pairs, ":")
+}
+
+// RenameDevicesFromFile sets nicknames from a mac,nickname CSV or TSV file
+// ("-" reads stdin). Rows that fail, such as ones with a MAC that isn't on
+// the network, are reported without stopping the rest.
+func (a *App) RenameDevicesFromFile(path string) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("reading mapping file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	rows, err := parseNicknameMapping(r)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		fmt.Printf("No devices to rename in %s\n", path)
+		return nil
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, row := range rows {
+		if err := a.renameRow(networkID, row); err != nil {
+			fmt.Printf("Failed to rename %s (line %d): %v\n", row.MAC, row.Line, err)
+			failed++
+		}
+	}
+
+	fmt.Printf("\n%d of %d devices renamed\n", len(rows)-failed, len(rows))
+	if failed > 0 {
+		return fmt.Errorf("failed to rename %d of %d devices", failed, len(rows))
+	}
+	return nil
+}
+
+// renameRow applies one mapping row
+func (a *App) renameRow(networkID string, row NicknameRow) error {
+	if row.Err != nil {
+		return row.Err
+	}
+	// With colons, the MAC can't be mistaken for a device ID prefix
+	deviceID, err := a.findDeviceID(networkID, colonMAC(row.MAC))
+	if err != nil {
+		return err
+	}
+	if err := a.Client.SetDeviceNickname(networkID, deviceID, row.Nickname); err != nil {
+		return fmt.Errorf("updating device: %w", err)
+	}
+	fmt.Printf("Device %s (%s) has been renamed to '%s'\n", deviceID, row.MAC, row.Nickname)
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestParseNicknameMapping(t *testing.T) {
+	input := `mac,nickname
+AA:BB:CC:DD:11:22, Work Laptop
+eeff00112233,"Phone, old"
+
+not-a-mac,Printer
+11:22:33:44:55:66,
+`
+	rows, err := parseNicknameMapping(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want 4: %+v", len(rows), rows)
+	}
+	if rows[0].MAC != "AA:BB:CC:DD:11:22" || rows[0].Nickname != "Work Laptop" || rows[0].Line != 2 {
+		t.Errorf("rows[0] = %+v", rows[0])
+	}
+	if rows[1].Nickname != "Phone, old" || rows[1].Err != nil {
+		t.Errorf("rows[1] = %+v, want quoted nickname", rows[1])
+	}
+	if rows[2].Err == nil || !strings.Contains(rows[2].Err.Error(), "invalid MAC") {
+		t.Errorf("rows[2].Err = %v, want invalid MAC", rows[2].Err)
+	}
+	if rows[3].Err == nil || !strings.Contains(rows[3].Err.Error(), "missing nickname") {
+		t.Errorf("rows[3].Err = %v, want missing nickname", rows[3].Err)
+	}
+}
+
+func TestParseNicknameMappingTSV(t *testing.T) {
+	rows, err := parseNicknameMapping(strings.NewReader("aa-bb-cc-dd-11-22\tDen TV\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []NicknameRow{{Line: 1, MAC: "aa-bb-cc-dd-11-22", Nickname: "Den TV"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v, want %+v", rows, want)
+	}
+}
+
+func TestDevicesRenameFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.csv")
+	mapping := "aa:bb:cc:dd:11:22,Work Laptop\n11-22-33-44-55-66,Backup NAS\nde:ad:be:ef:00:01,Ghost\n"
+	if err := os.WriteFile(path, []byte(mapping), 0600); err != nil {
+		t.Fatalf("writing mapping: %v", err)
+	}
+
+	renamed := make(map[string]string)
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		SetDeviceNicknameFn: func(networkID, deviceID, nickname string) error {
+			renamed[deviceID] = nickname
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = app.Devices([]string{"rename", "--from-file", path})
+	})
+
+	want := map[string]string{"aabbccdd1122": "Work Laptop", "112233445566": "Backup NAS"}
+	if !reflect.DeepEqual(renamed, want) {
+		t.Errorf("renamed = %v, want %v", renamed, want)
+	}
+	// The unknown MAC is reported, and the others still applied
+	if !strings.Contains(out, "Failed to rename de:ad:be:ef:00:01 (line 3)") {
+		t.Errorf("output missing failure for unknown MAC:\n%s", out)
+	}
+	if !strings.Contains(out, "2 of 3 devices renamed") {
+		t.Errorf("output missing summary:\n%s", out)
+	}
+	if err == nil || err.Error() != "failed to rename 1 of 3 devices" {
+		t.Errorf("err = %v, want failure count", err)
+	}
+}
+
+func TestDevicesRenameFromMissingFile(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Devices([]string{"rename", "--from-file=" + filepath.Join(t.TempDir(), "nope.csv")})
+	if err == nil || !strings.Contains(err.Error(), "reading mapping file") {
+		t.Errorf("err = %v, want read error", err)
+	}
+}
@@ -1,9 +1,8 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/dorin/eero-cli/internal/api"
@@ -11,37 +10,78 @@ import (
 
 // Reservations handles the reservations command
 func (a *App) Reservations(args []string) error {
+	// Parse flags
+	var withStatus, yes, prune bool
+	var fromDevice, desc string
+	var filteredArgs []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--with-status" {
+			withStatus = true
+		} else if args[i] == "--yes" || args[i] == "-y" {
+			yes = true
+		} else if args[i] == "--prune" {
+			prune = true
+		} else if args[i] == "--from-device" && i+1 < len(args) {
+			fromDevice = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--from-device=") {
+			fromDevice = strings.TrimPrefix(args[i], "--from-device=")
+		} else if args[i] == "--desc" && i+1 < len(args) {
+			desc = args[i+1]
+			i++ // skip the value
+		} else if strings.HasPrefix(args[i], "--desc=") {
+			desc = strings.TrimPrefix(args[i], "--desc=")
+		} else {
+			filteredArgs = append(filteredArgs, args[i])
+		}
+	}
+	args = filteredArgs
+
 	if len(args) == 0 {
-		return a.ListReservations()
+		return a.ListReservations(withStatus)
 	}
 
 	switch args[0] {
 	case "add":
+		if fromDevice != "" {
+			return a.AddReservationFromDevice(fromDevice, desc)
+		}
 		if len(args) < 3 {
-			return fmt.Errorf("usage: reservations add <mac> <ip> [description]")
+			return fmt.Errorf("usage: reservations add <mac> <ip> [description] | --from-device <device> [--desc <text>]")
 		}
-		desc := ""
-		if len(args) >= 4 {
+		if desc == "" && len(args) >= 4 {
 			desc = strings.Join(args[3:], " ")
 		}
 		return a.AddReservation(args[1], args[2], desc)
 	case "remove":
 		if len(args) < 2 {
-			return fmt.Errorf("usage: reservations remove <id|mac|ip>")
+			return fmt.Errorf("usage: reservations remove [--yes] <id|mac|ip>...")
 		}
-		return a.RemoveReservation(args[1])
+		return a.RemoveReservations(args[1:], yes)
 	case "inspect":
 		if len(args) < 2 {
 			return fmt.Errorf("usage: reservations inspect <id|mac|ip>")
 		}
 		return a.InspectReservation(args[1])
+	case "backup":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: reservations backup <file>")
+		}
+		return a.BackupReservations(args[1])
+	case "restore":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: reservations restore <file> [--prune] [--yes]")
+		}
+		return a.RestoreReservations(args[1], prune, yes)
 	default:
 		return fmt.Errorf("unknown reservations subcommand: %s", args[0])
 	}
 }
 
-// ListReservations lists all DHCP reservations
-func (a *App) ListReservations() error {
+// ListReservations lists all DHCP reservations. When withStatus is true,
+// each reservation is cross-referenced with the current device list to show
+// whether the reserved MAC is connected and under what name.
+func (a *App) ListReservations(withStatus bool) error {
 	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
@@ -52,15 +92,52 @@ func (a *App) ListReservations() error {
 		return fmt.Errorf("getting reservations: %w", err)
 	}
 
+	if a.structuredOutput() {
+		if reservations == nil {
+			reservations = []api.Reservation{}
+		}
+		sort.SliceStable(reservations, func(i, j int) bool {
+			return normalizeMAC(reservations[i].MAC) < normalizeMAC(reservations[j].MAC)
+		})
+		return a.printData(reservations)
+	}
+
+	// Build MAC to device map once for status lookups
+	devicesByMAC := make(map[string]api.Device)
+	if withStatus {
+		devices, err := a.devices(networkID)
+		if err != nil {
+			return fmt.Errorf("getting devices: %w", err)
+		}
+		for _, d := range devices {
+			devicesByMAC[normalizeMAC(d.MAC)] = d
+		}
+	}
+
 	headers := []string{"IP", "MAC", "DESCRIPTION", "ID"}
+	if withStatus {
+		headers = append(headers, "CONNECTED", "NAME")
+	}
 	var rows [][]string
 	for _, r := range reservations {
-		rows = append(rows, []string{
+		row := []string{
 			r.IP,
 			r.MAC,
 			r.Description,
 			api.ExtractReservationID(r.URL),
-		})
+		}
+		if withStatus {
+			connected := "no"
+			name := ""
+			if d, ok := devicesByMAC[normalizeMAC(r.MAC)]; ok {
+				if d.Connected {
+					connected = "yes"
+				}
+				name = d.DisplayName()
+			}
+			row = append(row, connected, name)
+		}
+		rows = append(rows, row)
 	}
 
 	PrintTable(headers, rows)
@@ -82,23 +159,111 @@ func (a *App) AddReservation(mac, ip, description string) error {
 	return nil
 }
 
-// RemoveReservation deletes a DHCP reservation
-func (a *App) RemoveReservation(query string) error {
+// AddReservationFromDevice reserves a device's current IP address. The
+// description defaults to the device's name.
+func (a *App) AddReservationFromDevice(deviceQuery, description string) error {
 	networkID, err := a.EnsureNetwork()
 	if err != nil {
 		return err
 	}
 
-	reservationID, err := a.findReservationID(networkID, query)
+	deviceID, err := a.findDeviceID(networkID, deviceQuery)
 	if err != nil {
 		return err
 	}
 
-	if err := a.Client.DeleteReservation(networkID, reservationID); err != nil {
-		return fmt.Errorf("deleting reservation: %w", err)
+	devices, err := a.devices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+
+	var device *api.Device
+	for i := range devices {
+		if api.ExtractDeviceID(devices[i].URL) == deviceID {
+			device = &devices[i]
+			break
+		}
+	}
+	if device == nil {
+		return notFoundError("device", deviceQuery, nil)
+	}
+
+	if device.IP == "" {
+		if !device.Connected {
+			return fmt.Errorf("device %s is offline and has no known IP address", device.DisplayName())
+		}
+		return fmt.Errorf("device %s has no IPv4 address to reserve", device.DisplayName())
+	}
+
+	if description == "" {
+		description = device.Nickname
+		if description == "" {
+			description = device.Hostname
+		}
+	}
+
+	return a.AddReservation(device.MAC, device.IP, description)
+}
+
+// RemoveReservations deletes one or more DHCP reservations. All queries are
+// resolved up front; the matches are shown and confirmed (unless yes is set)
+// before anything is deleted.
+func (a *App) RemoveReservations(queries []string, yes bool) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	reservations, err := a.Client.GetReservations(networkID)
+	if err != nil {
+		return fmt.Errorf("getting reservations: %w", err)
+	}
+
+	// Resolve every query before deleting anything
+	var matched []api.Reservation
+	seen := make(map[string]bool)
+	for _, q := range queries {
+		r, ok := matchReservation(reservations, q)
+		if !ok {
+			return notFoundError("reservation", strings.ToLower(q), nil)
+		}
+		id := api.ExtractReservationID(r.URL)
+		if !seen[id] {
+			seen[id] = true
+			matched = append(matched, r)
+		}
+	}
+
+	if !yes {
+		fmt.Println("The following reservations will be deleted:")
+		for _, r := range matched {
+			fmt.Printf("  %s  %s  %s\n", r.IP, r.MAC, r.Description)
+		}
+		if !Confirm(a.context(), "Delete these reservations?") {
+			fmt.Println("Delete cancelled")
+			return nil
+		}
+	}
+
+	var failed int
+	for _, r := range matched {
+		reservationID := api.ExtractReservationID(r.URL)
+		if err := a.Client.DeleteReservation(networkID, reservationID); err != nil {
+			fmt.Printf("Failed to delete reservation %s (%s): %v\n", r.IP, r.MAC, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Reservation deleted: %s (%s)\n", r.IP, r.MAC)
+	}
+
+	if len(matched) > 1 {
+		fmt.Printf("\nDeleted %d of %d reservations\n", len(matched)-failed, len(matched))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d reservations", failed, len(matched))
 	}
 
-	fmt.Println("Reservation deleted")
 	return nil
 }
 
@@ -119,13 +284,7 @@ func (a *App) InspectReservation(query string) error {
 		return fmt.Errorf("getting reservation: %w", err)
 	}
 
-	var pretty bytes.Buffer
-	if err := json.Indent(&pretty, data, "", "  "); err != nil {
-		return fmt.Errorf("formatting JSON: %w", err)
-	}
-
-	fmt.Println(pretty.String())
-	return nil
+	return a.printRawJSON(data)
 }
 
 // findReservationID resolves a query (ID, MAC, or IP) to a reservation ID
@@ -135,6 +294,16 @@ func (a *App) findReservationID(networkID, query string) (string, error) {
 		return "", fmt.Errorf("getting reservations: %w", err)
 	}
 
+	r, ok := matchReservation(reservations, query)
+	if !ok {
+		return "", notFoundError("reservation", strings.ToLower(query), nil)
+	}
+
+	return api.ExtractReservationID(r.URL), nil
+}
+
+// matchReservation finds the reservation matching a query by ID, MAC, or IP
+func matchReservation(reservations []api.Reservation, query string) (api.Reservation, bool) {
 	query = strings.ToLower(query)
 
 	for _, r := range reservations {
@@ -142,19 +311,27 @@ func (a *App) findReservationID(networkID, query string) (string, error) {
 
 		// Exact ID match
 		if reservationID == query {
-			return reservationID, nil
+			return r, true
 		}
 
 		// MAC match (normalized)
-		if strings.ToLower(r.MAC) == query || strings.ReplaceAll(strings.ToLower(r.MAC), ":", "") == strings.ReplaceAll(query, ":", "") {
-			return reservationID, nil
+		if normalizeMAC(r.MAC) == normalizeMAC(query) {
+			return r, true
 		}
 
 		// IP match
 		if r.IP == query {
-			return reservationID, nil
+			return r, true
 		}
 	}
 
-	return "", fmt.Errorf("reservation not found: %s", query)
+	return api.Reservation{}, false
+}
+
+// normalizeMAC lowercases a MAC address and strips separators so that
+// "AA:BB:CC:DD:EE:FF", "aa-bb-cc-dd-ee-ff", and "aabbccddeeff" compare equal
+func normalizeMAC(mac string) string {
+	mac = strings.ToLower(mac)
+	mac = strings.ReplaceAll(mac, ":", "")
+	return strings.ReplaceAll(mac, "-", "")
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -24,7 +25,7 @@ func TestListReservations(t *testing.T) {
 	app := newTestApp(mock)
 
 	out := captureStdout(t, func() {
-		if err := app.ListReservations(); err != nil {
+		if err := app.ListReservations(false); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
@@ -40,6 +41,69 @@ func TestListReservations(t *testing.T) {
 	}
 }
 
+func TestReservationsNoArgsLists(t *testing.T) {
+	calls := 0
+	mock := &mockClient{
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			calls++
+			return testReservations(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Reservations(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if calls != 1 {
+		t.Errorf("GetReservations called %d times, want 1", calls)
+	}
+	if !strings.Contains(out, "res1") || !strings.Contains(out, "NAS Server") {
+		t.Errorf("output is not the reservations list:\n%s", out)
+	}
+}
+
+func TestListReservationsWithStatus(t *testing.T) {
+	mock := &mockClient{
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return testReservations(), nil
+		},
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Reservations([]string{"--with-status"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "CONNECTED") || !strings.Contains(out, "NAME") {
+		t.Errorf("output missing status columns, got:\n%s", out)
+	}
+
+	// 11:22:33:44:55:66 is the NAS, which is online
+	var nasLine, printerLine string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "192.168.1.10 ") {
+			nasLine = line
+		}
+		if strings.Contains(line, "192.168.1.20 ") {
+			printerLine = line
+		}
+	}
+	if !strings.Contains(nasLine, "yes") || !strings.Contains(nasLine, "NAS") {
+		t.Errorf("NAS reservation should show connected device name, got: %q", nasLine)
+	}
+	// The printer MAC is not on the network
+	if !strings.Contains(printerLine, "no") {
+		t.Errorf("Printer reservation should show not connected, got: %q", printerLine)
+	}
+}
+
 func TestAddReservation(t *testing.T) {
 	var gotIP, gotMAC, gotDesc string
 	mock := &mockClient{
@@ -72,6 +136,66 @@ func TestAddReservation(t *testing.T) {
 	}
 }
 
+func TestAddReservationFromDevice(t *testing.T) {
+	var gotIP, gotMAC, gotDesc string
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		CreateReservationFn: func(networkID, ip, mac, description string) error {
+			gotIP, gotMAC, gotDesc = ip, mac, description
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Reservations([]string{"add", "--from-device", "My Laptop"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if gotIP != "192.168.1.100" || gotMAC != "AA:BB:CC:DD:11:22" {
+		t.Errorf("reservation = %s -> %s, want AA:BB:CC:DD:11:22 -> 192.168.1.100", gotMAC, gotIP)
+	}
+	if gotDesc != "My Laptop" {
+		t.Errorf("Description = %q, want nickname %q", gotDesc, "My Laptop")
+	}
+	if !strings.Contains(out, "Reservation created") {
+		t.Error("output missing confirmation message")
+	}
+
+	// --desc overrides the default description
+	captureStdout(t, func() {
+		if err := app.Reservations([]string{"add", "--from-device=NAS", "--desc", "Storage"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if gotIP != "192.168.1.10" || gotDesc != "Storage" {
+		t.Errorf("reservation = %s (%q), want 192.168.1.10 (Storage)", gotIP, gotDesc)
+	}
+}
+
+func TestAddReservationFromOfflineDevice(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			devices := testDevices()
+			devices[1].IP = "" // phone is offline
+			return devices, nil
+		},
+		CreateReservationFn: func(networkID, ip, mac, description string) error {
+			t.Error("CreateReservation should not be called")
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	err := app.AddReservationFromDevice("phone", "")
+	if err == nil || !strings.Contains(err.Error(), "offline and has no known IP") {
+		t.Errorf("expected offline error, got: %v", err)
+	}
+}
+
 func TestRemoveReservation(t *testing.T) {
 	var deletedID string
 	mock := &mockClient{
@@ -86,7 +210,7 @@ func TestRemoveReservation(t *testing.T) {
 	app := newTestApp(mock)
 
 	out := captureStdout(t, func() {
-		if err := app.RemoveReservation("res1"); err != nil {
+		if err := app.RemoveReservations([]string{"res1"}, true); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
@@ -134,7 +258,7 @@ func TestFindReservationByMAC(t *testing.T) {
 
 	// Find by MAC (case-insensitive)
 	captureStdout(t, func() {
-		if err := app.RemoveReservation("aa:bb:cc:dd:ee:ff"); err != nil {
+		if err := app.RemoveReservations([]string{"aa:bb:cc:dd:ee:ff"}, true); err != nil {
 			t.Fatalf("find by MAC failed: %v", err)
 		}
 	})
@@ -152,7 +276,7 @@ func TestFindReservationByIP(t *testing.T) {
 	app := newTestApp(mock)
 
 	captureStdout(t, func() {
-		if err := app.RemoveReservation("192.168.1.20"); err != nil {
+		if err := app.RemoveReservations([]string{"192.168.1.20"}, true); err != nil {
 			t.Fatalf("find by IP failed: %v", err)
 		}
 	})
@@ -170,7 +294,7 @@ func TestFindReservationByMACWithoutColons(t *testing.T) {
 	app := newTestApp(mock)
 
 	captureStdout(t, func() {
-		if err := app.RemoveReservation("112233445566"); err != nil {
+		if err := app.RemoveReservations([]string{"112233445566"}, true); err != nil {
 			t.Fatalf("find by MAC without colons failed: %v", err)
 		}
 	})
@@ -184,7 +308,7 @@ func TestFindReservationNotFound(t *testing.T) {
 	}
 	app := newTestApp(mock)
 
-	err := app.RemoveReservation("nonexistent")
+	err := app.RemoveReservations([]string{"nonexistent"}, true)
 	if err == nil {
 		t.Fatal("expected error for nonexistent reservation")
 	}
@@ -192,3 +316,98 @@ func TestFindReservationNotFound(t *testing.T) {
 		t.Errorf("error = %q, want 'reservation not found'", err.Error())
 	}
 }
+
+func TestRemoveReservationConfirm(t *testing.T) {
+	var deletedID string
+	mock := &mockClient{
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return testReservations(), nil
+		},
+		DeleteReservationFn: func(networkID, reservationID string) error {
+			deletedID = reservationID
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var out string
+	feedStdin(t, "y\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.RemoveReservations([]string{"192.168.1.10"}, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	if deletedID != "res1" {
+		t.Errorf("deleted = %q, want %q", deletedID, "res1")
+	}
+	// The matched reservation is shown before confirming
+	if !strings.Contains(out, "NAS Server") {
+		t.Errorf("output missing matched reservation summary, got:\n%s", out)
+	}
+}
+
+func TestRemoveReservationDeclined(t *testing.T) {
+	mock := &mockClient{
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return testReservations(), nil
+		},
+		DeleteReservationFn: func(networkID, reservationID string) error {
+			t.Errorf("DeleteReservation called for %s after declining", reservationID)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var out string
+	feedStdin(t, "n\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.RemoveReservations([]string{"res1"}, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	if !strings.Contains(out, "cancelled") {
+		t.Errorf("output missing cancellation, got:\n%s", out)
+	}
+}
+
+func TestRemoveMultipleReservations(t *testing.T) {
+	var deleted []string
+	mock := &mockClient{
+		GetReservationsFn: func(networkID string) ([]api.Reservation, error) {
+			return testReservations(), nil
+		},
+		DeleteReservationFn: func(networkID, reservationID string) error {
+			deleted = append(deleted, reservationID)
+			if reservationID == "res2" {
+				return fmt.Errorf("API error: conflict")
+			}
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = app.Reservations([]string{"remove", "--yes", "res1", "AA:BB:CC:DD:EE:FF"})
+	})
+
+	if len(deleted) != 2 {
+		t.Fatalf("deleted = %v, want both reservations attempted", deleted)
+	}
+	if err == nil || !strings.Contains(err.Error(), "failed to delete 1 of 2") {
+		t.Errorf("expected aggregated failure, got: %v", err)
+	}
+	if !strings.Contains(out, "Reservation deleted: 192.168.1.10") {
+		t.Errorf("output missing per-item success, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Failed to delete reservation 192.168.1.20") {
+		t.Errorf("output missing per-item failure, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Deleted 1 of 2 reservations") {
+		t.Errorf("output missing summary, got:\n%s", out)
+	}
+}
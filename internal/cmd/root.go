@@ -3,43 +3,162 @@ package cmd
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"strings"
 
 	"github.com/dorin/eero-cli/internal/api"
 	"github.com/dorin/eero-cli/internal/config"
+	"golang.org/x/term"
 )
 
 // App holds the application state
 type App struct {
 	Config *config.Config
 	Client api.EeroAPI
+
+	// Color is true when output may contain ANSI escape codes
+	Color bool
+
+	// Output is the selected output format (table, json, yaml, csv, or hosts)
+	Output string
+
+	// Envelope wraps JSON output with a metadata envelope
+	Envelope bool
+
+	// Compact prints JSON on a single line instead of indented
+	Compact bool
+
+	// OnlyFields limits JSON output to these fields
+	OnlyFields []string
+
+	// DiskCache saves read results on disk for use with StaleOK
+	DiskCache bool
+
+	// TrackIPs records the IPs of listed devices in the IP history, for
+	// devices churn
+	TrackIPs bool
+
+	// StaleOK falls back to cached data when the API is unreachable
+	StaleOK bool
+
+	// Quiet suppresses informational notices on stderr
+	Quiet bool
+
+	// Command is the name of the top-level command being run
+	Command string
+
+	// LocalAddrs detects this machine's addresses so pausing or blocking
+	// it can be caught; nil disables the check
+	LocalAddrs func() (localAddrs, error)
+
+	// memo caches API reads for the current command
+	memo commandMemo
+
+	// ctx is cancelled to stop the command (see Options.Context)
+	ctx context.Context
 }
 
 // NewApp creates a new application instance
-func NewApp() (*App, error) {
+func NewApp(opts Options) (*App, error) {
+	// Environment files are applied before config resolution
+	if opts.EnvFile != "" {
+		if err := config.LoadEnvFile(opts.EnvFile); err != nil {
+			return nil, fmt.Errorf("loading env file: %w", err)
+		}
+	} else {
+		ignored, err := config.LoadDefaultEnvFile()
+		if err != nil {
+			return nil, fmt.Errorf("loading env file: %w", err)
+		}
+		for _, key := range ignored {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring %s in %s; set it in the environment or load the file with --env-file\n", key, config.DefaultEnvFile)
+		}
+	}
+
+	// Read-only mode must be set before Load, which may migrate the file
+	if opts.ReadOnly {
+		config.SetReadOnly(true)
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		return nil, fmt.Errorf("loading config: %w", err)
 	}
+	cfg.ApplyEnv()
+	// --network wins over both, for this run only: updates reload the
+	// config from disk, so the override is never saved
+	if opts.Network != "" {
+		cfg.NetworkID = opts.Network
+	}
+	// --keyring turns the keychain on; the next save persists the choice
+	if opts.Keyring {
+		cfg.UseKeyring = true
+	}
 
-	client := api.New(cfg.Token)
+	co, err := clientOptions(cfg.Client, opts)
+	if err != nil {
+		return nil, err
+	}
+	if co.BaseURL != "" && co.BaseURL != api.DefaultBaseURL {
+		fmt.Fprintf(os.Stderr, "Warning: sending API requests, including the session token, to %s\n", co.BaseURL)
+	}
+	client := api.NewWithOptions(cfg.Token, co)
+	if opts.Debug {
+		client.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	}
+	if opts.Context != nil {
+		client = client.WithContext(opts.Context)
+	}
 
 	return &App{
-		Config: cfg,
-		Client: client,
+		Config:     cfg,
+		Client:     client,
+		Color:      resolveColor(opts.Color),
+		Output:     opts.Output,
+		Envelope:   opts.Envelope,
+		Compact:    opts.Compact,
+		OnlyFields: opts.OnlyFields,
+		DiskCache:  !config.ReadOnly(),
+		TrackIPs:   !config.ReadOnly(),
+		StaleOK:    opts.StaleOK,
+		Quiet:      opts.Quiet,
+		LocalAddrs: detectLocalAddrs,
+		ctx:        opts.Context,
 	}, nil
 }
 
+// context returns the command's context, which Ctrl+C cancels
+func (a *App) context() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+	return a.ctx
+}
+
+// clientFor returns a client whose requests use ctx instead of the
+// command's context, e.g. to tie them to an incoming HTTP request. Clients
+// that can't rebind, such as test mocks, are returned as is.
+func (a *App) clientFor(ctx context.Context) api.EeroAPI {
+	if c, ok := a.Client.(*api.Client); ok {
+		return c.WithContext(ctx)
+	}
+	return a.Client
+}
+
 // EnsureAuth checks that the user is authenticated
 func (a *App) EnsureAuth() error {
 	if !a.Config.HasToken() {
-		return fmt.Errorf("not logged in. Run 'eero-cli login' first")
+		return authError("not logged in. Run 'eero-cli login' first")
 	}
 
-	if !a.Client.ValidateToken() {
-		return fmt.Errorf("token is invalid or expired. Run 'eero-cli login' to re-authenticate")
+	// With --stale-ok, let the fetch itself decide between live and cached data
+	if !a.Client.ValidateToken() && !a.StaleOK {
+		return authError("token is invalid or expired. Run 'eero-cli login' to re-authenticate")
 	}
 
 	return nil
@@ -66,38 +185,165 @@ func (a *App) EnsureNetwork() (string, error) {
 	}
 
 	// Use first network, extract ID from URL
-	networkID := api.ExtractNetworkID(account.Networks.Data[0].URL)
-	a.Config.NetworkID = networkID
-	if err := a.Config.Save(); err != nil {
+	network := account.Networks.Data[0]
+	networkID := api.ExtractNetworkID(network.URL)
+	// In read-only mode the choice lasts for this command only
+	if err := a.saveNetworkID(networkID); errors.Is(err, config.ErrReadOnly) {
+		a.Config.NetworkID = networkID
+	} else if err != nil {
 		return "", fmt.Errorf("saving config: %w", err)
 	}
 
+	// Operating on the wrong network is easy to miss with several to choose from
+	if n := len(account.Networks.Data); n > 1 && !a.Quiet {
+		fmt.Fprintf(os.Stderr, "Using network '%s' (%s); you have %d networks — set one with 'eero-cli networks use'.\n", network.Name, networkID, n)
+	}
+
 	return networkID, nil
 }
 
-// Prompt reads a line of input from the user
-func Prompt(message string) string {
-	fmt.Print(message)
-	reader := bufio.NewReader(os.Stdin)
-	input, _ := reader.ReadString('\n')
-	return strings.TrimSpace(input)
+// saveNetworkID persists the network ID with a locked read-modify-write of
+// the config file, so concurrent eero-cli processes don't clobber each other
+func (a *App) saveNetworkID(networkID string) error {
+	return a.updateConfig(func(c *config.Config) {
+		c.NetworkID = networkID
+	})
 }
 
-// PromptSecret reads a line of input without echo (for sensitive data)
-func PromptSecret(message string) string {
+// updateConfig applies fn to both the config on disk, under the config lock,
+// and the in-memory config
+func (a *App) updateConfig(fn func(c *config.Config)) error {
+	unlock, err := config.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := config.Load()
+	if err != nil {
+		return err
+	}
+	current.UseKeyring = current.UseKeyring || a.Config.UseKeyring
+	fn(current)
+	if err := current.Save(); err != nil {
+		return err
+	}
+
+	fn(a.Config)
+	return nil
+}
+
+// Prompt reads a line of input from the user. It returns "" if ctx is
+// cancelled first, such as by Ctrl+C.
+func Prompt(ctx context.Context, message string) string {
+	return promptTo(ctx, os.Stdout, message)
+}
+
+// promptTo writes message to w and reads a line of input from the user
+func promptTo(ctx context.Context, w io.Writer, message string) string {
+	fmt.Fprint(w, message)
+	return readLine(ctx)
+}
+
+// stdinReader buffers os.Stdin across prompts, so piped answers to several
+// prompts aren't swallowed by the first prompt's buffer. It is recreated
+// when os.Stdin changes.
+var (
+	stdinReader *bufio.Reader
+	stdinSource *os.File
+)
+
+// readLine reads a trimmed line from stdin, or returns "" once ctx is
+// cancelled. An abandoned read is left to finish on its own; the command
+// is stopping, so nothing reads stdin after it.
+func readLine(ctx context.Context) string {
+	if ctx.Err() != nil {
+		return ""
+	}
+	if stdinReader == nil || stdinSource != os.Stdin {
+		stdinReader = bufio.NewReader(os.Stdin)
+		stdinSource = os.Stdin
+	}
+
+	lines := make(chan string, 1)
+	go func(r *bufio.Reader) {
+		input, _ := r.ReadString('\n')
+		lines <- strings.TrimSpace(input)
+	}(stdinReader)
+
+	select {
+	case line := <-lines:
+		return line
+	case <-ctx.Done():
+		return ""
+	}
+}
+
+// PromptSecret reads a line of input without echo (for sensitive data).
+// When stdin is not a terminal, such as piped input, it reads the line as
+// Prompt does.
+func PromptSecret(ctx context.Context, message string) string {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return Prompt(ctx, message)
+	}
+	if ctx.Err() != nil {
+		return ""
+	}
+
+	// ReadPassword turns echo back on when it returns, which an abandoned
+	// read never does
+	state, err := term.GetState(fd)
+	if err != nil {
+		return ""
+	}
 	fmt.Print(message)
-	reader := bufio.NewReader(os.Stdin)
-	input, _ := reader.ReadString('\n')
-	return strings.TrimSpace(input)
+	type result struct {
+		input []byte
+		err   error
+	}
+	results := make(chan result, 1)
+	go func() {
+		input, err := term.ReadPassword(fd)
+		results <- result{input, err}
+	}()
+
+	var r result
+	select {
+	case r = <-results:
+	case <-ctx.Done():
+		term.Restore(fd, state)
+		r.err = ctx.Err()
+	}
+	// The user's Enter wasn't echoed either
+	fmt.Println()
+	if r.err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(r.input))
+}
+
+// Confirm asks for a yes/no confirmation. Cancelling ctx answers no.
+func Confirm(ctx context.Context, message string) bool {
+	return confirmed(Prompt(ctx, message+" [y/N]: "))
+}
+
+// confirm is Confirm, asking on stderr in JSON and YAML modes so stdout
+// holds only the result. Ctrl+C answers no.
+func (a *App) confirm(message string) bool {
+	if a.structuredOutput() {
+		return confirmed(promptTo(a.context(), os.Stderr, message+" [y/N]: "))
+	}
+	return Confirm(a.context(), message)
 }
 
-// Confirm asks for a yes/no confirmation
-func Confirm(message string) bool {
-	response := Prompt(message + " [y/N]: ")
+// confirmed reports whether a confirmation response means yes
+func confirmed(response string) bool {
 	return strings.ToLower(response) == "y" || strings.ToLower(response) == "yes"
 }
 
-// PrintTable prints data in a simple table format
+// PrintTable prints data in a simple table format. Cells may contain ANSI
+// color codes; they don't count toward column widths.
 func PrintTable(headers []string, rows [][]string) {
 	if len(rows) == 0 {
 		fmt.Println("No data to display")
@@ -107,19 +353,19 @@ func PrintTable(headers []string, rows [][]string) {
 	// Calculate column widths
 	widths := make([]int, len(headers))
 	for i, h := range headers {
-		widths[i] = len(h)
+		widths[i] = visibleLen(h)
 	}
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if i < len(widths) && visibleLen(cell) > widths[i] {
+				widths[i] = visibleLen(cell)
 			}
 		}
 	}
 
 	// Print headers
 	for i, h := range headers {
-		fmt.Printf("%-*s  ", widths[i], h)
+		fmt.Print(padCell(h, widths[i]))
 	}
 	fmt.Println()
 
@@ -133,13 +379,18 @@ func PrintTable(headers []string, rows [][]string) {
 	for _, row := range rows {
 		for i, cell := range row {
 			if i < len(widths) {
-				fmt.Printf("%-*s  ", widths[i], cell)
+				fmt.Print(padCell(cell, widths[i]))
 			}
 		}
 		fmt.Println()
 	}
 }
 
+// padCell left-aligns a cell to width visible characters plus the column gap
+func padCell(cell string, width int) string {
+	return cell + strings.Repeat(" ", width-visibleLen(cell)) + "  "
+}
+
 // Usage prints the help message
 func Usage() {
 	fmt.Println(`eero-cli - Control your Eero WiFi network
@@ -149,50 +400,191 @@ Usage:
 
 Commands:
   login                     Authenticate with your Eero account
+    --identity <email|phone>  Use this login instead of prompting for it
+    --code <code>             Use this verification code instead of prompting
   logout                    Clear saved authentication
   status                    Show current authentication status
+  account [list]            List saved accounts (* marks the one in use)
+  account use <name>        Switch to another saved account
+  account add <name>        Log in to another account and switch to it (takes
+                            login's --identity and --code)
+  dashboard                 Summarize eeros, devices, guest network, and firmware
+                            (supports -o json)
+
+  networks                  List the account's networks (* marks the one in use);
+                            'network' also works
+  networks use <id|name>    Choose the network commands operate on
 
   devices [options]           List all devices
-    --profile <name|id>       Filter by profile name or ID
+    --profile <name|id,...>   Filter by profile name or ID (comma list matches any)
     --noprofile               Show only devices without a profile
     --wired                   Show only wired devices
     --wireless                Show only wireless devices
     --online                  Show only online devices
     --offline                 Show only offline devices
-    --paused                  Show only paused devices
+    --paused                  Show only paused devices (also: devices paused)
+    --blocked                 Show only blocked devices (also: devices blocked)
     --private                 Show only private (hidden MAC) devices
     --guest                   Show only guest network devices
     --noguest                 Exclude guest network devices
-  devices monitor [--interval <sec>]  Monitor devices for state changes
+    --type <type>             Show only devices of this type (e.g. laptop)
+    --grep <term>             Show only devices whose name, hostname, MAC, or IP
+                              contains term (case-insensitive)
+    --page-size <n>           Fetch devices from the API in pages of n
+    --dedupe[=name|hostname|mac]  Collapse devices sharing a key (default: name)
+    --format <field,...>      Show only these columns, in this order: id, name,
+                              ip, mac, status, type, private, profile, hostname,
+                              nickname, device_type, last_active, node
+    --sort <field>[:desc]     Sort by name (default), ip, status, type, profile,
+                              or mac
+  devices monitor [--interval <dur>]  Monitor devices for state changes (e.g. 30, 1m30s)
+    --only <id|mac|name>      Monitor only this device (repeatable)
+    --webhook <url>           POST each change to this URL as a JSON event
+                              (with --output json, changes print as JSON lines)
   devices inspect <id>        Show full device state as JSON
-  devices pause <id>          Pause a device's internet access
+  devices whois <ip|mac>      Show which device has an IP or MAC address
+  devices locate <mac>        Find which of your networks (and eero) a device is on
+  devices top [--limit <n>]   Show the devices using the most bandwidth (default 10)
+  devices churn [--since <window>]  Show devices whose IP changed recently
+                              (default 7d; e.g. 30d, 12h)
+  devices snapshot [--out <file>]  Save the current device list as JSON
+  devices diff <file>         Show devices added, removed, or changed since a snapshot
+  devices adopt --profile <name|id> [--type <type>] [--yes]
+                              Add all unprofiled devices to a profile
+  devices alias [list]        List device aliases
+  devices alias set <alias> <device>  Name a device; aliases work anywhere a device is expected
+  devices alias remove <alias>  Remove a device alias
+  devices pause <id> [--for <duration>]  Pause a device's internet access; with
+                              --for, wait in the foreground and then unpause it
   devices unpause <id>        Unpause a device
-  devices block <id>          Block a device from the network
+  devices block <id> [--until <time>]  Block a device from the network; with
+                              --until (07:00, 2h, or RFC 3339), unblock it on the
+                              first command run after that time
   devices unblock <id>        Unblock a device
+    --yes-really              Pause or block this machine anyway
+    --no-self-check           Skip detecting whether the device is this machine
   devices rename <id> <name>  Set a device's nickname
+  devices rename --from-file <path>  Set nicknames from a mac,nickname CSV or TSV
+                              file ("-" for stdin)
+  devices unprofile <id>      Remove a device from its profile, whichever it is
+  devices move <id> <profile> Move a device to a profile, out of its current one
+  devices set <id> [--nickname <name>] [--paused true|false] [--blocked true|false]
+                              Change several device settings in one update
 
   profiles                    List all profiles
+  profiles show <id>          Show a profile's status, schedules, and filters
   profiles inspect <id>       Show full profile state as JSON
-  profiles pause <id>         Pause a profile
+  profiles devices <id>       List a profile's devices (supports -o json/csv)
+  profiles pause <id> [--for <duration>]  Pause a profile (--for as for devices)
   profiles unpause <id>       Unpause a profile
+  profiles pause-all          Pause every profile (e.g. at bedtime)
+  profiles resume-all         Unpause every profile
+  profiles filter <id> <adult|illegal|violent|safesearch> on|off
+                              Turn a content filter on or off
   profiles add <profile> <device>     Add device to profile
   profiles remove <profile> <device>  Remove device from profile
+    --verify                  After add or remove, re-read the profile and list
+                              its devices
 
-  eeros                       List all eero mesh nodes
+  eeros [--wide]              List all eero mesh nodes with an A–F health grade
+                              (--wide adds serial, OS, uptime)
+    --sort <field>[:desc]     Sort by location (default), clients, or signal
+  eeros topology              Show the mesh as a tree from the gateway (supports -o json)
   eeros inspect <id>          Show full eero state as JSON
+  eeros inspect --all         Show every eero's state as a JSON array
   eeros reboot <id>           Reboot a single eero node
 
-  guest                     Show guest network status
+  guest [--reveal]          Show guest network status (password masked unless --reveal)
   guest enable              Enable guest network
   guest disable             Disable guest network
-  guest password <pass>     Set guest network password
+  guest password [<pass>]   Set guest network password (prompts if omitted)
+  guest rename <name>       Rename the guest network (1-32 bytes)
+  guest clients             List devices on the guest network
+  guest setup --name <name> --password <pass>  Configure and enable the guest network
 
-  reservations                          List all DHCP reservations
+  reservations [--with-status]          List all DHCP reservations
   reservations add <mac> <ip> [desc]    Create a DHCP reservation
-  reservations remove <id|mac|ip>       Delete a DHCP reservation
+  reservations add --from-device <device> [--desc <text>]
+                                        Reserve a device's current IP
+  reservations remove [--yes] <id|mac|ip>...  Delete one or more DHCP reservations
   reservations inspect <id|mac|ip>      Show full reservation JSON
+  reservations backup <file>            Save all reservations to a JSON file
+  reservations restore <file> [--prune] [--yes]
+                                        Create/update reservations to match a backup
+                                        (--prune also deletes ones not in it)
 
-  reboot                    Reboot the network
+  forwards                  List port forwarding rules
+  forwards add <ip> <ext>:<int>/<tcp|udp> [desc]
+                            Forward an external port to a device
+  forwards remove <id|desc> Delete a port forwarding rule
 
-  help                      Show this help message`)
+  internet [status]         Show whether internet access is paused
+  internet pause            Pause internet access for the whole network
+  internet resume           Resume internet access
+
+  dhcp [show]               Show the LAN subnet and DHCP range
+  dhcp set <subnet> <start> <end> [--yes]  Change the DHCP range
+
+  radio [show]              Show radio settings (band steering, legacy mode)
+  radio set <band-steering|legacy> <on|off>  Change a radio setting
+
+  wifi qr [--png <path>]    Show a QR code that joins the main WiFi network
+                            (prompts for the password if the API omits it)
+
+  reboot                    Reboot the network
+  reboot --eero <id|location|serial>  Reboot a single eero node
+
+  update [status]           Show firmware versions and whether an update is available
+  update apply [--yes]      Install the available firmware update
+
+  speedtest                 Run a speed test on the gateway and show the result
+  speedtest last            Show the most recent speed test result
+
+  export [--listen <addr>]  Print device and eero metrics for Prometheus; with
+                            --listen, serve them on /metrics instead
+
+  completion <bash|zsh|fish>  Print a shell completion script
+
+  help                      Show this help message
+
+Global options:
+  --color <always|auto|never>  Colorize output (auto = only when writing to a terminal)
+  --no-color                   Same as --color never; NO_COLOR=1 also disables
+                               color unless --color always is given
+  --network <id>               Use this network for one command, without saving it
+  --env-file <path>            Load KEY=VALUE environment variables from a file
+                               (defaults to .eero.env in the current directory)
+  -o, --output <format>        Output format: table, json, yaml, or csv (supported by
+                               dashboard, devices, devices churn, devices diff,
+                               devices top, eeros, profiles, profiles devices,
+                               reservations; login, logout, reboot, status, and
+                               update support json and yaml; devices also supports
+                               hosts, for /etc/hosts lines)
+  --json                       Shorthand for --output json
+  --compact                    Print JSON on one line (also inspect commands)
+  --pretty                     Print indented JSON (default)
+  --envelope                   Wrap JSON output in {"meta": {...}, "data": ...}
+  --only-fields <a,b,...>      Limit JSON output to these fields (e.g. mac,ip,nickname)
+  --timeout <duration>         Per-request timeout (e.g. 10s; default 30s)
+  --retries <n>                Retry transient failures n times (default 2, 0 disables)
+  --pin <sha256>               Require the API server's public key to match this hash
+  --api-url <url>              Send API requests to this URL instead of eero's, e.g.
+                               a debugging proxy (also EERO_API_URL); https only,
+                               except for localhost
+  --stale-ok                   Show the last cached data if the API is unreachable
+                               (devices and eeros listings only)
+  --debug                      Log API requests to stderr
+  --read-only                  Never write to the config directory (also
+                               EERO_READ_ONLY=1); commands that must save fail
+  --keyring                    Keep the token in the OS keychain from the next
+                               save on (sets use_keyring in the config)
+  --stats                      Summarize API requests and time per path on stderr
+  -q, --quiet                  Suppress informational notices on stderr
+
+Exit codes:
+  0  Success
+  1  Usage or other error
+  2  Not logged in, or the token is invalid or expired
+  3  Device, profile, eero, or other item not found
+  4  API error, or the API could not be reached`)
 }
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+// parseUntil parses a --until value relative to now: a clock time like
+// "07:00" (the next occurrence), a duration like "2h", or an RFC 3339
+// timestamp. The result must be in the future.
+func parseUntil(s string, now time.Time) (time.Time, error) {
+	var t time.Time
+	if clock, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		t = time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+	} else if d, err := time.ParseDuration(s); err == nil {
+		t = now.Add(d)
+	} else if ts, err := time.Parse(time.RFC3339, s); err == nil {
+		t = ts
+	} else {
+		return time.Time{}, fmt.Errorf("invalid --until value: %s (e.g. 07:00, 2h, or 2024-01-02T07:00:00Z)", s)
+	}
+
+	if !t.After(now) {
+		return time.Time{}, fmt.Errorf("invalid --until value: %s is not in the future", s)
+	}
+	return t, nil
+}
+
+// parseFor parses a --for duration, which must be positive
+func parseFor(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --for value: %s (e.g. 30m, 1h30m)", s)
+	}
+	return d, nil
+}
+
+// pauseWait waits for d, returning ctx's error if it is cancelled first.
+// Tests replace it to avoid sleeping.
+var pauseWait = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pauseFor pauses something, waits in the foreground for d, then unpauses
+// it. Ctrl+C stops waiting and leaves it paused. what names it in messages
+// and unpauseCmd is the command to unpause it by hand.
+func (a *App) pauseFor(what, unpauseCmd string, d time.Duration, setPaused func(pause bool) error) error {
+	if err := setPaused(true); err != nil {
+		return err
+	}
+
+	resume := time.Now().Add(d)
+	fmt.Printf("Unpausing %s at %s. Keep this running, or press Ctrl+C to leave it paused.\n",
+		what, resume.Local().Format("15:04:05"))
+
+	if err := pauseWait(a.context(), d); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: stopped waiting; %s is still paused. Run 'eero-cli %s' to unpause it.\n", what, unpauseCmd)
+		return nil
+	}
+
+	return setPaused(false)
+}
+
+// BlockDeviceUntil blocks a device now and schedules it to be unblocked by
+// the first command run after until
+func (a *App) BlockDeviceUntil(deviceQuery string, until time.Time) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	d, err := a.findDevice(networkID, deviceQuery)
+	if err != nil {
+		return err
+	}
+	deviceID := api.ExtractDeviceID(d.URL)
+
+	if err := a.Client.BlockDevice(networkID, deviceID, true); err != nil {
+		return fmt.Errorf("updating device: %w", err)
+	}
+
+	entry := config.ScheduledUnblock{NetworkID: networkID, DeviceID: deviceID, Name: d.DisplayName(), At: until, Account: a.Config.ActiveAccount()}
+	err = a.updateConfig(func(c *config.Config) {
+		c.ScheduledUnblocks = append(withoutUnblock(c.ScheduledUnblocks, networkID, deviceID), entry)
+	})
+	if err != nil {
+		return fmt.Errorf("device %s is blocked, but saving the scheduled unblock failed: %w", deviceID, err)
+	}
+
+	fmt.Printf("Device %s has been blocked until %s\n", deviceID, until.Local().Format("Mon Jan 2 15:04"))
+	fmt.Println("It is unblocked by the first eero-cli command run after that time.")
+	return nil
+}
+
+// cancelScheduledUnblock drops any pending unblock for a device, e.g. when it
+// is unblocked by hand or blocked indefinitely
+func (a *App) cancelScheduledUnblock(networkID, deviceID string) error {
+	if !slices.ContainsFunc(a.Config.ScheduledUnblocks, func(u config.ScheduledUnblock) bool {
+		return u.NetworkID == networkID && u.DeviceID == deviceID
+	}) {
+		return nil
+	}
+	return a.updateConfig(func(c *config.Config) {
+		c.ScheduledUnblocks = withoutUnblock(c.ScheduledUnblocks, networkID, deviceID)
+	})
+}
+
+// withoutUnblock returns entries without those for the given device
+func withoutUnblock(entries []config.ScheduledUnblock, networkID, deviceID string) []config.ScheduledUnblock {
+	return slices.DeleteFunc(slices.Clone(entries), func(u config.ScheduledUnblock) bool {
+		return u.NetworkID == networkID && u.DeviceID == deviceID
+	})
+}
+
+// ProcessScheduledUnblocks unblocks devices whose temporary block expired
+// before now. Only the active account's entries are processed, since other
+// accounts' devices can't be reached with its token; they wait until that
+// account is used again. Entries that fail are kept and retried by the next
+// command; problems are reported on stderr rather than failing the command.
+func (a *App) ProcessScheduledUnblocks(now time.Time) {
+	// Done entries couldn't be removed in read-only mode, so they'd be
+	// unblocked again on every run
+	if len(a.Config.ScheduledUnblocks) == 0 || !a.Config.HasToken() || config.ReadOnly() {
+		return
+	}
+
+	// Each device has at most one entry, so network and device identify it
+	done := make(map[[2]string]bool)
+	for _, u := range a.Config.ScheduledUnblocks {
+		if u.At.After(now) || u.AccountName() != a.Config.ActiveAccount() {
+			continue
+		}
+		err := a.Client.BlockDevice(u.NetworkID, u.DeviceID, false)
+		if err != nil && !api.IsNotFound(err) {
+			fmt.Fprintf(os.Stderr, "Warning: couldn't unblock %s after its block expired: %v\n", u.Name, err)
+			continue
+		}
+		done[[2]string{u.NetworkID, u.DeviceID}] = true
+		if !a.Quiet {
+			fmt.Fprintf(os.Stderr, "Unblocked %s (block expired %s)\n", u.Name, u.At.Local().Format("Mon Jan 2 15:04"))
+		}
+	}
+	if len(done) == 0 {
+		return
+	}
+
+	err := a.updateConfig(func(c *config.Config) {
+		c.ScheduledUnblocks = slices.DeleteFunc(slices.Clone(c.ScheduledUnblocks), func(u config.ScheduledUnblock) bool {
+			return done[[2]string{u.NetworkID, u.DeviceID}]
+		})
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: couldn't save scheduled unblocks: %v\n", err)
+	}
+}
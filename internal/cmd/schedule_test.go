@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/config"
+)
+
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2024, 3, 10, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"07:00", time.Date(2024, 3, 11, 7, 0, 0, 0, time.UTC)},   // tomorrow morning
+		{"23:15", time.Date(2024, 3, 10, 23, 15, 0, 0, time.UTC)}, // later today
+		{"2h", now.Add(2 * time.Hour)},
+		{"2024-03-12T08:00:00Z", time.Date(2024, 3, 12, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseUntil(tt.in, now)
+		if err != nil {
+			t.Errorf("parseUntil(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseUntil(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"soon", "25:00", "-1h", "2024-03-01T08:00:00Z"} {
+		if _, err := parseUntil(in, now); err == nil {
+			t.Errorf("parseUntil(%q) expected error", in)
+		}
+	}
+}
+
+func TestProcessScheduledUnblocks(t *testing.T) {
+	useTempConfigDir(t)
+	now := time.Date(2024, 3, 11, 7, 5, 0, 0, time.UTC)
+
+	var unblocked []string
+	mock := &mockClient{
+		BlockDeviceFn: func(networkID, deviceID string, block bool) error {
+			if block {
+				t.Errorf("unexpected block of %s", deviceID)
+			}
+			if deviceID == "failing" {
+				return &api.StatusError{StatusCode: 500, Message: "boom"}
+			}
+			unblocked = append(unblocked, deviceID)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Config.Token = "tok"
+
+	entries := []config.ScheduledUnblock{
+		{NetworkID: "12345", DeviceID: "expired", Name: "Tablet", At: now.Add(-5 * time.Minute)},
+		{NetworkID: "12345", DeviceID: "failing", Name: "Console", At: now.Add(-time.Hour)},
+		{NetworkID: "12345", DeviceID: "pending", Name: "TV", At: now.Add(time.Hour)},
+	}
+	app.Config.ScheduledUnblocks = entries
+	if err := app.Config.Save(); err != nil {
+		t.Fatalf("saving config: %v", err)
+	}
+
+	stderr := captureStderr(t, func() {
+		app.ProcessScheduledUnblocks(now)
+	})
+
+	if len(unblocked) != 1 || unblocked[0] != "expired" {
+		t.Errorf("unblocked = %v, want [expired]", unblocked)
+	}
+	if !strings.Contains(stderr, "Unblocked Tablet") || !strings.Contains(stderr, "couldn't unblock Console") {
+		t.Errorf("unexpected stderr:\n%s", stderr)
+	}
+
+	// The failed entry is kept for a retry; the future one is untouched
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	for name, got := range map[string][]config.ScheduledUnblock{"saved": saved.ScheduledUnblocks, "in memory": app.Config.ScheduledUnblocks} {
+		var ids []string
+		for _, u := range got {
+			ids = append(ids, u.DeviceID)
+		}
+		if strings.Join(ids, ",") != "failing,pending" {
+			t.Errorf("%s entries = %v, want [failing pending]", name, ids)
+		}
+	}
+}
+
+func TestProcessScheduledUnblocksActiveAccountOnly(t *testing.T) {
+	useTempConfigDir(t)
+	now := time.Date(2024, 3, 11, 7, 5, 0, 0, time.UTC)
+
+	var unblocked []string
+	mock := &mockClient{
+		BlockDeviceFn: func(networkID, deviceID string, block bool) error {
+			unblocked = append(unblocked, deviceID)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Config.Active = "family"
+	app.Config.ScheduledUnblocks = []config.ScheduledUnblock{
+		// From before accounts existed, so the default account's
+		{NetworkID: "111", DeviceID: "home-tv", Name: "TV", At: now.Add(-time.Hour)},
+		{NetworkID: "222", DeviceID: "family-tablet", Name: "Tablet", At: now.Add(-time.Hour), Account: "family"},
+	}
+	if err := app.Config.Save(); err != nil {
+		t.Fatalf("saving config: %v", err)
+	}
+
+	captureStderr(t, func() {
+		app.ProcessScheduledUnblocks(now)
+	})
+
+	if len(unblocked) != 1 || unblocked[0] != "family-tablet" {
+		t.Errorf("unblocked = %v, want only the family account's device", unblocked)
+	}
+	// The default account's entry waits until that account is used
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if len(saved.ScheduledUnblocks) != 1 || saved.ScheduledUnblocks[0].DeviceID != "home-tv" {
+		t.Errorf("saved entries = %+v, want [home-tv]", saved.ScheduledUnblocks)
+	}
+}
+
+func TestBlockDeviceUntilSchedulesUnblock(t *testing.T) {
+	useTempConfigDir(t)
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	var blocks []bool
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		BlockDeviceFn: func(networkID, deviceID string, block bool) error {
+			blocks = append(blocks, block)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	captureStdout(t, func() {
+		if err := app.BlockDeviceUntil("NAS", until); err != nil {
+			t.Fatalf("BlockDeviceUntil error: %v", err)
+		}
+	})
+
+	saved, _ := config.Load()
+	if len(saved.ScheduledUnblocks) != 1 {
+		t.Fatalf("scheduled = %+v, want 1 entry", saved.ScheduledUnblocks)
+	}
+	u := saved.ScheduledUnblocks[0]
+	if u.DeviceID != "112233445566" || u.Name != "NAS" || !u.At.Equal(until) || u.Account != config.DefaultAccount {
+		t.Errorf("entry = %+v", u)
+	}
+
+	// Unblocking by hand cancels the scheduled unblock
+	captureStdout(t, func() {
+		if err := app.BlockDevice("NAS", false); err != nil {
+			t.Fatalf("BlockDevice error: %v", err)
+		}
+	})
+	saved, _ = config.Load()
+	if len(saved.ScheduledUnblocks) != 0 || len(app.Config.ScheduledUnblocks) != 0 {
+		t.Errorf("scheduled unblock not cancelled: %+v", saved.ScheduledUnblocks)
+	}
+	if len(blocks) != 2 || !blocks[0] || blocks[1] {
+		t.Errorf("block calls = %v, want [true false]", blocks)
+	}
+}
+
+// fakePauseWait replaces pauseWait for a test with one that returns err at
+// once, recording the requested durations
+func fakePauseWait(t *testing.T, err error) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	old := pauseWait
+	pauseWait = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return err
+	}
+	t.Cleanup(func() { pauseWait = old })
+	return &waits
+}
+
+func TestDevicePauseFor(t *testing.T) {
+	waits := fakePauseWait(t, nil)
+
+	var calls []bool
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		PauseDeviceFn: func(networkID, deviceID string, pause bool) error {
+			calls = append(calls, pause)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"pause", "NAS", "--for", "30m"}); err != nil {
+			t.Fatalf("Devices pause error: %v", err)
+		}
+	})
+
+	if !reflect.DeepEqual(calls, []bool{true, false}) {
+		t.Errorf("pause calls = %v, want pause then unpause", calls)
+	}
+	if !reflect.DeepEqual(*waits, []time.Duration{30 * time.Minute}) {
+		t.Errorf("waits = %v, want [30m]", *waits)
+	}
+	for _, want := range []string{"Unpausing device NAS at", "has been unpaused"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDevicePauseForInterrupted(t *testing.T) {
+	fakePauseWait(t, context.Canceled)
+
+	var calls []bool
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		PauseDeviceFn: func(networkID, deviceID string, pause bool) error {
+			calls = append(calls, pause)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var stderr string
+	captureStdout(t, func() {
+		stderr = captureStderr(t, func() {
+			if err := app.Devices([]string{"pause", "NAS", "--for=1h"}); err != nil {
+				t.Fatalf("Devices pause error: %v", err)
+			}
+		})
+	})
+
+	if !reflect.DeepEqual(calls, []bool{true}) {
+		t.Errorf("pause calls = %v, want only the pause", calls)
+	}
+	if !strings.Contains(stderr, "device NAS is still paused") || !strings.Contains(stderr, "devices unpause NAS") {
+		t.Errorf("stderr = %q, want a still-paused warning", stderr)
+	}
+}
+
+func TestProfilePauseFor(t *testing.T) {
+	fakePauseWait(t, nil)
+
+	var calls []bool
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return testProfiles(), nil
+		},
+		PauseProfileFn: func(networkID, profileID string, pause bool) error {
+			calls = append(calls, pause)
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+
+	captureStdout(t, func() {
+		if err := app.Profiles([]string{"pause", "Adults", "--for", "45m"}); err != nil {
+			t.Fatalf("Profiles pause error: %v", err)
+		}
+	})
+
+	if !reflect.DeepEqual(calls, []bool{true, false}) {
+		t.Errorf("pause calls = %v, want pause then unpause", calls)
+	}
+}
+
+func TestParseForRejectsInvalid(t *testing.T) {
+	for _, in := range []string{"", "soon", "0s", "-5m", "30"} {
+		if _, err := parseFor(in); err == nil {
+			t.Errorf("parseFor(%q) should fail", in)
+		}
+	}
+}
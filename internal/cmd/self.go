@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// localAddrs holds the IP and MAC addresses of this machine's interfaces
+type localAddrs struct {
+	IPs  map[string]bool
+	MACs map[string]bool // normalized with normalizeMAC
+}
+
+// detectLocalAddrs collects addresses from every up, non-loopback interface
+func detectLocalAddrs() (localAddrs, error) {
+	local := localAddrs{IPs: make(map[string]bool), MACs: make(map[string]bool)}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return local, fmt.Errorf("listing interfaces: %w", err)
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		if len(iface.HardwareAddr) > 0 {
+			local.MACs[normalizeMAC(iface.HardwareAddr.String())] = true
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				local.IPs[ipNet.IP.String()] = true
+			}
+		}
+	}
+
+	return local, nil
+}
+
+// match reports whether d is this machine, returning the address that matched
+func (l localAddrs) match(d api.Device) (string, bool) {
+	if d.MAC != "" && l.MACs[normalizeMAC(d.MAC)] {
+		return d.MAC, true
+	}
+	if d.IP != "" && l.IPs[d.IP] {
+		return d.IP, true
+	}
+	return "", false
+}
+
+// checkSelfDevice refuses to pause or block the device this CLI is running
+// on unless --yes-really is given. Detection is best-effort: any failure
+// lets the command proceed.
+func (a *App) checkSelfDevice(deviceQuery, action string, opts selfCheckOptions) error {
+	if a.LocalAddrs == nil || opts.NoSelfCheck || opts.YesReally {
+		return nil
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return nil
+	}
+	d, err := a.findDevice(networkID, deviceQuery)
+	if err != nil {
+		return nil
+	}
+	local, err := a.LocalAddrs()
+	if err != nil {
+		return nil
+	}
+
+	if addr, ok := local.match(*d); ok {
+		return fmt.Errorf("%s (%s) appears to be this machine; %s it may cut your own connection (use --yes-really to proceed)", d.DisplayName(), addr, action)
+	}
+	return nil
+}
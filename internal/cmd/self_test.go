@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func fakeLocalAddrs() (localAddrs, error) {
+	return localAddrs{
+		IPs:  map[string]bool{"192.168.1.100": true},
+		MACs: map[string]bool{normalizeMAC("11-22-33-44-55-66"): true},
+	}, nil
+}
+
+func TestLocalAddrsMatch(t *testing.T) {
+	local, _ := fakeLocalAddrs()
+
+	tests := []struct {
+		name string
+		d    api.Device
+		want bool
+	}{
+		{"ip", api.Device{IP: "192.168.1.100", MAC: "AA:BB:CC:DD:11:22"}, true},
+		{"mac", api.Device{IP: "192.168.1.10", MAC: "11:22:33:44:55:66"}, true},
+		{"neither", api.Device{IP: "192.168.1.101", MAC: "EE:FF:00:11:22:33"}, false},
+		{"empty", api.Device{}, false},
+	}
+
+	for _, tt := range tests {
+		if _, got := local.match(tt.d); got != tt.want {
+			t.Errorf("%s: match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPauseSelfRequiresYesReally(t *testing.T) {
+	var paused bool
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		PauseDeviceFn: func(networkID, deviceID string, pause bool) error {
+			paused = true
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+	app.LocalAddrs = fakeLocalAddrs
+
+	err := app.Devices([]string{"pause", "My Laptop"})
+	if err == nil || !strings.Contains(err.Error(), "--yes-really") {
+		t.Fatalf("expected --yes-really error, got: %v", err)
+	}
+	if paused {
+		t.Fatal("device should not be paused without --yes-really")
+	}
+
+	captureStdout(t, func() {
+		if err := app.Devices([]string{"pause", "My Laptop", "--yes-really"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !paused {
+		t.Error("device should be paused with --yes-really")
+	}
+}
+
+func TestBlockOtherDeviceSkipsConfirmation(t *testing.T) {
+	var blocked bool
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+		BlockDeviceFn: func(networkID, deviceID string, block bool) error {
+			blocked = true
+			return nil
+		},
+	}
+	app := newTestApp(mock)
+	app.LocalAddrs = fakeLocalAddrs
+
+	captureStdout(t, func() {
+		if err := app.Devices([]string{"block", "phone"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !blocked {
+		t.Error("a device other than this machine should be blocked without confirmation")
+	}
+
+	// NAS matches the local MAC, but the check can be skipped
+	blocked = false
+	captureStdout(t, func() {
+		if err := app.Devices([]string{"block", "NAS", "--no-self-check"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !blocked {
+		t.Error("--no-self-check should skip detection")
+	}
+}
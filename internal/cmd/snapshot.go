@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// DeviceChange is a single field that differs between two snapshots
+type DeviceChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ChangedDevice is a device present in both snapshots with differing fields
+type ChangedDevice struct {
+	MAC     string         `json:"mac"`
+	Name    string         `json:"name"`
+	Changes []DeviceChange `json:"changes"`
+}
+
+// DeviceDiff is the result of comparing two device snapshots
+type DeviceDiff struct {
+	Added   []api.Device    `json:"added"`
+	Removed []api.Device    `json:"removed"`
+	Changed []ChangedDevice `json:"changed"`
+}
+
+// Empty reports whether the snapshots were identical
+func (d DeviceDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// SnapshotDevices saves the current device list as JSON to path, or to
+// stdout if path is empty
+func (a *App) SnapshotDevices(path string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	devices, err := a.Client.GetDevices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+	if devices == nil {
+		devices = []api.Device{}
+	}
+
+	// A stable order keeps snapshots of an unchanged network identical
+	data, err := json.MarshalIndent(sortedByMAC(devices), "", "  ")
+	if err != nil {
+		return fmt.Errorf("formatting JSON: %w", err)
+	}
+
+	if path == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+
+	fmt.Printf("Saved snapshot of %d devices to %s\n", len(devices), path)
+	return nil
+}
+
+// DiffDevices compares a saved snapshot against the current device list
+func (a *App) DiffDevices(path string) error {
+	old, err := loadSnapshot(path)
+	if err != nil {
+		return err
+	}
+
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	current, err := a.Client.GetDevices(networkID)
+	if err != nil {
+		return fmt.Errorf("getting devices: %w", err)
+	}
+
+	diff := diffDevices(old, current)
+
+	if a.structuredOutput() {
+		return a.printData(diff)
+	}
+
+	if diff.Empty() {
+		fmt.Println("No changes since snapshot")
+		return nil
+	}
+
+	if len(diff.Added) > 0 {
+		fmt.Printf("Added (%d):\n", len(diff.Added))
+		for _, d := range diff.Added {
+			fmt.Printf("  + %s (%s) %s\n", d.DisplayName(), d.MAC, d.DisplayIP())
+		}
+		fmt.Println()
+	}
+
+	if len(diff.Removed) > 0 {
+		fmt.Printf("Removed (%d):\n", len(diff.Removed))
+		for _, d := range diff.Removed {
+			fmt.Printf("  - %s (%s) %s\n", d.DisplayName(), d.MAC, d.DisplayIP())
+		}
+		fmt.Println()
+	}
+
+	if len(diff.Changed) > 0 {
+		fmt.Printf("Changed (%d):\n", len(diff.Changed))
+		for _, c := range diff.Changed {
+			fmt.Printf("  ~ %s (%s)\n", c.Name, c.MAC)
+			for _, ch := range c.Changes {
+				fmt.Printf("      %s: %s -> %s\n", ch.Field, orDash(ch.Old), orDash(ch.New))
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	return nil
+}
+
+// loadSnapshot reads a device snapshot written by SnapshotDevices. Enveloped
+// JSON output ({"meta": ..., "data": [...]}) is accepted as well.
+func loadSnapshot(path string) ([]api.Device, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	var devices []api.Device
+	if err := json.Unmarshal(data, &devices); err == nil {
+		return devices, nil
+	}
+
+	var env struct {
+		Data []api.Device `json:"data"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil || env.Data == nil {
+		return nil, fmt.Errorf("parsing snapshot %s: not a device list", path)
+	}
+	return env.Data, nil
+}
+
+// diffDevices compares two device lists keyed by MAC address
+func diffDevices(old, current []api.Device) DeviceDiff {
+	diff := DeviceDiff{
+		Added:   []api.Device{},
+		Removed: []api.Device{},
+		Changed: []ChangedDevice{},
+	}
+
+	oldByMAC := make(map[string]api.Device)
+	for _, d := range old {
+		oldByMAC[normalizeMAC(d.MAC)] = d
+	}
+
+	seen := make(map[string]bool)
+	for _, d := range current {
+		key := normalizeMAC(d.MAC)
+		seen[key] = true
+
+		prev, ok := oldByMAC[key]
+		if !ok {
+			diff.Added = append(diff.Added, d)
+			continue
+		}
+
+		if changes := deviceChanges(prev, d); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, ChangedDevice{
+				MAC:     d.MAC,
+				Name:    d.DisplayName(),
+				Changes: changes,
+			})
+		}
+	}
+
+	for _, d := range old {
+		if !seen[normalizeMAC(d.MAC)] {
+			diff.Removed = append(diff.Removed, d)
+		}
+	}
+
+	byName := func(devices []api.Device) func(i, j int) bool {
+		return func(i, j int) bool {
+			return strings.ToLower(devices[i].DisplayName()) < strings.ToLower(devices[j].DisplayName())
+		}
+	}
+	sort.Slice(diff.Added, byName(diff.Added))
+	sort.Slice(diff.Removed, byName(diff.Removed))
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return strings.ToLower(diff.Changed[i].Name) < strings.ToLower(diff.Changed[j].Name)
+	})
+
+	return diff
+}
+
+// deviceChanges lists the tracked fields that differ between two versions
+// of the same device
+func deviceChanges(old, current api.Device) []DeviceChange {
+	var changes []DeviceChange
+	compare := func(field, o, n string) {
+		if o != n {
+			changes = append(changes, DeviceChange{Field: field, Old: o, New: n})
+		}
+	}
+
+	compare("name", old.DisplayName(), current.DisplayName())
+	compare("status", deviceStatus(old), deviceStatus(current))
+	compare("ip", old.DisplayIP(), current.DisplayIP())
+	compare("profile", deviceProfileName(old), deviceProfileName(current))
+
+	return changes
+}
+
+// deviceProfileName returns the device's profile name, "Guest" for guest
+// devices, or "" if it has no profile
+func deviceProfileName(d api.Device) string {
+	if d.IsGuest {
+		return "Guest"
+	}
+	if d.Profile != nil {
+		return d.Profile.Name
+	}
+	return ""
+}
+
+// orDash returns s, or "-" if s is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// changedDevices returns testDevices with the phone removed, the NAS moved
+// to a new IP and paused, and a new device added
+func changedDevices() []api.Device {
+	devices := testDevices()
+	nas := devices[2]
+	nas.IP = "192.168.1.20"
+	nas.Paused = true
+	// MAC case differences must not count as a new device
+	nas.MAC = strings.ToLower(nas.MAC)
+
+	return []api.Device{
+		devices[0],
+		nas,
+		{
+			URL:       "/2.2/networks/12345/devices/998877665544",
+			MAC:       "99:88:77:66:55:44",
+			Hostname:  "tablet",
+			IP:        "192.168.1.102",
+			Connected: true,
+		},
+	}
+}
+
+func TestDiffDevices(t *testing.T) {
+	diff := diffDevices(testDevices(), changedDevices())
+
+	if len(diff.Added) != 1 || diff.Added[0].Hostname != "tablet" {
+		t.Errorf("Added = %+v, want tablet", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Hostname != "phone" {
+		t.Errorf("Removed = %+v, want phone", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Name != "NAS" {
+		t.Fatalf("Changed = %+v, want NAS", diff.Changed)
+	}
+
+	changes := make(map[string]DeviceChange)
+	for _, c := range diff.Changed[0].Changes {
+		changes[c.Field] = c
+	}
+	if c := changes["status"]; c.Old != "online" || c.New != "paused" {
+		t.Errorf("status change = %+v, want online -> paused", c)
+	}
+	if c := changes["ip"]; c.Old != "192.168.1.10" || c.New != "192.168.1.20" {
+		t.Errorf("ip change = %+v", c)
+	}
+	if len(changes) != 2 {
+		t.Errorf("changes = %+v, want only status and ip", diff.Changed[0].Changes)
+	}
+}
+
+func TestDiffDevicesIdentical(t *testing.T) {
+	if diff := diffDevices(testDevices(), testDevices()); !diff.Empty() {
+		t.Errorf("expected empty diff, got %+v", diff)
+	}
+}
+
+func TestDiffDevicesProfileChange(t *testing.T) {
+	current := testDevices()
+	current[0].Profile = nil
+
+	diff := diffDevices(testDevices(), current)
+	if len(diff.Changed) != 1 {
+		t.Fatalf("Changed = %+v, want one device", diff.Changed)
+	}
+	c := diff.Changed[0].Changes[0]
+	if c.Field != "profile" || c.Old != "Adults" || c.New != "" {
+		t.Errorf("change = %+v, want profile Adults -> empty", c)
+	}
+}
+
+func TestSnapshotAndDiffDevices(t *testing.T) {
+	devices := testDevices()
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+	path := filepath.Join(t.TempDir(), "snap.json")
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"snapshot", "--out", path}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Saved snapshot of 3 devices") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	devices = changedDevices()
+	out = captureStdout(t, func() {
+		if err := app.Devices([]string{"diff", path}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"Added (1)", "+ tablet", "Removed (1)", "- phone", "Changed (1)", "~ NAS", "status: online -> paused", "1 added, 1 removed, 1 changed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDiffDevicesJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+	data, _ := json.Marshal(testDevices())
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("writing snapshot: %v", err)
+	}
+
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.DiffDevices(path); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var diff map[string][]json.RawMessage
+	if err := json.Unmarshal([]byte(out), &diff); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	for _, key := range []string{"added", "removed", "changed"} {
+		if v, ok := diff[key]; !ok || v == nil || len(v) != 0 {
+			t.Errorf("%s = %v, want empty array", key, v)
+		}
+	}
+}
+
+func TestDiffDevicesBadSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+	os.WriteFile(path, []byte(`{"hello": "world"}`), 0600)
+
+	app := newTestApp(&mockClient{})
+	if err := app.DiffDevices(path); err == nil || !strings.Contains(err.Error(), "not a device list") {
+		t.Errorf("expected parse error, got: %v", err)
+	}
+	if err := app.DiffDevices(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing snapshot")
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// sortSpec is a parsed --sort value. The zero value means the listing's
+// default order.
+type sortSpec struct {
+	Field string
+	Desc  bool
+}
+
+// parseSort parses a --sort value, "<field>" or "<field>:asc|desc", where
+// field is one of valid
+func parseSort(s string, valid []string) (sortSpec, error) {
+	field, dir, hasDir := strings.Cut(strings.ToLower(strings.TrimSpace(s)), ":")
+	if !slices.Contains(valid, field) {
+		return sortSpec{}, fmt.Errorf("invalid --sort field: %s (valid: %s)", field, strings.Join(valid, ", "))
+	}
+	spec := sortSpec{Field: field}
+	if hasDir {
+		switch dir {
+		case "asc":
+		case "desc":
+			spec.Desc = true
+		default:
+			return sortSpec{}, fmt.Errorf("invalid --sort direction: %s (must be asc or desc)", dir)
+		}
+	}
+	return spec, nil
+}
+
+// deviceSortFields are the fields accepted by devices --sort
+var deviceSortFields = []string{"name", "ip", "status", "type", "profile", "mac"}
+
+// deviceCompare compares two devices by a --sort field
+func deviceCompare(field string) func(a, b api.Device) int {
+	switch field {
+	case "ip":
+		return func(a, b api.Device) int { return compareIPs(a.DisplayIP(), b.DisplayIP()) }
+	case "status":
+		return func(a, b api.Device) int { return cmp.Compare(deviceStatus(a), deviceStatus(b)) }
+	case "type":
+		return func(a, b api.Device) int { return cmp.Compare(connType(a), connType(b)) }
+	case "profile":
+		return func(a, b api.Device) int { return compareFold(profileDisplay(a), profileDisplay(b)) }
+	case "mac":
+		return func(a, b api.Device) int { return cmp.Compare(normalizeMAC(a.MAC), normalizeMAC(b.MAC)) }
+	default:
+		return func(a, b api.Device) int { return compareFold(a.DisplayName(), b.DisplayName()) }
+	}
+}
+
+// deviceSorter sorts devices, keeping dedupe counts (when not nil) aligned
+type deviceSorter struct {
+	devices []api.Device
+	counts  []int
+	compare func(a, b api.Device) int
+	desc    bool
+}
+
+func (s deviceSorter) Len() int { return len(s.devices) }
+
+func (s deviceSorter) Less(i, j int) bool {
+	if s.desc {
+		return s.compare(s.devices[j], s.devices[i]) < 0
+	}
+	return s.compare(s.devices[i], s.devices[j]) < 0
+}
+
+func (s deviceSorter) Swap(i, j int) {
+	s.devices[i], s.devices[j] = s.devices[j], s.devices[i]
+	if s.counts != nil {
+		s.counts[i], s.counts[j] = s.counts[j], s.counts[i]
+	}
+}
+
+// sortDevices sorts devices in place by spec (by name when unset), moving
+// counts along with them. Ties keep their original order.
+func sortDevices(devices []api.Device, counts []int, spec sortSpec) {
+	sort.Stable(deviceSorter{devices, counts, deviceCompare(spec.Field), spec.Desc})
+}
+
+// eeroSortFields are the fields accepted by eeros --sort
+var eeroSortFields = []string{"location", "clients", "signal"}
+
+// sortedEeros returns a copy of eeros sorted by spec (by location when
+// unset). Ties keep their original order.
+func sortedEeros(eeros []api.Eero, spec sortSpec) []api.Eero {
+	var compare func(a, b api.Eero) int
+	switch spec.Field {
+	case "clients":
+		compare = func(a, b api.Eero) int { return cmp.Compare(a.ConnectedClientsCount, b.ConnectedClientsCount) }
+	case "signal":
+		compare = func(a, b api.Eero) int { return cmp.Compare(a.MeshQualityBars, b.MeshQualityBars) }
+	default:
+		compare = func(a, b api.Eero) int { return compareFold(a.Location, b.Location) }
+	}
+
+	sorted := slices.Clone(eeros)
+	slices.SortStableFunc(sorted, func(a, b api.Eero) int {
+		if spec.Desc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+	return sorted
+}
+
+// compareIPs compares addresses numerically, IPv4 before IPv6. Values that
+// aren't addresses (such as an empty IP) sort after all addresses.
+func compareIPs(a, b string) int {
+	ipA, errA := netip.ParseAddr(a)
+	ipB, errB := netip.ParseAddr(b)
+	switch {
+	case errA != nil && errB != nil:
+		return cmp.Compare(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+	return ipA.Compare(ipB)
+}
+
+// compareFold compares strings case-insensitively
+func compareFold(a, b string) int {
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
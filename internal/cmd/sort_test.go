@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		in   string
+		want sortSpec
+	}{
+		{"name", sortSpec{Field: "name"}},
+		{"IP", sortSpec{Field: "ip"}},
+		{"ip:asc", sortSpec{Field: "ip"}},
+		{"clients:desc", sortSpec{Field: "clients", Desc: true}},
+	}
+	valid := append(deviceSortFields, eeroSortFields...)
+	for _, tt := range tests {
+		got, err := parseSort(tt.in, valid)
+		if err != nil {
+			t.Errorf("parseSort(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSort(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "size", "name:up", "ip:"} {
+		if _, err := parseSort(in, deviceSortFields); err == nil {
+			t.Errorf("parseSort(%q) expected error", in)
+		}
+	}
+}
+
+func TestCompareIPs(t *testing.T) {
+	ordered := []string{"10.0.0.1", "192.168.1.2", "192.168.1.10", "192.168.1.100", "fe80::1", "", "unknown"}
+	for i := 0; i+1 < len(ordered); i++ {
+		if compareIPs(ordered[i], ordered[i+1]) >= 0 {
+			t.Errorf("compareIPs(%q, %q) >= 0, want < 0", ordered[i], ordered[i+1])
+		}
+	}
+}
+
+// tableColumn returns column col of each row of a printed table, skipping
+// the header and separator lines and stopping at the blank line before any
+// footer
+func tableColumn(out string, col int) []string {
+	var values []string
+	for _, line := range strings.Split(out, "\n")[2:] {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			break
+		}
+		if len(fields) > col {
+			values = append(values, fields[col])
+		}
+	}
+	return values
+}
+
+func TestListDevicesSortByIP(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			devices := testDevices()
+			devices[0].IP = "192.168.1.2"
+			return devices, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	for _, tt := range []struct {
+		sort string
+		want string
+	}{
+		{"--sort=ip", "192.168.1.2 192.168.1.10 192.168.1.101"},
+		{"--sort=ip:desc", "192.168.1.101 192.168.1.10 192.168.1.2"},
+	} {
+		out := captureStdout(t, func() {
+			if err := app.Devices([]string{tt.sort, "--format", "ip,name"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		if got := strings.Join(tableColumn(out, 0), " "); got != tt.want {
+			t.Errorf("%s: IPs = %s, want %s\n%s", tt.sort, got, tt.want, out)
+		}
+	}
+}
+
+func TestListEerosSortByClientsDesc(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			eeros := testEeros()
+			eeros[0].ConnectedClientsCount = 30
+			return eeros, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Eeros([]string{"--sort", "clients:desc"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if got := strings.Join(tableColumn(out, 0), " "); got != "8318690 8318691" {
+		t.Errorf("eero order = %s, want 8318690 8318691\n%s", got, out)
+	}
+
+	// Without --sort, eeros are listed by location
+	out = captureStdout(t, func() {
+		if err := app.Eeros(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if got := strings.Join(tableColumn(out, 0), " "); got != "8318691 8318690" {
+		t.Errorf("default eero order = %s, want Bedroom (8318691) first\n%s", got, out)
+	}
+}
+
+func TestSortInvalidField(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	if err := app.Devices([]string{"--sort", "clients"}); err == nil || !strings.Contains(err.Error(), "invalid --sort field") {
+		t.Errorf("devices error = %v", err)
+	}
+	if err := app.Eeros([]string{"--sort=ip"}); err == nil || !strings.Contains(err.Error(), "invalid --sort field") {
+		t.Errorf("eeros error = %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// How often and how long Speedtest polls for a new result
+var (
+	speedtestPollInterval = 3 * time.Second
+	speedtestTimeout      = 2 * time.Minute
+)
+
+// Speedtest handles the speedtest command
+func (a *App) Speedtest(args []string) error {
+	if len(args) == 0 {
+		return a.RunSpeedtest()
+	}
+
+	switch args[0] {
+	case "last":
+		return a.LastSpeedtest()
+	default:
+		return fmt.Errorf("unknown speedtest subcommand: %s", args[0])
+	}
+}
+
+// RunSpeedtest starts a speed test on the gateway and waits for the result
+func (a *App) RunSpeedtest() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	// A new result is recognized by its date changing
+	before, err := a.Client.GetSpeedtest(networkID)
+	if err != nil {
+		return fmt.Errorf("getting speed test: %w", err)
+	}
+
+	if err := a.Client.StartSpeedtest(networkID); err != nil {
+		return fmt.Errorf("starting speed test: %w", err)
+	}
+	a.progressf("Running speed test, this can take a minute...\n")
+
+	deadline := time.Now().Add(speedtestTimeout)
+	for {
+		time.Sleep(speedtestPollInterval)
+
+		result, err := a.Client.GetSpeedtest(networkID)
+		if err != nil {
+			return fmt.Errorf("getting speed test: %w", err)
+		}
+		if result.Date != "" && result.Date != before.Date {
+			return a.printSpeedtest(result)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("speed test did not finish within %s; check later with 'eero-cli speedtest last'", speedtestTimeout)
+		}
+	}
+}
+
+// LastSpeedtest shows the most recent speed test without running a new one
+func (a *App) LastSpeedtest() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	result, err := a.Client.GetSpeedtest(networkID)
+	if err != nil {
+		return fmt.Errorf("getting speed test: %w", err)
+	}
+
+	if result.Date == "" && !a.structuredOutput() {
+		fmt.Println("No speed test has been run on this network yet.")
+		fmt.Println("Run one with 'eero-cli speedtest'.")
+		return nil
+	}
+
+	return a.printSpeedtest(result)
+}
+
+// printSpeedtest shows a speed test result as a table or structured data
+func (a *App) printSpeedtest(result *api.Speedtest) error {
+	if a.structuredOutput() {
+		return a.printData(result)
+	}
+
+	headers := []string{"DATE", "DOWN", "UP"}
+	rows := [][]string{{
+		result.Date,
+		fmt.Sprintf("%.1f Mbps", result.DownMbps),
+		fmt.Sprintf("%.1f Mbps", result.UpMbps),
+	}}
+	PrintTable(headers, rows)
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// fastSpeedtestPolling shortens the poll interval and timeout for a test
+func fastSpeedtestPolling(t *testing.T, timeout time.Duration) {
+	t.Helper()
+	oldInterval, oldTimeout := speedtestPollInterval, speedtestTimeout
+	speedtestPollInterval, speedtestTimeout = time.Millisecond, timeout
+	t.Cleanup(func() {
+		speedtestPollInterval, speedtestTimeout = oldInterval, oldTimeout
+	})
+}
+
+func TestRunSpeedtestWaitsForNewResult(t *testing.T) {
+	fastSpeedtestPolling(t, time.Second)
+
+	started := false
+	polls := 0
+	mock := &mockClient{
+		StartSpeedtestFn: func(networkID string) error {
+			started = true
+			return nil
+		},
+		GetSpeedtestFn: func(networkID string) (*api.Speedtest, error) {
+			if !started {
+				return &api.Speedtest{DownMbps: 100, UpMbps: 10, Date: "2024-03-09T08:00:00Z"}, nil
+			}
+			polls++
+			if polls < 3 {
+				return &api.Speedtest{DownMbps: 100, UpMbps: 10, Date: "2024-03-09T08:00:00Z"}, nil
+			}
+			return &api.Speedtest{DownMbps: 512.34, UpMbps: 40.1, Date: "2024-03-10T08:00:00Z"}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Speedtest(nil); err != nil {
+			t.Fatalf("Speedtest error: %v", err)
+		}
+	})
+
+	if polls != 3 {
+		t.Errorf("polled %d times, want 3", polls)
+	}
+	for _, want := range []string{"2024-03-10T08:00:00Z", "512.3 Mbps", "40.1 Mbps"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "100.0 Mbps") {
+		t.Errorf("output shows the old result:\n%s", out)
+	}
+}
+
+func TestRunSpeedtestFirstEverAndTimeout(t *testing.T) {
+	fastSpeedtestPolling(t, 20*time.Millisecond)
+
+	// No test has run before, and none finishes
+	mock := &mockClient{
+		StartSpeedtestFn: func(networkID string) error { return nil },
+		GetSpeedtestFn: func(networkID string) (*api.Speedtest, error) {
+			return &api.Speedtest{}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	captureStdout(t, func() {
+		err = app.Speedtest(nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "did not finish") {
+		t.Errorf("expected timeout error, got: %v", err)
+	}
+}
+
+func TestLastSpeedtestNeverRun(t *testing.T) {
+	mock := &mockClient{
+		GetSpeedtestFn: func(networkID string) (*api.Speedtest, error) {
+			return &api.Speedtest{}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Speedtest([]string{"last"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No speed test has been run") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf8"
+)
+
+// maxSSIDBytes is the longest SSID allowed by 802.11
+const maxSSIDBytes = 32
+
+// validateSSID checks that name is a valid network name: 1-32 bytes of
+// valid UTF-8
+func validateSSID(name string) error {
+	if name == "" {
+		return fmt.Errorf("network name cannot be empty")
+	}
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("network name is not valid UTF-8")
+	}
+	if n := len(name); n > maxSSIDBytes {
+		return fmt.Errorf("network name is %d bytes; the maximum is %d bytes (non-ASCII characters use more than one byte)", n, maxSSIDBytes)
+	}
+	return nil
+}
+
+// warnNonASCIISSID prints a warning to stderr if name contains characters
+// that some clients display or match incorrectly
+func warnNonASCIISSID(name string) {
+	for _, r := range name {
+		if r > 127 {
+			fmt.Fprintf(os.Stderr, "Warning: network name %q contains non-ASCII characters, which some devices mishandle\n", name)
+			return
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSSIDBoundaries(t *testing.T) {
+	if err := validateSSID(strings.Repeat("a", 32)); err != nil {
+		t.Errorf("32-byte name rejected: %v", err)
+	}
+	if err := validateSSID("x"); err != nil {
+		t.Errorf("1-byte name rejected: %v", err)
+	}
+
+	err := validateSSID(strings.Repeat("a", 33))
+	if err == nil || !strings.Contains(err.Error(), "33 bytes") {
+		t.Errorf("expected 33-byte error, got: %v", err)
+	}
+
+	if err := validateSSID(""); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected empty error, got: %v", err)
+	}
+}
+
+func TestValidateSSIDCountsBytes(t *testing.T) {
+	// Each "é" is two bytes, so 16 of them make 32 bytes
+	if err := validateSSID(strings.Repeat("é", 16)); err != nil {
+		t.Errorf("32-byte UTF-8 name rejected: %v", err)
+	}
+	if err := validateSSID(strings.Repeat("é", 16) + "a"); err == nil {
+		t.Error("33-byte UTF-8 name accepted")
+	}
+	if err := validateSSID("bad\xff"); err == nil || !strings.Contains(err.Error(), "UTF-8") {
+		t.Errorf("expected UTF-8 error, got: %v", err)
+	}
+}
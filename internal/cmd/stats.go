@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// statsReporter is implemented by clients that count their requests
+type statsReporter interface {
+	Stats() api.Stats
+}
+
+// PrintStats writes a summary of the API requests made by this command to
+// stderr, busiest path first, to make repeated per-item fetches visible
+func (a *App) PrintStats() {
+	reporter, ok := a.Client.(statsReporter)
+	if !ok {
+		return
+	}
+	stats := reporter.Stats()
+
+	fmt.Fprintf(os.Stderr, "\nAPI requests: %d in %s\n", stats.Requests, stats.Duration.Round(time.Millisecond))
+	if len(stats.Paths) == 0 {
+		return
+	}
+
+	width := len(strconv.Itoa(stats.Paths[0].Count))
+	for _, ps := range stats.Paths {
+		fmt.Fprintf(os.Stderr, "  %*d  %8s  %s %s\n", width, ps.Count, ps.Duration.Round(time.Millisecond), ps.Method, ps.Pattern)
+	}
+}
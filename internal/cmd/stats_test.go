@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// statsClient is a mockClient that reports fixed request stats
+type statsClient struct {
+	*mockClient
+	stats api.Stats
+}
+
+func (c statsClient) Stats() api.Stats { return c.stats }
+
+func TestPrintStats(t *testing.T) {
+	app := newTestApp(&mockClient{})
+	app.Client = statsClient{
+		mockClient: &mockClient{},
+		stats: api.Stats{
+			Requests: 12,
+			Duration: 1500 * time.Millisecond,
+			Paths: []api.PathStats{
+				{Method: "GET", Pattern: "/2.2/networks/{id}/profiles/{id}", Count: 11, Duration: 1400 * time.Millisecond},
+				{Method: "GET", Pattern: "/2.2/networks/{id}/profiles", Count: 1, Duration: 100 * time.Millisecond},
+			},
+		},
+	}
+
+	out := captureStderr(t, app.PrintStats)
+
+	for _, want := range []string{
+		"API requests: 12 in 1.5s",
+		"11      1.4s  GET /2.2/networks/{id}/profiles/{id}",
+		" 1     100ms  GET /2.2/networks/{id}/profiles\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stats output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintStatsWithoutReporter(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	if out := captureStderr(t, app.PrintStats); out != "" {
+		t.Errorf("expected no output for a client without stats, got %q", out)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// suggest returns the candidate closest to query (case-insensitive), or ""
+// if nothing is close enough to be a plausible typo
+func suggest(query string, candidates []string) string {
+	query = strings.ToLower(query)
+	maxDistance := max(2, len([]rune(query))/3)
+
+	best, bestDistance := "", maxDistance+1
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		if d := levenshtein(query, strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	return best
+}
+
+// notFoundError builds a "<kind> not found" error, appending a suggestion
+// when one of the candidates is a close match. It exits with ExitNotFound.
+func notFoundError(kind, query string, candidates []string) error {
+	err := fmt.Errorf("%s not found: %s", kind, query)
+	if s := suggest(query, candidates); s != "" {
+		err = fmt.Errorf("%s not found: %s (did you mean '%s'?)", kind, query, s)
+	}
+	return &codedError{code: ExitNotFound, err: err}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"adults", "adults", 0},
+		{"aduls", "adults", 1},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"Adults", "Kids", "Guests"}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"Aduls", "Adults"},
+		{"kds", "Kids"},
+		{"guest", "Guests"},
+		{"something-else", ""},
+	}
+
+	for _, tt := range tests {
+		if got := suggest(tt.query, candidates); got != tt.want {
+			t.Errorf("suggest(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFindProfileSuggestion(t *testing.T) {
+	mock := &mockClient{
+		GetProfilesFn: func(networkID string) ([]api.Profile, error) {
+			return []api.Profile{
+				{URL: "/2.2/networks/12345/profiles/prof1", Name: "Adults"},
+				{URL: "/2.2/networks/12345/profiles/prof2", Name: "Kids"},
+			}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	_, err := app.findProfileID("12345", "Aduls")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "profile not found: aduls") {
+		t.Errorf("error = %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "did you mean 'Adults'?") {
+		t.Errorf("error = %q, want suggestion", err.Error())
+	}
+}
+
+func TestFindDeviceSuggestion(t *testing.T) {
+	mock := &mockClient{
+		GetDevicesFn: func(networkID string) ([]api.Device, error) {
+			return testDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	_, err := app.findDeviceID("12345", "My Laptp")
+	if err == nil || !strings.Contains(err.Error(), "did you mean 'My Laptop'?") {
+		t.Errorf("expected suggestion, got: %v", err)
+	}
+
+	// Nothing close: no suggestion
+	_, err = app.findDeviceID("12345", "refrigerator")
+	if err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("expected plain not-found error, got: %v", err)
+	}
+}
+
+func TestFindEeroSuggestion(t *testing.T) {
+	mock := &mockClient{
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			return testEeros(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	_, err := app.findEeroID("12345", "Bedrom")
+	if err == nil || !strings.Contains(err.Error(), "did you mean 'Bedroom'?") {
+		t.Errorf("expected suggestion, got: %v", err)
+	}
+}
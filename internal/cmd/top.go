@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// defaultTopLimit is how many devices devices top shows without --limit
+const defaultTopLimit = 10
+
+// TopDevices lists the devices using the most bandwidth
+func (a *App) TopDevices(limit int) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	devices, err := a.Client.GetDeviceUsage(networkID)
+	if err != nil {
+		return fmt.Errorf("getting device usage: %w", err)
+	}
+
+	sortByUsage(devices)
+	if limit <= 0 {
+		limit = defaultTopLimit
+	}
+	if len(devices) > limit {
+		devices = devices[:limit]
+	}
+
+	headers := []string{"#", "ID", "NAME", "IP", "DOWN", "UP", "TOTAL", "STATUS"}
+	var rows [][]string
+	for i, d := range devices {
+		u := d.Usage
+		if u == nil {
+			u = &api.DeviceUsage{}
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			api.ExtractDeviceID(d.URL),
+			d.DisplayName(),
+			d.DisplayIP(),
+			formatUsage(u.DownMbps, u.DownBytes),
+			formatUsage(u.UpMbps, u.UpBytes),
+			formatUsage(u.TotalMbps(), u.TotalBytes()),
+			deviceStatus(d),
+		})
+	}
+
+	switch a.outputFormat() {
+	case OutputJSON, OutputYAML:
+		if devices == nil {
+			devices = []api.Device{}
+		}
+		return a.printData(devices)
+	case OutputCSV:
+		return printCSV(headers, rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No devices found.")
+		return nil
+	}
+	PrintTable(headers, rows)
+	return nil
+}
+
+// sortByUsage orders devices by total bandwidth, highest first. Rates take
+// precedence over byte counts; idle devices follow, online before offline.
+func sortByUsage(devices []api.Device) {
+	sort.SliceStable(devices, func(i, j int) bool {
+		ui, uj := devices[i].Usage, devices[j].Usage
+		if ui.IsZero() != uj.IsZero() {
+			return !ui.IsZero()
+		}
+		if ui.IsZero() {
+			return devices[i].Connected && !devices[j].Connected
+		}
+		if ui.TotalMbps() != uj.TotalMbps() {
+			return ui.TotalMbps() > uj.TotalMbps()
+		}
+		return ui.TotalBytes() > uj.TotalBytes()
+	})
+}
+
+// formatUsage shows a rate when one was reported, else a byte count
+func formatUsage(mbps float64, bytes int64) string {
+	switch {
+	case mbps > 0:
+		return fmt.Sprintf("%.1f Mbps", mbps)
+	case bytes > 0:
+		return formatBytes(bytes)
+	default:
+		return "-"
+	}
+}
+
+// formatBytes renders a byte count with a binary unit (e.g. "1.5 MB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// usageDevices returns devices with different bandwidth usage, in no
+// particular order: an offline phone, a busy TV, a laptop, and an idle NAS
+func usageDevices() []api.Device {
+	return []api.Device{
+		{URL: "/2.2/networks/12345/devices/eeff00112233", Hostname: "phone", IP: "192.168.1.101"},
+		{URL: "/2.2/networks/12345/devices/112233445566", Nickname: "NAS", IP: "192.168.1.10", Connected: true,
+			Usage: &api.DeviceUsage{}},
+		{URL: "/2.2/networks/12345/devices/aabbccdd1122", Nickname: "My Laptop", IP: "192.168.1.100", Connected: true,
+			Usage: &api.DeviceUsage{DownMbps: 3.2, UpMbps: 1.1}},
+		{URL: "/2.2/networks/12345/devices/99aabbccddee", Nickname: "TV", IP: "192.168.1.120", Connected: true,
+			Usage: &api.DeviceUsage{DownMbps: 45.5, UpMbps: 0.4}},
+	}
+}
+
+func TestTopDevicesSortsByUsage(t *testing.T) {
+	mock := &mockClient{
+		GetDeviceUsageFn: func(networkID string) ([]api.Device, error) {
+			return usageDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"top"}); err != nil {
+			t.Fatalf("Devices top error: %v", err)
+		}
+	})
+
+	last := -1
+	for _, name := range []string{"TV", "My Laptop", "NAS", "phone"} {
+		i := strings.Index(out, name)
+		if i < 0 {
+			t.Fatalf("output missing %q:\n%s", name, out)
+		}
+		if i < last {
+			t.Errorf("%q is out of order:\n%s", name, out)
+		}
+		last = i
+	}
+	for _, want := range []string{"45.5 Mbps", "45.9 Mbps", "4.3 Mbps"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTopDevicesLimit(t *testing.T) {
+	mock := &mockClient{
+		GetDeviceUsageFn: func(networkID string) ([]api.Device, error) {
+			return usageDevices(), nil
+		},
+	}
+	app := newTestApp(mock)
+
+	out := captureStdout(t, func() {
+		if err := app.Devices([]string{"top", "--limit", "1"}); err != nil {
+			t.Fatalf("Devices top error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "TV") || strings.Contains(out, "My Laptop") {
+		t.Errorf("want only the top device:\n%s", out)
+	}
+
+	if err := app.Devices([]string{"top", "--limit=0"}); err == nil {
+		t.Error("expected error for --limit=0")
+	}
+}
+
+func TestSortByUsageFallsBackToBytes(t *testing.T) {
+	devices := []api.Device{
+		{Nickname: "small", Usage: &api.DeviceUsage{DownBytes: 1024}},
+		{Nickname: "large", Usage: &api.DeviceUsage{DownBytes: 4096, UpBytes: 1024}},
+	}
+	sortByUsage(devices)
+
+	if devices[0].Nickname != "large" {
+		t.Errorf("order = %s, %s; want large first", devices[0].Nickname, devices[1].Nickname)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:                    "512 B",
+		1536:                   "1.5 KB",
+		62914560:               "60.0 MB",
+		3 * 1024 * 1024 * 1024: "3.0 GB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
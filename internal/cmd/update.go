@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+// UpdateStatusResult is the machine-readable output of update status
+type UpdateStatusResult struct {
+	api.UpdateStatus
+	NetworkID string       `json:"network_id"`
+	Eeros     []EeroUpdate `json:"eeros"`
+}
+
+// EeroUpdate is one node's firmware version and update availability
+type EeroUpdate struct {
+	ID              string `json:"id"`
+	Location        string `json:"location"`
+	Model           string `json:"model"`
+	Version         string `json:"version"`
+	UpdateAvailable bool   `json:"update_available"`
+}
+
+// UpdateResult is the machine-readable result of update apply. Being up to
+// date or declining the confirmation is a result too, not an error.
+type UpdateResult struct {
+	Action         string `json:"action"`
+	NetworkID      string `json:"network_id"`
+	TargetFirmware string `json:"target_firmware,omitempty"`
+	UpToDate       bool   `json:"up_to_date"`
+	Cancelled      bool   `json:"cancelled"`
+}
+
+// Update handles the update command
+func (a *App) Update(args []string) error {
+	if len(args) == 0 || args[0] == "status" {
+		if len(args) > 1 {
+			return fmt.Errorf("usage: update status")
+		}
+		return a.UpdateStatus()
+	}
+
+	switch args[0] {
+	case "apply":
+		yes := false
+		for _, arg := range args[1:] {
+			if arg == "--yes" || arg == "-y" {
+				yes = true
+			} else {
+				return fmt.Errorf("usage: update apply [--yes]")
+			}
+		}
+		return a.ApplyUpdate(yes)
+	default:
+		return fmt.Errorf("unknown update subcommand: %s", args[0])
+	}
+}
+
+// UpdateStatus shows whether a firmware update is available, and each
+// eero's current version
+func (a *App) UpdateStatus() error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	status, err := a.Client.GetUpdateStatus(networkID)
+	if err != nil {
+		return fmt.Errorf("getting update status: %w", err)
+	}
+
+	eeros, err := a.eeros(networkID)
+	if err != nil {
+		return fmt.Errorf("getting eeros: %w", err)
+	}
+	eeros = sortedEeros(eeros, sortSpec{})
+
+	result := UpdateStatusResult{UpdateStatus: *status, NetworkID: networkID, Eeros: []EeroUpdate{}}
+	for _, e := range eeros {
+		result.Eeros = append(result.Eeros, EeroUpdate{
+			ID:              api.ExtractEeroID(e.URL),
+			Location:        e.Location,
+			Model:           e.Model,
+			Version:         e.OSVersion,
+			UpdateAvailable: e.UpdateAvailable,
+		})
+	}
+
+	if a.structuredOutput() {
+		return a.printData(result)
+	}
+
+	switch {
+	case !status.HasUpdate:
+		fmt.Println("Firmware: up to date")
+	case status.CanUpdateNow:
+		fmt.Printf("Update available: %s\n", status.TargetFirmware)
+	default:
+		fmt.Printf("Update available: %s (can't be installed right now)\n", status.TargetFirmware)
+	}
+
+	if len(result.Eeros) == 0 {
+		return nil
+	}
+	fmt.Println()
+
+	headers := []string{"ID", "LOCATION", "MODEL", "VERSION", "UPDATE"}
+	var rows [][]string
+	for _, e := range result.Eeros {
+		update := "no"
+		if e.UpdateAvailable {
+			update = "yes"
+		}
+		rows = append(rows, []string{e.ID, e.Location, e.Model, e.Version, update})
+	}
+	PrintTable(headers, rows)
+	return nil
+}
+
+// ApplyUpdate installs the available firmware update after confirming.
+// Being up to date already is not an error.
+func (a *App) ApplyUpdate(yes bool) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	status, err := a.Client.GetUpdateStatus(networkID)
+	if err != nil {
+		return fmt.Errorf("getting update status: %w", err)
+	}
+
+	result := UpdateResult{Action: "update", NetworkID: networkID, TargetFirmware: status.TargetFirmware}
+
+	if !status.HasUpdate {
+		result.UpToDate = true
+		if a.structuredOutput() {
+			return a.printData(result)
+		}
+		fmt.Println("Firmware is already up to date")
+		return nil
+	}
+	if !status.CanUpdateNow {
+		return fmt.Errorf("update to %s can't be installed right now; try again later", status.TargetFirmware)
+	}
+
+	if !yes && !a.confirm(fmt.Sprintf("Update the network to firmware %s? Every eero will reboot and devices will disconnect temporarily.", status.TargetFirmware)) {
+		result.Cancelled = true
+		if a.structuredOutput() {
+			return a.printData(result)
+		}
+		fmt.Println("Update cancelled")
+		return nil
+	}
+
+	if err := a.Client.ApplyUpdate(networkID); err != nil {
+		return fmt.Errorf("applying update: %w", err)
+	}
+
+	if a.structuredOutput() {
+		return a.printData(result)
+	}
+	fmt.Printf("Updating to firmware %s. The network will restart when the update is installed.\n", status.TargetFirmware)
+	return nil
+}
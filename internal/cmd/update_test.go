@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+)
+
+func updateMock(status api.UpdateStatus) *mockClient {
+	return &mockClient{
+		GetUpdateStatusFn: func(networkID string) (*api.UpdateStatus, error) {
+			return &status, nil
+		},
+		GetEerosFn: func(networkID string) ([]api.Eero, error) {
+			eeros := testEeros()
+			eeros[1].UpdateAvailable = true
+			return eeros, nil
+		},
+	}
+}
+
+func TestUpdateStatusTable(t *testing.T) {
+	app := newTestApp(updateMock(api.UpdateStatus{HasUpdate: true, CanUpdateNow: true, TargetFirmware: "7.4.0"}))
+
+	out := captureStdout(t, func() {
+		if err := app.Update(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Update available: 7.4.0") {
+		t.Errorf("output missing update line:\n%s", out)
+	}
+	for _, want := range []string{"VERSION", "UPDATE", "Bedroom", "Living Room"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	// Rows are sorted by location, like eeros
+	bedroom := strings.Index(out, "Bedroom")
+	living := strings.Index(out, "Living Room")
+	if bedroom > living {
+		t.Errorf("Bedroom should be listed before Living Room:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "Bedroom") && !strings.HasSuffix(strings.TrimSpace(line), "yes") {
+			t.Errorf("Bedroom row should show an update: %q", line)
+		}
+		if strings.Contains(line, "Living Room") && !strings.HasSuffix(strings.TrimSpace(line), "no") {
+			t.Errorf("Living Room row should show no update: %q", line)
+		}
+	}
+}
+
+func TestUpdateStatusUpToDate(t *testing.T) {
+	app := newTestApp(updateMock(api.UpdateStatus{}))
+
+	out := captureStdout(t, func() {
+		if err := app.Update([]string{"status"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Firmware: up to date") {
+		t.Errorf("output = %q, want up to date line", out)
+	}
+}
+
+func TestUpdateStatusJSON(t *testing.T) {
+	app := newTestApp(updateMock(api.UpdateStatus{HasUpdate: true, TargetFirmware: "7.4.0"}))
+	app.Output = OutputJSON
+
+	out := captureStdout(t, func() {
+		if err := app.Update([]string{"status"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var result UpdateStatusResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+	}
+	if !result.HasUpdate || result.TargetFirmware != "7.4.0" || result.NetworkID != "12345" {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Eeros) != 2 || result.Eeros[0].Location != "Bedroom" || !result.Eeros[0].UpdateAvailable {
+		t.Errorf("Eeros = %+v", result.Eeros)
+	}
+}
+
+func TestUpdateApplyConfirmed(t *testing.T) {
+	applied := ""
+	mock := updateMock(api.UpdateStatus{HasUpdate: true, CanUpdateNow: true, TargetFirmware: "7.4.0"})
+	mock.ApplyUpdateFn = func(networkID string) error {
+		applied = networkID
+		return nil
+	}
+	app := newTestApp(mock)
+
+	var out string
+	feedStdin(t, "y\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.Update([]string{"apply"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	if applied != "12345" {
+		t.Errorf("ApplyUpdate network = %q, want 12345", applied)
+	}
+	if !strings.Contains(out, "Updating to firmware 7.4.0") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestUpdateApplyDeclined(t *testing.T) {
+	mock := updateMock(api.UpdateStatus{HasUpdate: true, CanUpdateNow: true, TargetFirmware: "7.4.0"})
+	mock.ApplyUpdateFn = func(networkID string) error {
+		t.Fatal("ApplyUpdate should not be called when declined")
+		return nil
+	}
+	app := newTestApp(mock)
+
+	var out string
+	feedStdin(t, "n\n", func() {
+		out = captureStdout(t, func() {
+			if err := app.Update([]string{"apply"}); err != nil {
+				t.Fatalf("declining should not be an error: %v", err)
+			}
+		})
+	})
+
+	if !strings.Contains(out, "Update cancelled") {
+		t.Errorf("output = %q, want cancellation notice", out)
+	}
+}
+
+func TestUpdateApplyYesSkipsConfirmation(t *testing.T) {
+	applied := false
+	mock := updateMock(api.UpdateStatus{HasUpdate: true, CanUpdateNow: true, TargetFirmware: "7.4.0"})
+	mock.ApplyUpdateFn = func(networkID string) error {
+		applied = true
+		return nil
+	}
+	app := newTestApp(mock)
+
+	// No stdin: a prompt would read EOF and decline
+	feedStdin(t, "", func() {
+		captureStdout(t, func() {
+			if err := app.Update([]string{"apply", "--yes"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	if !applied {
+		t.Error("ApplyUpdate was not called with --yes")
+	}
+}
+
+func TestUpdateApplyUpToDate(t *testing.T) {
+	// ApplyUpdateFn is unset: calling it would panic
+	app := newTestApp(updateMock(api.UpdateStatus{}))
+
+	out := captureStdout(t, func() {
+		if err := app.Update([]string{"apply", "--yes"}); err != nil {
+			t.Fatalf("up to date should not be an error: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "already up to date") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestUpdateApplyNotNow(t *testing.T) {
+	app := newTestApp(updateMock(api.UpdateStatus{HasUpdate: true, TargetFirmware: "7.4.0"}))
+
+	err := app.Update([]string{"apply", "--yes"})
+	if err == nil || !strings.Contains(err.Error(), "can't be installed right now") {
+		t.Errorf("err = %v, want can't be installed error", err)
+	}
+}
+
+func TestUpdateUnknownSubcommand(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Update([]string{"bogus"})
+	if err == nil || !strings.Contains(err.Error(), "unknown update subcommand") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Webhook delivery defaults for devices monitor --webhook
+const (
+	webhookTimeout    = 10 * time.Second
+	webhookRetries    = 2
+	webhookRetryDelay = time.Second
+)
+
+// MonitorEvent is a device change detected by devices monitor, as posted to
+// a --webhook or printed with --output json
+type MonitorEvent struct {
+	Time           time.Time              `json:"time"`
+	Event          string                 `json:"event"`
+	DeviceID       string                 `json:"device_id"`
+	Name           string                 `json:"name"`
+	MAC            string                 `json:"mac"`
+	IP             string                 `json:"ip"`
+	Status         string                 `json:"status"`
+	PreviousStatus string                 `json:"previous_status,omitempty"`
+	Changes        map[string]FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is the before and after value of a field that changed
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// monitorEvent describes the change from prev to curr. Status changes name
+// the event (connected, disconnected, paused, blocked); otherwise it is
+// new, ip_changed, or changed. Changes lists every field that changed.
+func monitorEvent(deviceID string, prev, curr DeviceState, isNew bool, now time.Time) MonitorEvent {
+	e := MonitorEvent{
+		Time:     now.UTC(),
+		DeviceID: deviceID,
+		Name:     curr.Name,
+		MAC:      curr.MAC,
+		IP:       curr.IP,
+		Status:   curr.status(),
+	}
+
+	switch {
+	case isNew:
+		e.Event = "new"
+	case prev.status() != curr.status():
+		e.PreviousStatus = prev.status()
+		switch e.Status {
+		case "online":
+			e.Event = "connected"
+		case "offline":
+			e.Event = "disconnected"
+		default:
+			e.Event = e.Status
+		}
+	case prev.IP != curr.IP:
+		e.Event = "ip_changed"
+	default:
+		e.Event = "changed"
+	}
+
+	if !isNew {
+		e.Changes = stateChanges(prev, curr)
+	}
+	return e
+}
+
+// removedEvent describes a device that is no longer in the device list,
+// from its last known state
+func removedEvent(deviceID string, prev DeviceState, now time.Time) MonitorEvent {
+	return MonitorEvent{
+		Time:           now.UTC(),
+		Event:          "removed",
+		DeviceID:       deviceID,
+		Name:           prev.Name,
+		MAC:            prev.MAC,
+		IP:             prev.IP,
+		Status:         "removed",
+		PreviousStatus: prev.status(),
+	}
+}
+
+// stateChanges returns the fields devices monitor watches that differ
+// between prev and curr, or nil when none do
+func stateChanges(prev, curr DeviceState) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	add := func(field string, before, after interface{}) {
+		if before != after {
+			changes[field] = FieldChange{Before: before, After: after}
+		}
+	}
+	add("connected", prev.Connected, curr.Connected)
+	add("paused", prev.Paused, curr.Paused)
+	add("blocked", prev.Blocked, curr.Blocked)
+	add("private", prev.IsPrivate, curr.IsPrivate)
+	add("ip", prev.IP, curr.IP)
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// webhook posts monitor events as JSON to a URL
+type webhook struct {
+	URL        string
+	Client     *http.Client
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// newWebhook returns a webhook for url with the default timeout and retries
+func newWebhook(url string) *webhook {
+	return &webhook{
+		URL:        url,
+		Client:     &http.Client{Timeout: webhookTimeout},
+		Retries:    webhookRetries,
+		RetryDelay: webhookRetryDelay,
+	}
+}
+
+// parseWebhookURL validates a --webhook value, which must be an http or
+// https URL
+func parseWebhookURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid --webhook URL: %s (must be http or https)", s)
+	}
+	return s, nil
+}
+
+// Send posts the event, retrying connection failures, 429s, and 5xx
+// responses
+func (w *webhook) Send(e MonitorEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("formatting webhook event: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.Retries {
+			return err
+		}
+		time.Sleep(w.RetryDelay)
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying
+func (w *webhook) post(body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("posting webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("posting webhook: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("posting webhook: %s", resp.Status)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookServer records the events posted to it, answering with the given
+// status codes in turn and 200 after they run out
+func webhookServer(t *testing.T, statuses ...int) (*httptest.Server, func() []MonitorEvent) {
+	t.Helper()
+	var mu sync.Mutex
+	var events []MonitorEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Method = %q, want POST", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var e MonitorEvent
+		if err := json.Unmarshal(body, &e); err != nil {
+			t.Errorf("body is not a MonitorEvent: %v\n%s", err, body)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+		if len(statuses) > 0 {
+			w.WriteHeader(statuses[0])
+			statuses = statuses[1:]
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []MonitorEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]MonitorEvent(nil), events...)
+	}
+}
+
+func TestWebhookPostsEvents(t *testing.T) {
+	srv, events := webhookServer(t)
+	hook := newWebhook(srv.URL)
+
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	offline := DeviceState{Name: "phone", MAC: "EE:FF:00:11:22:33", IP: "192.168.1.101"}
+	online := offline
+	online.Connected = true
+	paused := online
+	paused.Paused = true
+
+	for _, e := range []MonitorEvent{
+		monitorEvent("eeff00112233", offline, online, false, now),
+		monitorEvent("eeff00112233", online, paused, false, now),
+		monitorEvent("eeff00112233", paused, offline, false, now),
+	} {
+		if err := hook.Send(e); err != nil {
+			t.Fatalf("Send error: %v", err)
+		}
+	}
+
+	got := events()
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want 3", len(got))
+	}
+	want := []string{"connected", "paused", "disconnected"}
+	for i, e := range got {
+		if e.Event != want[i] {
+			t.Errorf("events[%d].Event = %q, want %q", i, e.Event, want[i])
+		}
+		if e.DeviceID != "eeff00112233" || e.Name != "phone" || !e.Time.Equal(now) {
+			t.Errorf("events[%d] = %+v", i, e)
+		}
+	}
+	if got[0].Status != "online" || got[0].PreviousStatus != "offline" {
+		t.Errorf("events[0] status = %q from %q", got[0].Status, got[0].PreviousStatus)
+	}
+}
+
+func TestWebhookRetriesTransientFailures(t *testing.T) {
+	srv, events := webhookServer(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+	hook := newWebhook(srv.URL)
+	hook.RetryDelay = 0
+
+	if err := hook.Send(MonitorEvent{Event: "new"}); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	if n := len(events()); n != 3 {
+		t.Errorf("posted %d times, want 3", n)
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	srv, events := webhookServer(t, 500, 500, 500, 500)
+	hook := newWebhook(srv.URL)
+	hook.RetryDelay = 0
+
+	err := hook.Send(MonitorEvent{Event: "new"})
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("expected 500 error, got: %v", err)
+	}
+	if n := len(events()); n != webhookRetries+1 {
+		t.Errorf("posted %d times, want %d", n, webhookRetries+1)
+	}
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	srv, events := webhookServer(t, http.StatusNotFound)
+	hook := newWebhook(srv.URL)
+	hook.RetryDelay = 0
+
+	if err := hook.Send(MonitorEvent{Event: "new"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if n := len(events()); n != 1 {
+		t.Errorf("posted %d times, want 1", n)
+	}
+}
+
+func TestMonitorEventKinds(t *testing.T) {
+	base := DeviceState{Name: "NAS", IP: "192.168.1.10", Connected: true}
+	moved := base
+	moved.IP = "192.168.1.20"
+	private := base
+	private.IsPrivate = true
+
+	tests := []struct {
+		prev, curr DeviceState
+		isNew      bool
+		want       string
+	}{
+		{DeviceState{}, base, true, "new"},
+		{base, moved, false, "ip_changed"},
+		{base, private, false, "changed"},
+		{base, DeviceState{Blocked: true}, false, "blocked"},
+	}
+	for _, tt := range tests {
+		if got := monitorEvent("x", tt.prev, tt.curr, tt.isNew, time.Now()).Event; got != tt.want {
+			t.Errorf("event = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMonitorEventChanges(t *testing.T) {
+	base := DeviceState{Name: "NAS", IP: "192.168.1.10", Connected: true}
+	moved := base
+	moved.IP = "192.168.1.20"
+	moved.Connected = false
+
+	e := monitorEvent("x", base, moved, false, time.Now())
+	want := map[string]FieldChange{
+		"connected": {Before: true, After: false},
+		"ip":        {Before: "192.168.1.10", After: "192.168.1.20"},
+	}
+	if !reflect.DeepEqual(e.Changes, want) {
+		t.Errorf("Changes = %+v, want %+v", e.Changes, want)
+	}
+
+	if e := monitorEvent("x", DeviceState{}, base, true, time.Now()); e.Changes != nil {
+		t.Errorf("new device Changes = %+v, want nil", e.Changes)
+	}
+}
+
+func TestDevicesWebhookRejectsBadURL(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	err := app.Devices([]string{"monitor", "--webhook", "ftp://example.com/hook"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --webhook URL") {
+		t.Errorf("expected invalid URL error, got: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/dorin/eero-cli/internal/qr"
+)
+
+// qrPNGScale is the pixel size of one QR module in a PNG
+const qrPNGScale = 10
+
+// WiFi handles the wifi command
+func (a *App) WiFi(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: wifi qr [--png <path>]")
+	}
+
+	switch args[0] {
+	case "qr":
+		const usage = "usage: wifi qr [--png <path>]"
+		var pngPath string
+		for i := 1; i < len(args); i++ {
+			if args[i] == "--png" && i+1 < len(args) {
+				pngPath = args[i+1]
+				i++ // skip the value
+			} else if strings.HasPrefix(args[i], "--png=") {
+				pngPath = strings.TrimPrefix(args[i], "--png=")
+			} else {
+				return fmt.Errorf(usage)
+			}
+		}
+		return a.WiFiQR(pngPath)
+	default:
+		return fmt.Errorf("unknown wifi subcommand: %s", args[0])
+	}
+}
+
+// WiFiQR prints a QR code that joins the main WiFi network, and saves it as
+// a PNG when pngPath is set. The API doesn't always return the password, so
+// it is asked for (without echo) when missing.
+func (a *App) WiFiQR(pngPath string) error {
+	networkID, err := a.EnsureNetwork()
+	if err != nil {
+		return err
+	}
+
+	settings, err := a.Client.GetNetworkSettings(networkID)
+	if err != nil {
+		return fmt.Errorf("getting network settings: %w", err)
+	}
+	if settings.Name == "" {
+		return fmt.Errorf("the network has no WiFi name")
+	}
+
+	password := settings.Password
+	if password == "" {
+		password = PromptSecret(a.context(), fmt.Sprintf("WiFi password for %s: ", settings.Name))
+		if password == "" {
+			return fmt.Errorf("the API did not return the WiFi password and none was entered")
+		}
+	}
+
+	code, err := qr.Encode([]byte(wifiPayload(settings.Name, password)))
+	if err != nil {
+		return err
+	}
+
+	if pngPath != "" {
+		if err := writeQRPNG(code, pngPath); err != nil {
+			return err
+		}
+	}
+
+	a.printQR(code)
+	fmt.Printf("Scan to join %s\n", settings.Name)
+	if pngPath != "" {
+		fmt.Printf("Saved QR code to %s\n", pngPath)
+	}
+	return nil
+}
+
+// wifiPayload builds the WIFI: string that phone cameras recognize as a
+// network to join
+func wifiPayload(ssid, password string) string {
+	return fmt.Sprintf("WIFI:T:WPA;S:%s;P:%s;;", wifiEscape(ssid), wifiEscape(password))
+}
+
+// wifiEscape backslash-escapes the characters that delimit WIFI: fields
+func wifiEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`\;,:"`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// printQR prints a QR code to the terminal. With color enabled it is drawn
+// white on black so it scans on light terminal themes too.
+func (a *App) printQR(code *qr.Code) {
+	for _, line := range strings.SplitAfter(code.Terminal(), "\n") {
+		if line == "" {
+			continue
+		}
+		if a.Color {
+			line = colorQR + strings.TrimSuffix(line, "\n") + colorReset + "\n"
+		}
+		fmt.Print(line)
+	}
+}
+
+// writeQRPNG saves a QR code as a black-on-white PNG. The file is private,
+// since the code contains the WiFi password.
+func writeQRPNG(code *qr.Code, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("writing PNG: %w", err)
+	}
+	if err := png.Encode(f, code.Image(qrPNGScale)); err != nil {
+		f.Close()
+		return fmt.Errorf("writing PNG: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing PNG: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dorin/eero-cli/internal/api"
+	"github.com/dorin/eero-cli/internal/qr"
+)
+
+func TestWiFiPayload(t *testing.T) {
+	tests := []struct {
+		ssid, password string
+		want           string
+	}{
+		{"Home", "hunter22", "WIFI:T:WPA;S:Home;P:hunter22;;"},
+		{`Cafe;Bar`, `a:b,c"d\e`, `WIFI:T:WPA;S:Cafe\;Bar;P:a\:b\,c\"d\\e;;`},
+	}
+
+	for _, tt := range tests {
+		if got := wifiPayload(tt.ssid, tt.password); got != tt.want {
+			t.Errorf("wifiPayload(%q, %q) = %q, want %q", tt.ssid, tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestWiFiQRUsesAPIPassword(t *testing.T) {
+	mock := &mockClient{
+		GetNetworkSettingsFn: func(networkID string) (*api.NetworkSettings, error) {
+			return &api.NetworkSettings{Name: "Home", Password: "hunter22"}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	output := captureStdout(t, func() {
+		err = app.WiFi([]string{"qr"})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	code, _ := qr.Encode([]byte("WIFI:T:WPA;S:Home;P:hunter22;;"))
+	if !strings.HasPrefix(output, code.Terminal()) {
+		t.Errorf("output should start with the QR code, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Scan to join Home") {
+		t.Errorf("output missing the network name:\n%s", output)
+	}
+}
+
+func TestWiFiQRPromptsForPassword(t *testing.T) {
+	mock := &mockClient{
+		GetNetworkSettingsFn: func(networkID string) (*api.NetworkSettings, error) {
+			return &api.NetworkSettings{Name: "Home"}, nil
+		},
+	}
+	app := newTestApp(mock)
+	path := filepath.Join(t.TempDir(), "wifi.png")
+
+	var err error
+	output := captureStdout(t, func() {
+		feedStdin(t, "hunter22\n", func() {
+			err = app.WiFi([]string{"qr", "--png", path})
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "WiFi password for Home: ") {
+		t.Errorf("expected a password prompt, got:\n%s", output)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("PNG not written: %v", err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("decoding PNG: %v", err)
+	}
+
+	code, _ := qr.Encode([]byte("WIFI:T:WPA;S:Home;P:hunter22;;"))
+	side := (code.Size + 2*qr.QuietZone) * qrPNGScale
+	if b := img.Bounds(); b.Dx() != side || b.Dy() != side {
+		t.Errorf("PNG bounds = %v, want %dx%d", b, side, side)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("PNG mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestWiFiQRNoPassword(t *testing.T) {
+	mock := &mockClient{
+		GetNetworkSettingsFn: func(networkID string) (*api.NetworkSettings, error) {
+			return &api.NetworkSettings{Name: "Home"}, nil
+		},
+	}
+	app := newTestApp(mock)
+
+	var err error
+	captureStdout(t, func() {
+		feedStdin(t, "\n", func() {
+			err = app.WiFi([]string{"qr"})
+		})
+	})
+	if err == nil || !strings.Contains(err.Error(), "did not return the WiFi password") {
+		t.Errorf("expected a missing password error, got %v", err)
+	}
+}
+
+func TestWiFiUsage(t *testing.T) {
+	app := newTestApp(&mockClient{})
+
+	for _, args := range [][]string{{}, {"qr", "--png"}, {"qr", "--size", "3"}} {
+		if err := app.WiFi(args); err == nil || !strings.HasPrefix(err.Error(), "usage:") {
+			t.Errorf("WiFi(%q): expected a usage error, got %v", args, err)
+		}
+	}
+	if err := app.WiFi([]string{"show"}); err == nil || !strings.Contains(err.Error(), "unknown wifi subcommand") {
+		t.Errorf("expected an unknown subcommand error, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultAccount is the account used until another is added. Configs from
+// before accounts existed are migrated into it.
+const DefaultAccount = "default"
+
+// Account is a saved login: its token and selected network
+type Account struct {
+	Token     string `json:"token,omitempty"`
+	NetworkID string `json:"network_id,omitempty"`
+}
+
+// ActiveAccount returns the name of the account in use
+func (c *Config) ActiveAccount() string {
+	if c.Active == "" {
+		return DefaultAccount
+	}
+	return c.Active
+}
+
+// HasAccount reports whether name is a saved account or the active one
+func (c *Config) HasAccount(name string) bool {
+	_, ok := c.Accounts[name]
+	return ok || name == c.ActiveAccount()
+}
+
+// AccountNames returns the saved accounts and the active one, sorted
+func (c *Config) AccountNames() []string {
+	names := []string{c.ActiveAccount()}
+	for name := range c.Accounts {
+		if name != c.ActiveAccount() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// AccountLoggedIn reports whether an account has a token, in the file or
+// the keychain. The active account's is Token, which EERO_TOKEN may set.
+func (c *Config) AccountLoggedIn(name string) bool {
+	if name == c.ActiveAccount() {
+		return c.HasToken()
+	}
+	if c.Accounts[name].Token != "" {
+		return true
+	}
+	if !c.UseKeyring {
+		return false
+	}
+	token, err := keyringToken(name)
+	return err == nil && token != ""
+}
+
+// AccountNetworkID returns the network an account uses
+func (c *Config) AccountNetworkID(name string) string {
+	if name == c.ActiveAccount() {
+		return c.NetworkID
+	}
+	return c.Accounts[name].NetworkID
+}
+
+// UseAccount makes name the active account, replacing Token and NetworkID
+// with its own. Check HasAccount first: an unknown name is active with no
+// token.
+func (c *Config) UseAccount(name string) {
+	c.Active = name
+	c.Token, c.NetworkID = "", ""
+	c.loadActive()
+}
+
+// AddAccount makes a new account active, with no token or network yet. It
+// is stored by the next Save, along with the previous account if that was
+// never saved.
+func (c *Config) AddAccount(name string) error {
+	if err := validAccountName(name); err != nil {
+		return err
+	}
+	if c.HasAccount(name) {
+		return fmt.Errorf("account %s already exists", name)
+	}
+
+	if _, ok := c.Accounts[c.ActiveAccount()]; !ok {
+		if c.Accounts == nil {
+			c.Accounts = make(map[string]Account)
+		}
+		previous := Account{NetworkID: c.NetworkID}
+		if !c.TokenFromEnv {
+			previous.Token = c.Token
+		}
+		c.Accounts[c.ActiveAccount()] = previous
+	}
+	c.Active = name
+	c.Token, c.NetworkID = "", ""
+	return nil
+}
+
+// validAccountName checks that an account name is usable as a keychain entry
+// and on the command line
+func validAccountName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t\n:/") {
+		return fmt.Errorf("invalid account name: %q (no spaces, colons, or slashes)", name)
+	}
+	return nil
+}
+
+// loadActive sets Token and NetworkID from the active account. A token
+// still in the file wins over the keychain: it was saved while the keychain
+// was unavailable, or before use_keyring was set. If the keychain can't be
+// read, Token stays empty; Save never deletes an entry, so it survives.
+func (c *Config) loadActive() {
+	if account, ok := c.Accounts[c.ActiveAccount()]; ok {
+		c.Token, c.NetworkID = account.Token, account.NetworkID
+	}
+	if c.UseKeyring && c.Token == "" {
+		if token, err := keyringToken(c.ActiveAccount()); err == nil {
+			c.Token = token
+		}
+	}
+}
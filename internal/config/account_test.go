@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestLoadMigratesTokenIntoDefaultAccount(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	os.MkdirAll(dir, 0700)
+	v1 := `{"version": 1, "token": "tok", "network_id": "12345"}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(v1), 0600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.ActiveAccount() != DefaultAccount || cfg.Token != "tok" || cfg.NetworkID != "12345" {
+		t.Errorf("loaded = %+v", cfg)
+	}
+
+	// The file now keeps the login under accounts only
+	var top map[string]json.RawMessage
+	file := readConfigFile(t, dir)
+	if err := json.Unmarshal([]byte(file), &top); err != nil {
+		t.Fatalf("parsing config file: %v", err)
+	}
+	if _, ok := top["token"]; ok || top["accounts"] == nil || string(top["active"]) != `"default"` {
+		t.Errorf("config file not migrated:\n%s", file)
+	}
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if reloaded.Accounts[DefaultAccount] != (Account{Token: "tok", NetworkID: "12345"}) {
+		t.Errorf("default account = %+v", reloaded.Accounts[DefaultAccount])
+	}
+}
+
+func TestSaveStoresActiveAccount(t *testing.T) {
+	useTempConfigDir(t)
+
+	cfg := &Config{Token: "home-token", NetworkID: "1"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if err := cfg.AddAccount("family"); err != nil {
+		t.Fatalf("AddAccount() error: %v", err)
+	}
+	cfg.Token, cfg.NetworkID = "family-token", "2"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.ActiveAccount() != "family" || loaded.Token != "family-token" || loaded.NetworkID != "2" {
+		t.Errorf("loaded = %+v, want the family account active", loaded)
+	}
+
+	loaded.UseAccount(DefaultAccount)
+	if loaded.Token != "home-token" || loaded.NetworkID != "1" {
+		t.Errorf("after UseAccount: token = %q, network = %q", loaded.Token, loaded.NetworkID)
+	}
+	if got := strings.Join(loaded.AccountNames(), ","); got != "default,family" {
+		t.Errorf("AccountNames() = %s", got)
+	}
+}
+
+func TestAddAccountRejectsBadNames(t *testing.T) {
+	cfg := &Config{Token: "tok"}
+	for _, name := range []string{"", "my account", "a:b", DefaultAccount} {
+		if err := cfg.AddAccount(name); err == nil {
+			t.Errorf("AddAccount(%q) expected error", name)
+		}
+	}
+	if cfg.Token != "tok" || cfg.ActiveAccount() != DefaultAccount {
+		t.Errorf("failed AddAccount changed the config: %+v", cfg)
+	}
+}
+
+func TestKeyringPerAccount(t *testing.T) {
+	keyring.MockInit()
+	dir := useTempConfigDir(t)
+
+	cfg := &Config{Token: "home-token", UseKeyring: true}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if err := cfg.AddAccount("family"); err != nil {
+		t.Fatalf("AddAccount() error: %v", err)
+	}
+	cfg.Token = "family-token"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	if file := readConfigFile(t, dir); strings.Contains(file, "-token") {
+		t.Errorf("config file contains a token:\n%s", file)
+	}
+	// The default account keeps the entry from before accounts existed
+	if got, _ := keyring.Get(appName, keyringUser); got != "home-token" {
+		t.Errorf("default keychain token = %q", got)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Token != "family-token" || !loaded.AccountLoggedIn(DefaultAccount) {
+		t.Errorf("loaded token = %q, default logged in = %v", loaded.Token, loaded.AccountLoggedIn(DefaultAccount))
+	}
+	loaded.UseAccount(DefaultAccount)
+	if loaded.Token != "home-token" {
+		t.Errorf("default account token = %q, want home-token from the keychain", loaded.Token)
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheDir returns the directory holding cached API responses, alongside
+// the config file
+func CacheDir() (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "cache"), nil
+}
+
+// WriteCache stores data under name in the cache directory
+func WriteCache(name string, data []byte) error {
+	if ReadOnly() {
+		return ErrReadOnly
+	}
+	dir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, name), data)
+}
+
+// ReadCache returns the data cached under name and when it was written
+func ReadCache(name string) ([]byte, time.Time, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	path := filepath.Join(dir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return data, info.ModTime(), nil
+}
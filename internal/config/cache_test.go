@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	before := time.Now().Add(-time.Second)
+	if err := WriteCache("devices-123.json", []byte(`[1,2,3]`)); err != nil {
+		t.Fatalf("WriteCache error: %v", err)
+	}
+
+	data, modTime, err := ReadCache("devices-123.json")
+	if err != nil {
+		t.Fatalf("ReadCache error: %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("data = %q", data)
+	}
+	if modTime.Before(before) {
+		t.Errorf("modTime = %v, want after %v", modTime, before)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "cache", "devices-123.json"))
+	if err != nil {
+		t.Fatalf("cache file missing: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("cache file permissions = %o, want 600", perm)
+	}
+}
+
+func TestReadCacheMissing(t *testing.T) {
+	useTempConfigDir(t)
+
+	_, _, err := ReadCache("nothing.json")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ReadCache error = %v, want not exist", err)
+	}
+}
@@ -4,9 +4,14 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -14,9 +19,126 @@ const (
 	configFile = "config.json"
 )
 
+// CurrentVersion is the config file format written by this build
+const CurrentVersion = 2
+
+// Environment variables that override the stored token and network ID
+const (
+	EnvToken     = "EERO_TOKEN"
+	EnvNetworkID = "EERO_NETWORK_ID"
+)
+
+// EnvReadOnly, when set to a true value, has the same effect as SetReadOnly
+const EnvReadOnly = "EERO_READ_ONLY"
+
+// EnvAPIURL overrides the eero API base URL, like --api-url
+const EnvAPIURL = "EERO_API_URL"
+
+// ErrReadOnly is returned by writes to the config directory in read-only mode
+var ErrReadOnly = errors.New("config is read-only (--read-only or " + EnvReadOnly + " is set)")
+
+// readOnly is set by SetReadOnly
+var readOnly bool
+
+// SetReadOnly makes every write to the config directory (the config file,
+// its lock, the cache, and the IP history) fail with ErrReadOnly
+func SetReadOnly(on bool) {
+	readOnly = on
+}
+
+// ReadOnly reports whether writes to the config directory are disabled
+func ReadOnly() bool {
+	if readOnly {
+		return true
+	}
+	on, _ := strconv.ParseBool(os.Getenv(EnvReadOnly))
+	return on
+}
+
 type Config struct {
-	Token     string `json:"token"`
-	NetworkID string `json:"network_id"`
+	// Version is the file format version; Load migrates older files
+	Version int `json:"version"`
+
+	// Token and NetworkID belong to the active account. The file keeps
+	// them under accounts; Load and Save move them in and out.
+	Token     string         `json:"token,omitempty"`
+	NetworkID string         `json:"network_id,omitempty"`
+	Client    ClientSettings `json:"client,omitzero"`
+
+	// Accounts maps account names to their saved token and network, and
+	// Active names the one in use (DefaultAccount when empty)
+	Accounts map[string]Account `json:"accounts,omitempty"`
+	Active   string             `json:"active,omitempty"`
+
+	// DeviceAliases maps short names to device IDs
+	DeviceAliases map[string]string `json:"device_aliases,omitempty"`
+
+	// ScheduledUnblocks are temporary blocks awaiting their expiry
+	ScheduledUnblocks []ScheduledUnblock `json:"scheduled_unblocks,omitempty"`
+
+	// UseKeyring keeps the token in the OS keychain instead of this file
+	UseKeyring bool `json:"use_keyring,omitempty"`
+
+	// TokenFromEnv is set by ApplyEnv when Token came from EERO_TOKEN
+	TokenFromEnv bool `json:"-"`
+}
+
+// ScheduledUnblock records a device to unblock once At has passed
+type ScheduledUnblock struct {
+	NetworkID string    `json:"network_id"`
+	DeviceID  string    `json:"device_id"`
+	Name      string    `json:"name,omitempty"`
+	At        time.Time `json:"at"`
+	// Account is the account that blocked the device, whose token can
+	// unblock it. Entries from before accounts existed have none and belong
+	// to DefaultAccount.
+	Account string `json:"account,omitempty"`
+}
+
+// AccountName returns the account that scheduled the unblock
+func (u ScheduledUnblock) AccountName() string {
+	if u.Account == "" {
+		return DefaultAccount
+	}
+	return u.Account
+}
+
+// ClientSettings tunes the API client. Zero values use the built-in defaults.
+type ClientSettings struct {
+	Timeout       Duration `json:"timeout,omitzero"`
+	Retries       int      `json:"retries,omitzero"`
+	RetryDelay    Duration `json:"retry_delay,omitzero"`
+	RateLimitWait Duration `json:"rate_limit_wait,omitzero"`
+	PinSHA256     string   `json:"pin_sha256,omitempty"`
+}
+
+// Duration is a time.Duration stored in JSON as a string like "45s".
+// Plain numbers are accepted as seconds.
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes a duration string or a number of seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		*d = Duration(v)
+		return nil
+	}
+
+	var secs float64
+	if err := json.Unmarshal(data, &secs); err != nil {
+		return fmt.Errorf("invalid duration: %s", data)
+	}
+	*d = Duration(secs * float64(time.Second))
+	return nil
 }
 
 // ConfigPath returns the path to the config file following platform conventions
@@ -61,7 +183,7 @@ func Load() (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return &Config{}, nil
+			return &Config{Version: CurrentVersion}, nil
 		}
 		return nil, err
 	}
@@ -71,11 +193,67 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	// Migrate before reading the keychain, which is per account
+	migrated := cfg.migrate()
+	cfg.loadActive()
+
+	if migrated {
+		// Best effort: callers may hold the config lock, so this writes
+		// without taking it, and a read-only config (file or mode) still
+		// loads. Migrations are idempotent, so a lost write is simply redone
+		// next time.
+		_ = cfg.Save()
+	}
+
 	return &cfg, nil
 }
 
-// Save writes the configuration to disk
+// migrations[v] upgrades a config from version v to v+1
+var migrations = []func(c *Config){
+	// 0 → 1: alias lookups expect lowercase keys, so lowercase any that
+	// were edited by hand
+	func(c *Config) {
+		for alias, deviceID := range c.DeviceAliases {
+			if lower := strings.ToLower(alias); lower != alias {
+				delete(c.DeviceAliases, alias)
+				c.DeviceAliases[lower] = deviceID
+			}
+		}
+	},
+	// 1 → 2: the token and network ID move into the default account
+	func(c *Config) {
+		if c.Token == "" && c.NetworkID == "" {
+			return
+		}
+		if c.Accounts == nil {
+			c.Accounts = make(map[string]Account)
+		}
+		c.Accounts[DefaultAccount] = Account{Token: c.Token, NetworkID: c.NetworkID}
+		c.Active = DefaultAccount
+	},
+}
+
+// migrate upgrades c to CurrentVersion, reporting whether it changed. Files
+// from a newer build are left untouched.
+func (c *Config) migrate() bool {
+	if c.Version >= CurrentVersion {
+		return false
+	}
+	for v := max(c.Version, 0); v < CurrentVersion; v++ {
+		migrations[v](c)
+	}
+	c.Version = CurrentVersion
+	return true
+}
+
+// Save writes the configuration to disk, storing Token and NetworkID as the
+// active account's. With UseKeyring, tokens go to the OS keychain and the
+// file keeps the rest; if the keychain is unavailable, they stay in the
+// file.
 func (c *Config) Save() error {
+	if ReadOnly() {
+		return ErrReadOnly
+	}
 	path, err := ConfigPath()
 	if err != nil {
 		return err
@@ -86,12 +264,114 @@ func (c *Config) Save() error {
 		return err
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
+	name := c.ActiveAccount()
+	file := *c
+	file.Token, file.NetworkID = "", ""
+	file.Accounts = maps.Clone(c.Accounts)
+	if file.Accounts == nil {
+		file.Accounts = make(map[string]Account)
+	}
+	file.Accounts[name] = Account{Token: c.Token, NetworkID: c.NetworkID}
+	file.Active = name
+
+	// An empty token leaves the keychain alone: it may only mean the entry
+	// couldn't be read. Clear is what deletes it.
+	if c.UseKeyring {
+		for n, account := range file.Accounts {
+			if account.Token != "" && setKeyringToken(n, account.Token) == nil {
+				account.Token = ""
+				file.Accounts[n] = account
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0600)
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	c.Accounts, c.Active = file.Accounts, file.Active
+	return nil
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and
+// renames it over path, so readers never observe a partially written file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// Lock takes an exclusive advisory lock on the config file, blocking until
+// it is available. Callers doing a read-modify-write should hold the lock
+// across Load and Save. The returned function releases the lock.
+func Lock() (func(), error) {
+	if ReadOnly() {
+		return nil, ErrReadOnly
+	}
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// ApplyEnv overrides the token and network ID with EERO_TOKEN and
+// EERO_NETWORK_ID when they are set, for CI and other headless use. Apply
+// it to the in-memory config only: Load does not, so locked
+// read-modify-write updates never persist the overrides.
+func (c *Config) ApplyEnv() {
+	if token := os.Getenv(EnvToken); token != "" {
+		c.Token = token
+		c.TokenFromEnv = true
+	}
+	if networkID := os.Getenv(EnvNetworkID); networkID != "" {
+		c.NetworkID = networkID
+	}
 }
 
 // HasToken returns true if a token is configured
@@ -99,9 +379,21 @@ func (c *Config) HasToken() bool {
 	return c.Token != ""
 }
 
-// Clear removes the stored token and network ID
+// Clear removes the active account's stored token and network ID, deleting
+// its keychain entry with UseKeyring
 func (c *Config) Clear() error {
+	if ReadOnly() {
+		return ErrReadOnly
+	}
 	c.Token = ""
 	c.NetworkID = ""
-	return c.Save()
+	if err := c.Save(); err != nil {
+		return err
+	}
+	if c.UseKeyring {
+		if err := setKeyringToken(c.ActiveAccount(), ""); err != nil {
+			return fmt.Errorf("deleting the keychain token: %w", err)
+		}
+	}
+	return nil
 }
@@ -1,9 +1,16 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestConfigPath(t *testing.T) {
@@ -84,3 +91,322 @@ func TestConfigClear(t *testing.T) {
 		t.Error("Cleared config should not have network ID")
 	}
 }
+
+// useTempConfigDir points ConfigPath at a temp directory for the test
+func useTempConfigDir(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("config directory override requires XDG_CONFIG_HOME (linux)")
+	}
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	return filepath.Join(dir, appName)
+}
+
+func TestConfigSaveAtomic(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	cfg := &Config{Token: "test-token-123", NetworkID: "network-456"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Token != cfg.Token || loaded.NetworkID != cfg.NetworkID {
+		t.Errorf("Load() = %+v, want %+v", loaded, cfg)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, configFile))
+	if err != nil {
+		t.Fatalf("stat config: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("config mode = %o, want 600", perm)
+	}
+
+	// No temp files should be left behind
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if e.Name() != configFile {
+			t.Errorf("unexpected file left in config dir: %s", e.Name())
+		}
+	}
+}
+
+func TestConfigConcurrentSaves(t *testing.T) {
+	useTempConfigDir(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cfg := &Config{
+				Token:     fmt.Sprintf("token-%d-%s", i, strings.Repeat("x", i*100)),
+				NetworkID: fmt.Sprintf("network-%d", i),
+			}
+			if err := cfg.Save(); err != nil {
+				t.Errorf("Save() error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("config corrupted after concurrent saves: %v", err)
+	}
+	if loaded.NetworkID == "" {
+		t.Error("NetworkID is empty after concurrent saves")
+	}
+}
+
+func TestLockIsExclusive(t *testing.T) {
+	useTempConfigDir(t)
+
+	unlock, err := Lock()
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		unlock2, err := Lock()
+		if err != nil {
+			t.Errorf("second Lock() error: %v", err)
+			close(acquired)
+			return
+		}
+		close(acquired)
+		unlock2()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second Lock() acquired while the first was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+
+	select {
+	case <-acquired:
+	case <-time.After(2 * time.Second):
+		t.Fatal("second Lock() not acquired after unlock")
+	}
+}
+
+func TestClientSettingsRoundTrip(t *testing.T) {
+	useTempConfigDir(t)
+
+	cfg := &Config{
+		Token: "tok",
+		Client: ClientSettings{
+			Timeout:    Duration(45 * time.Second),
+			Retries:    3,
+			RetryDelay: Duration(500 * time.Millisecond),
+		},
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Client != cfg.Client {
+		t.Errorf("Client = %+v, want %+v", loaded.Client, cfg.Client)
+	}
+}
+
+func TestClientSettingsOmittedWhenUnset(t *testing.T) {
+	data, err := json.Marshal(&Config{Token: "tok"})
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if strings.Contains(string(data), "client") {
+		t.Errorf("unset client settings should be omitted: %s", data)
+	}
+}
+
+func TestDurationUnmarshal(t *testing.T) {
+	var s ClientSettings
+	if err := json.Unmarshal([]byte(`{"timeout": "1m30s", "retry_delay": 2}`), &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if time.Duration(s.Timeout) != 90*time.Second {
+		t.Errorf("Timeout = %v, want 1m30s", time.Duration(s.Timeout))
+	}
+	if time.Duration(s.RetryDelay) != 2*time.Second {
+		t.Errorf("RetryDelay = %v, want 2s (numbers are seconds)", time.Duration(s.RetryDelay))
+	}
+
+	if err := json.Unmarshal([]byte(`{"timeout": "soon"}`), &s); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
+
+func TestLoadMigratesUnversionedConfig(t *testing.T) {
+	useTempConfigDir(t)
+
+	path, err := ConfigPath()
+	if err != nil {
+		t.Fatalf("ConfigPath() error: %v", err)
+	}
+	os.MkdirAll(filepath.Dir(path), 0700)
+	v0 := `{"token": "tok", "network_id": "12345", "device_aliases": {"NAS": "112233445566", "tv": "aabbccddeeff"}}`
+	if err := os.WriteFile(path, []byte(v0), 0600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentVersion)
+	}
+	if cfg.Token != "tok" || cfg.NetworkID != "12345" {
+		t.Errorf("migration lost fields: %+v", cfg)
+	}
+	want := map[string]string{"nas": "112233445566", "tv": "aabbccddeeff"}
+	if fmt.Sprint(cfg.DeviceAliases) != fmt.Sprint(want) {
+		t.Errorf("DeviceAliases = %v, want %v", cfg.DeviceAliases, want)
+	}
+
+	// The migrated shape is written back
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	var saved Config
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("parsing saved config: %v", err)
+	}
+	if saved.Version != CurrentVersion || saved.DeviceAliases["nas"] != "112233445566" {
+		t.Errorf("saved config not migrated: %s", data)
+	}
+}
+
+func TestLoadLeavesNewerConfigAlone(t *testing.T) {
+	useTempConfigDir(t)
+
+	path, _ := ConfigPath()
+	os.MkdirAll(filepath.Dir(path), 0700)
+	data := fmt.Sprintf(`{"version": %d, "token": "tok", "device_aliases": {"NAS": "x"}}`, CurrentVersion+1)
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Version != CurrentVersion+1 || cfg.DeviceAliases["NAS"] != "x" {
+		t.Errorf("newer config was modified: %+v", cfg)
+	}
+}
+
+func TestApplyEnvOverridesConfig(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv(EnvToken, "env-token")
+	t.Setenv(EnvNetworkID, "67890")
+
+	// No config file exists
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.HasToken() {
+		t.Fatal("Load() must not apply environment overrides itself")
+	}
+
+	cfg.ApplyEnv()
+	if !cfg.HasToken() || cfg.Token != "env-token" || !cfg.TokenFromEnv {
+		t.Errorf("Token = %q, TokenFromEnv = %v, want env-token from env", cfg.Token, cfg.TokenFromEnv)
+	}
+	if cfg.NetworkID != "67890" {
+		t.Errorf("NetworkID = %q, want 67890", cfg.NetworkID)
+	}
+
+	data, _ := json.Marshal(cfg)
+	if strings.Contains(string(data), "TokenFromEnv") || strings.Contains(string(data), "token_from_env") {
+		t.Errorf("TokenFromEnv must not be persisted: %s", data)
+	}
+}
+
+func TestApplyEnvKeepsStoredValuesWhenUnset(t *testing.T) {
+	t.Setenv(EnvToken, "")
+	t.Setenv(EnvNetworkID, "")
+
+	cfg := &Config{Token: "file-token", NetworkID: "12345"}
+	cfg.ApplyEnv()
+	if cfg.Token != "file-token" || cfg.NetworkID != "12345" || cfg.TokenFromEnv {
+		t.Errorf("config changed with no env vars set: %+v", cfg)
+	}
+}
+
+func TestReadOnlyBlocksWrites(t *testing.T) {
+	dir := useTempConfigDir(t)
+	SetReadOnly(true)
+	t.Cleanup(func() { SetReadOnly(false) })
+
+	cfg := &Config{Token: "tok", NetworkID: "12345"}
+	if err := cfg.Save(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Save error = %v, want ErrReadOnly", err)
+	}
+	if err := cfg.Clear(); !errors.Is(err, ErrReadOnly) || cfg.Token != "tok" {
+		t.Errorf("Clear = %v with token %q, want ErrReadOnly and the token kept", err, cfg.Token)
+	}
+	if _, err := Lock(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Lock error = %v, want ErrReadOnly", err)
+	}
+	if err := WriteCache("x.json", []byte("{}")); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("WriteCache error = %v, want ErrReadOnly", err)
+	}
+	if err := (IPHistory{}).Save(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("IPHistory.Save error = %v, want ErrReadOnly", err)
+	}
+
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("config directory was created in read-only mode: %v", err)
+	}
+}
+
+func TestReadOnlyFromEnv(t *testing.T) {
+	t.Setenv(EnvReadOnly, "1")
+	if !ReadOnly() {
+		t.Error("ReadOnly() = false with EERO_READ_ONLY=1")
+	}
+	t.Setenv(EnvReadOnly, "0")
+	if ReadOnly() {
+		t.Error("ReadOnly() = true with EERO_READ_ONLY=0")
+	}
+}
+
+func TestLoadDoesNotMigrateReadOnly(t *testing.T) {
+	dir := useTempConfigDir(t)
+	os.MkdirAll(dir, 0700)
+	path := filepath.Join(dir, configFile)
+	original := `{"token": "tok", "device_aliases": {"NAS": "112233445566"}}`
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvReadOnly, "true")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if cfg.DeviceAliases["nas"] != "112233445566" {
+		t.Errorf("aliases = %v, want the migrated in-memory config", cfg.DeviceAliases)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != original {
+		t.Errorf("config file rewritten in read-only mode:\n%s", data)
+	}
+}
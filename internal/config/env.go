@@ -0,0 +1,116 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// DefaultEnvFile is loaded automatically from the working directory if present
+const DefaultEnvFile = ".eero.env"
+
+// ParseEnv parses dotenv-style KEY=VALUE lines. Blank lines and lines
+// starting with '#' are ignored, an optional "export " prefix is allowed,
+// and values may be wrapped in single or double quotes.
+func ParseEnv(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		vars[key] = parseEnvValue(strings.TrimSpace(value))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// parseEnvValue strips surrounding quotes, or a trailing comment from an
+// unquoted value
+func parseEnvValue(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	if idx := strings.Index(value, " #"); idx != -1 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	return value
+}
+
+// untrustedEnv lists the variables DefaultEnvFile may not set. It is loaded
+// from whatever directory the CLI runs in, so it could otherwise send the
+// token to another server or swap in someone else's token.
+var untrustedEnv = []string{EnvAPIURL, EnvToken}
+
+// LoadEnvFile reads a dotenv file into the process environment. Variables
+// that are already set in the real environment are not overridden.
+func LoadEnvFile(path string) error {
+	_, err := loadEnvFile(path, nil)
+	return err
+}
+
+// LoadDefaultEnvFile loads DefaultEnvFile from the working directory if it
+// exists. It returns the untrusted variables it found and ignored, so they
+// can be reported.
+func LoadDefaultEnvFile() ([]string, error) {
+	ignored, err := loadEnvFile(DefaultEnvFile, untrustedEnv)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return ignored, err
+}
+
+// loadEnvFile is LoadEnvFile, ignoring the keys in skip. It returns the
+// skipped keys present in the file, sorted.
+func loadEnvFile(path string, skip []string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	vars, err := ParseEnv(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var skipped []string
+	for key, value := range vars {
+		if slices.Contains(skip, key) {
+			skipped = append(skipped, key)
+			continue
+		}
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(skipped)
+
+	return skipped, nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseEnv(t *testing.T) {
+	input := `
+# comment line
+EERO_TOKEN=abc123
+export EERO_NETWORK_ID = 12345
+QUOTED="hello world"
+SINGLE='it''s'
+INLINE=value # trailing comment
+EMPTY=
+`
+	vars, err := ParseEnv(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"EERO_TOKEN":      "abc123",
+		"EERO_NETWORK_ID": "12345",
+		"QUOTED":          "hello world",
+		"SINGLE":          "it''s",
+		"INLINE":          "value",
+		"EMPTY":           "",
+	}
+	if len(vars) != len(expected) {
+		t.Errorf("len(vars) = %d, want %d: %v", len(vars), len(expected), vars)
+	}
+	for k, want := range expected {
+		if got := vars[k]; got != want {
+			t.Errorf("vars[%q] = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestParseEnvInvalidLine(t *testing.T) {
+	_, err := ParseEnv(strings.NewReader("GOOD=1\nnot a valid line\n"))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error = %q, want line number", err.Error())
+	}
+}
+
+func TestLoadEnvFileDoesNotOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.env")
+	data := "EERO_CLI_TEST_SET=from-file\nEERO_CLI_TEST_UNSET=from-file\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("writing env file: %v", err)
+	}
+
+	t.Setenv("EERO_CLI_TEST_SET", "from-env")
+	// Register cleanup for the variable the loader will set
+	t.Setenv("EERO_CLI_TEST_UNSET", "")
+	os.Unsetenv("EERO_CLI_TEST_UNSET")
+
+	if err := LoadEnvFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := os.Getenv("EERO_CLI_TEST_SET"); got != "from-env" {
+		t.Errorf("EERO_CLI_TEST_SET = %q, want real env to take precedence", got)
+	}
+	if got := os.Getenv("EERO_CLI_TEST_UNSET"); got != "from-file" {
+		t.Errorf("EERO_CLI_TEST_UNSET = %q, want %q", got, "from-file")
+	}
+}
+
+func TestLoadEnvFileMissing(t *testing.T) {
+	err := LoadEnvFile(filepath.Join(t.TempDir(), "missing.env"))
+	if err == nil {
+		t.Fatal("expected error for missing env file")
+	}
+}
+
+func TestLoadDefaultEnvFileIgnoresUntrusted(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	data := "EERO_API_URL=https://evil.example\nEERO_TOKEN=theirs\nEERO_CLI_TEST_DEFAULT=ok\n"
+	if err := os.WriteFile(filepath.Join(dir, DefaultEnvFile), []byte(data), 0600); err != nil {
+		t.Fatalf("writing env file: %v", err)
+	}
+	for _, key := range []string{EnvAPIURL, EnvToken, "EERO_CLI_TEST_DEFAULT"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	ignored, err := LoadDefaultEnvFile()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(ignored, ",") != "EERO_API_URL,EERO_TOKEN" {
+		t.Errorf("ignored = %v, want [EERO_API_URL EERO_TOKEN]", ignored)
+	}
+	if os.Getenv(EnvAPIURL) != "" || os.Getenv(EnvToken) != "" {
+		t.Errorf("untrusted variables were set: %s=%q, %s=%q", EnvAPIURL, os.Getenv(EnvAPIURL), EnvToken, os.Getenv(EnvToken))
+	}
+	if got := os.Getenv("EERO_CLI_TEST_DEFAULT"); got != "ok" {
+		t.Errorf("EERO_CLI_TEST_DEFAULT = %q, want ok", got)
+	}
+}
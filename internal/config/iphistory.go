@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const ipHistoryFile = "ip_history.json"
+
+// MaxIPHistory is how many IP changes are kept per device
+const MaxIPHistory = 10
+
+// IPObservation records a device being seen with a new IP address
+type IPObservation struct {
+	IP   string    `json:"ip"`
+	Seen time.Time `json:"seen"`
+}
+
+// IPHistory maps lowercase device MACs to the IPs they have been seen with,
+// oldest first. It is stored alongside the config file.
+type IPHistory map[string][]IPObservation
+
+// IPHistoryPath returns the path to the IP history file
+func IPHistoryPath() (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), ipHistoryFile), nil
+}
+
+// LoadIPHistory reads the IP history, returning an empty one if none exists
+func LoadIPHistory() (IPHistory, error) {
+	path, err := IPHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return IPHistory{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h := IPHistory{}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Save writes the IP history to disk
+func (h IPHistory) Save() error {
+	if ReadOnly() {
+		return ErrReadOnly
+	}
+	path, err := IPHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// Record notes that the device with the given MAC was seen with ip at now.
+// Only changes are kept, up to MaxIPHistory per device. It reports whether
+// the history changed.
+func (h IPHistory) Record(mac, ip string, now time.Time) bool {
+	if mac == "" || ip == "" {
+		return false
+	}
+	mac = strings.ToLower(mac)
+
+	seen := h[mac]
+	if len(seen) > 0 && seen[len(seen)-1].IP == ip {
+		return false
+	}
+	seen = append(seen, IPObservation{IP: ip, Seen: now})
+	if len(seen) > MaxIPHistory {
+		seen = seen[len(seen)-MaxIPHistory:]
+	}
+	h[mac] = seen
+	return true
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIPHistoryRecordKeepsChanges(t *testing.T) {
+	h := IPHistory{}
+	t0 := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	if !h.Record("AA:BB:CC:DD:11:22", "192.168.1.100", t0) {
+		t.Error("first sighting should be recorded")
+	}
+	if h.Record("aa:bb:cc:dd:11:22", "192.168.1.100", t0.Add(time.Hour)) {
+		t.Error("same IP again should not be recorded")
+	}
+	if !h.Record("aa:bb:cc:dd:11:22", "192.168.1.150", t0.Add(2*time.Hour)) {
+		t.Error("a new IP should be recorded")
+	}
+	if h.Record("", "192.168.1.5", t0) || h.Record("aa:bb", "", t0) {
+		t.Error("missing MAC or IP should not be recorded")
+	}
+
+	seen := h["aa:bb:cc:dd:11:22"]
+	if len(seen) != 2 || seen[0].IP != "192.168.1.100" || seen[1].IP != "192.168.1.150" {
+		t.Fatalf("history = %+v", seen)
+	}
+	if !seen[1].Seen.Equal(t0.Add(2 * time.Hour)) {
+		t.Errorf("Seen = %v, want the time of the change", seen[1].Seen)
+	}
+}
+
+func TestIPHistoryRecordIsCapped(t *testing.T) {
+	h := IPHistory{}
+	t0 := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	for i := range MaxIPHistory + 5 {
+		h.Record("aa", fmt.Sprintf("10.0.0.%d", i), t0.Add(time.Duration(i)*time.Minute))
+	}
+
+	seen := h["aa"]
+	if len(seen) != MaxIPHistory {
+		t.Fatalf("len = %d, want %d", len(seen), MaxIPHistory)
+	}
+	if seen[0].IP != "10.0.0.5" || seen[len(seen)-1].IP != "10.0.0.14" {
+		t.Errorf("kept %s..%s, want the most recent", seen[0].IP, seen[len(seen)-1].IP)
+	}
+}
+
+func TestIPHistorySaveLoad(t *testing.T) {
+	useTempConfigDir(t)
+
+	h, err := LoadIPHistory()
+	if err != nil || len(h) != 0 {
+		t.Fatalf("LoadIPHistory() = %v, %v; want empty", h, err)
+	}
+
+	t0 := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	h.Record("aa", "10.0.0.1", t0)
+	if err := h.Save(); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	loaded, err := LoadIPHistory()
+	if err != nil {
+		t.Fatalf("LoadIPHistory error: %v", err)
+	}
+	if got := loaded["aa"]; len(got) != 1 || got[0].IP != "10.0.0.1" || !got[0].Seen.Equal(t0) {
+		t.Errorf("loaded = %+v", loaded)
+	}
+}
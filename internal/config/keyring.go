@@ -0,0 +1,44 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringUser is the keychain account under the appName service that holds
+// the default account's token
+const keyringUser = "token"
+
+// keyringUserFor returns the keychain account holding an account's token.
+// The default account keeps the entry used before there were accounts.
+func keyringUserFor(account string) string {
+	if account == DefaultAccount {
+		return keyringUser
+	}
+	return keyringUser + ":" + account
+}
+
+// keyringToken reads an account's token from the OS keychain. A missing
+// entry is an empty token, not an error.
+func keyringToken(account string) (string, error) {
+	token, err := keyring.Get(appName, keyringUserFor(account))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+	return token, err
+}
+
+// setKeyringToken stores an account's token in the OS keychain, deleting
+// the entry for an empty token
+func setKeyringToken(account, token string) error {
+	user := keyringUserFor(account)
+	if token == "" {
+		err := keyring.Delete(appName, user)
+		if errors.Is(err, keyring.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	return keyring.Set(appName, user, token)
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// readConfigFile returns the raw config file in dir
+func readConfigFile(t *testing.T, dir string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatalf("reading config file: %v", err)
+	}
+	return string(data)
+}
+
+func TestKeyringSaveLoad(t *testing.T) {
+	keyring.MockInit()
+	dir := useTempConfigDir(t)
+
+	cfg := &Config{Token: "secret-token", NetworkID: "12345", UseKeyring: true}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	if file := readConfigFile(t, dir); strings.Contains(file, "secret-token") {
+		t.Errorf("config file contains the token:\n%s", file)
+	}
+	if got, _ := keyring.Get(appName, keyringUser); got != "secret-token" {
+		t.Errorf("keychain token = %q, want secret-token", got)
+	}
+	if cfg.Token != "secret-token" {
+		t.Errorf("in-memory token = %q after Save", cfg.Token)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Token != "secret-token" || loaded.NetworkID != "12345" || !loaded.UseKeyring {
+		t.Errorf("loaded = %+v", loaded)
+	}
+
+	// Clearing the config deletes the keychain entry
+	if err := loaded.Clear(); err != nil {
+		t.Fatalf("Clear() error: %v", err)
+	}
+	if _, err := keyring.Get(appName, keyringUser); !errors.Is(err, keyring.ErrNotFound) {
+		t.Errorf("keychain entry after Clear: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestKeyringMigratesFileToken(t *testing.T) {
+	keyring.MockInit()
+	dir := useTempConfigDir(t)
+
+	// A token saved before use_keyring was set
+	if err := (&Config{Version: CurrentVersion, Token: "file-token"}).Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	cfg.UseKeyring = true
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	if file := readConfigFile(t, dir); strings.Contains(file, "file-token") {
+		t.Errorf("token left in the config file:\n%s", file)
+	}
+	if got, _ := keyring.Get(appName, keyringUser); got != "file-token" {
+		t.Errorf("keychain token = %q, want file-token", got)
+	}
+}
+
+func TestKeyringUnavailableFallsBackToFile(t *testing.T) {
+	keyring.MockInitWithError(errors.New("no secret service"))
+	dir := useTempConfigDir(t)
+
+	cfg := &Config{Token: "secret-token", UseKeyring: true}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if file := readConfigFile(t, dir); !strings.Contains(file, "secret-token") {
+		t.Errorf("token missing from the config file:\n%s", file)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Token != "secret-token" {
+		t.Errorf("loaded token = %q, want secret-token", loaded.Token)
+	}
+}
+
+func TestKeyringNotUsedByDefault(t *testing.T) {
+	keyring.MockInit()
+	dir := useTempConfigDir(t)
+
+	if err := (&Config{Token: "file-token"}).Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if file := readConfigFile(t, dir); !strings.Contains(file, "file-token") {
+		t.Errorf("token missing from the config file:\n%s", file)
+	}
+	if _, err := keyring.Get(appName, keyringUser); !errors.Is(err, keyring.ErrNotFound) {
+		t.Errorf("keychain used without use_keyring: err = %v", err)
+	}
+}
+
+func TestKeyringSaveKeepsUnreadEntry(t *testing.T) {
+	keyring.MockInit()
+	useTempConfigDir(t)
+	if err := keyring.Set(appName, keyringUser, "secret-token"); err != nil {
+		t.Fatalf("keyring.Set: %v", err)
+	}
+
+	// A config whose token couldn't be read from the keychain, saved for
+	// an unrelated change
+	cfg := &Config{Version: CurrentVersion, UseKeyring: true, DeviceAliases: map[string]string{"tv": "d1"}}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if got, _ := keyring.Get(appName, keyringUser); got != "secret-token" {
+		t.Errorf("keychain token = %q after Save, want secret-token", got)
+	}
+}
@@ -0,0 +1,521 @@
+// Package qr encodes short text as a QR code, e.g. a WiFi join code, and
+// renders it for a terminal or as an image
+package qr
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// QuietZone is the light border, in modules, that Terminal and Image draw
+// around the code so scanners can find it
+const QuietZone = 4
+
+// blockLayout is how a version's codewords split into error correction
+// blocks at level M
+type blockLayout struct {
+	ecLen  int   // error correction codewords per block
+	blocks []int // data codewords in each block, shortest first
+}
+
+// levelM holds the block layouts of versions 1 to 10 at error correction
+// level M, which recovers from about 15% damage
+var levelM = []blockLayout{
+	{10, []int{16}},
+	{16, []int{28}},
+	{26, []int{44}},
+	{18, []int{32, 32}},
+	{24, []int{43, 43}},
+	{16, []int{27, 27, 27, 27}},
+	{18, []int{31, 31, 31, 31}},
+	{22, []int{38, 38, 39, 39}},
+	{22, []int{36, 36, 36, 37, 37}},
+	{26, []int{43, 43, 43, 43, 44}},
+}
+
+// alignmentCenters holds the alignment pattern coordinates of versions 1
+// to 10; version 1 has none
+var alignmentCenters = [][]int{
+	nil,
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+}
+
+// MaxBytes is the most data Encode accepts: the byte-mode capacity of
+// version 10 at level M
+const MaxBytes = 213
+
+// Code is an encoded QR symbol
+type Code struct {
+	// Size is the width and height in modules, without the quiet zone
+	Size    int
+	modules [][]bool // [y][x], true is dark
+}
+
+// Dark reports whether the module at x, y is dark. Modules outside the
+// symbol, in the quiet zone, are light.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Encode encodes data in byte mode at error correction level M, using the
+// smallest version that fits
+func Encode(data []byte) (*Code, error) {
+	for version := 1; version <= len(levelM); version++ {
+		capacity := dataCapacity(version)
+		if 4+countBits(version)+8*len(data) <= capacity*8 {
+			codewords := dataCodewords(data, version, capacity)
+			return build(version, interleave(codewords, levelM[version-1])), nil
+		}
+	}
+	return nil, fmt.Errorf("too much data for a QR code: %d bytes (maximum %d)", len(data), MaxBytes)
+}
+
+// dataCapacity returns the number of data codewords of a version
+func dataCapacity(version int) int {
+	n := 0
+	for _, b := range levelM[version-1].blocks {
+		n += b
+	}
+	return n
+}
+
+// countBits returns the width of the byte-mode length field
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// bitWriter accumulates bits, most significant first
+type bitWriter struct {
+	bytes []byte
+	n     int // bits written
+}
+
+func (w *bitWriter) write(v, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		if w.n%8 == 0 {
+			w.bytes = append(w.bytes, 0)
+		}
+		if v>>i&1 == 1 {
+			w.bytes[len(w.bytes)-1] |= 0x80 >> (w.n % 8)
+		}
+		w.n++
+	}
+}
+
+// dataCodewords lays out the mode, length, and data, then pads them to the
+// version's capacity
+func dataCodewords(data []byte, version, capacity int) []byte {
+	var w bitWriter
+	w.write(0b0100, 4) // byte mode
+	w.write(len(data), countBits(version))
+	for _, b := range data {
+		w.write(int(b), 8)
+	}
+	w.write(0, min(4, capacity*8-w.n)) // terminator
+	if w.n%8 != 0 {
+		w.write(0, 8-w.n%8)
+	}
+	for pad := 0; len(w.bytes) < capacity; pad++ {
+		w.write([]int{0xEC, 0x11}[pad%2], 8)
+	}
+	return w.bytes
+}
+
+// interleave splits the data codewords into blocks, appends each block's
+// error correction, and interleaves them in the order they are placed
+func interleave(data []byte, layout blockLayout) []byte {
+	divisor := rsDivisor(layout.ecLen)
+	var blocks, ecc [][]byte
+	for _, n := range layout.blocks {
+		blocks = append(blocks, data[:n])
+		ecc = append(ecc, rsRemainder(data[:n], divisor))
+		data = data[n:]
+	}
+
+	var out []byte
+	for i := 0; i < layout.blocks[len(layout.blocks)-1]; i++ {
+		for _, b := range blocks {
+			if i < len(b) {
+				out = append(out, b[i])
+			}
+		}
+	}
+	for i := 0; i < layout.ecLen; i++ {
+		for _, e := range ecc {
+			out = append(out, e[i])
+		}
+	}
+	return out
+}
+
+// gfMul multiplies in GF(2^8) modulo the QR polynomial x^8+x^4+x^3+x^2+1
+func gfMul(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// rsDivisor returns the Reed-Solomon generator polynomial of the given
+// degree, highest coefficient first, without the leading 1
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the error correction codewords of data
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMul(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// symbol is a QR matrix under construction. Function modules (finders,
+// timing, alignment, format and version information) are never masked.
+type symbol struct {
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newSymbol(version int) *symbol {
+	size := version*4 + 17
+	s := &symbol{size: size}
+	s.modules = make([][]bool, size)
+	s.function = make([][]bool, size)
+	for y := range size {
+		s.modules[y] = make([]bool, size)
+		s.function[y] = make([]bool, size)
+	}
+	s.drawFunctionPatterns(version)
+	return s
+}
+
+func (s *symbol) setFunction(x, y int, dark bool) {
+	s.modules[y][x] = dark
+	s.function[y][x] = true
+}
+
+func (s *symbol) drawFunctionPatterns(version int) {
+	for i := range s.size {
+		s.setFunction(6, i, i%2 == 0)
+		s.setFunction(i, 6, i%2 == 0)
+	}
+
+	for _, c := range [][2]int{{3, 3}, {s.size - 4, 3}, {3, s.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := c[0]+dx, c[1]+dy
+				if x < 0 || y < 0 || x >= s.size || y >= s.size {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				s.setFunction(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	centers := alignmentCenters[version-1]
+	last := len(centers) - 1
+	for i, cx := range centers {
+		for j, cy := range centers {
+			// Skip the three corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					s.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn once the mask is
+	// chosen
+	s.drawFormat(0)
+
+	if version >= 7 {
+		rem := version
+		for range 12 {
+			rem = rem<<1 ^ (rem>>11)*0x1F25
+		}
+		bits := version<<12 | rem
+		for i := range 18 {
+			dark := bits>>i&1 == 1
+			a, b := s.size-11+i%3, i/3
+			s.setFunction(a, b, dark)
+			s.setFunction(b, a, dark)
+		}
+	}
+}
+
+// drawFormat draws both copies of the format information for level M and
+// the given mask, plus the dark module
+func (s *symbol) drawFormat(mask int) {
+	data := 0b00<<3 | mask // level M
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		s.setFunction(8, i, bit(i))
+	}
+	s.setFunction(8, 7, bit(6))
+	s.setFunction(8, 8, bit(7))
+	s.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		s.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		s.setFunction(s.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		s.setFunction(8, s.size-15+i, bit(i))
+	}
+	s.setFunction(8, s.size-8, true)
+}
+
+// drawCodewords places the codewords in the two-module-wide zigzag from the
+// bottom right corner, skipping function modules. Leftover modules stay
+// light, which are the remainder bits.
+func (s *symbol) drawCodewords(codewords []byte) {
+	i := 0
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := range s.size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if upward {
+					y = s.size - 1 - vert
+				}
+				if s.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				s.modules[y][x] = codewords[i/8]>>(7-i%8)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// masked reports whether mask pattern m inverts the module at x, y
+func masked(m, x, y int) bool {
+	switch m {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (s *symbol) applyMask(m int) {
+	for y := range s.size {
+		for x := range s.size {
+			if !s.function[y][x] && masked(m, x, y) {
+				s.modules[y][x] = !s.modules[y][x]
+			}
+		}
+	}
+}
+
+func (s *symbol) clone() *symbol {
+	c := &symbol{size: s.size}
+	for y := range s.size {
+		c.modules = append(c.modules, append([]bool(nil), s.modules[y]...))
+		c.function = append(c.function, append([]bool(nil), s.function[y]...))
+	}
+	return c
+}
+
+// build places the codewords and applies the mask with the lowest penalty
+func build(version int, codewords []byte) *Code {
+	base := newSymbol(version)
+	base.drawCodewords(codewords)
+
+	var best *symbol
+	bestPenalty := 0
+	for m := range 8 {
+		s := base.clone()
+		s.applyMask(m)
+		s.drawFormat(m)
+		if p := s.penalty(); best == nil || p < bestPenalty {
+			best, bestPenalty = s, p
+		}
+	}
+	return &Code{Size: best.size, modules: best.modules}
+}
+
+// penalty scores how hard the symbol is to scan: long runs, 2x2 blocks,
+// finder-like patterns, and an unbalanced dark/light ratio
+func (s *symbol) penalty() int {
+	p := 0
+	for i := range s.size {
+		p += linePenalty(s.size, func(j int) bool { return s.modules[i][j] })
+		p += linePenalty(s.size, func(j int) bool { return s.modules[j][i] })
+	}
+
+	dark := 0
+	for y := range s.size {
+		for x := range s.size {
+			if s.modules[y][x] {
+				dark++
+			}
+			if x+1 < s.size && y+1 < s.size {
+				c := s.modules[y][x]
+				if c == s.modules[y][x+1] && c == s.modules[y+1][x] && c == s.modules[y+1][x+1] {
+					p += 3
+				}
+			}
+		}
+	}
+	total := s.size * s.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return p + k*10
+}
+
+// finderLike is the 1:1:3:1:1 dark-light pattern of a finder's center line
+var finderLike = []bool{true, false, true, true, true, false, true}
+
+// linePenalty scores one row or column of n modules
+func linePenalty(n int, dark func(int) bool) int {
+	p := 0
+	run := 1
+	for j := 1; j <= n; j++ {
+		if j < n && dark(j) == dark(j-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			p += 3 + run - 5
+		}
+		run = 1
+	}
+
+	light := func(from, to int) bool {
+		for j := from; j < to; j++ {
+			if j >= 0 && j < n && dark(j) {
+				return false
+			}
+		}
+		return true
+	}
+	for j := 0; j+len(finderLike) <= n; j++ {
+		match := true
+		for k, want := range finderLike {
+			if dark(j+k) != want {
+				match = false
+				break
+			}
+		}
+		if match && (light(j-4, j) || light(j+7, j+11)) {
+			p += 40
+		}
+	}
+	return p
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Terminal renders the code with Unicode half blocks, two module rows per
+// line. Light modules are drawn as blocks, so the code scans as printed
+// light-on-dark, the usual terminal colors.
+func (c *Code) Terminal() string {
+	var b strings.Builder
+	for y := -QuietZone; y < c.Size+QuietZone; y += 2 {
+		for x := -QuietZone; x < c.Size+QuietZone; x++ {
+			top, bottom := !c.Dark(x, y), !c.Dark(x, y+1)
+			if y+1 >= c.Size+QuietZone {
+				bottom = false
+			}
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Image renders the code in black on white, scale pixels per module
+func (c *Code) Image(scale int) image.Image {
+	side := (c.Size + 2*QuietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for py := range side {
+		for px := range side {
+			v := color.Gray{Y: 255}
+			if c.Dark(px/scale-QuietZone, py/scale-QuietZone) {
+				v = color.Gray{Y: 0}
+			}
+			img.SetGray(px, py, v)
+		}
+	}
+	return img
+}
@@ -0,0 +1,256 @@
+package qr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want []byte
+	}{
+		{
+			"numeric 01234567",
+			[]byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			[]byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55},
+		},
+		{
+			"alphanumeric HELLO WORLD",
+			[]byte{0x20, 0x5B, 0x0B, 0x78, 0xD1, 0x72, 0xDC, 0x4D, 0x43, 0x40, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			[]byte{0xC4, 0x23, 0x27, 0x77, 0xEB, 0xD7, 0xE7, 0xE2, 0x5D, 0x17},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := rsRemainder(tt.data, rsDivisor(10)); !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: rsRemainder = % X, want % X", tt.name, got, tt.want)
+		}
+	}
+}
+
+// readBack decodes an error-free symbol: it checks the format bits,
+// unmasks the data modules, de-interleaves the blocks, checks each block's
+// Reed-Solomon syndromes, and returns the byte-mode payload
+func readBack(t *testing.T, c *Code) []byte {
+	t.Helper()
+	version := (c.Size - 17) / 4
+
+	format := 0
+	for i := 0; i <= 5; i++ {
+		format |= b2i(c.Dark(8, i)) << i
+	}
+	format |= b2i(c.Dark(8, 7)) << 6
+	format |= b2i(c.Dark(8, 8)) << 7
+	format |= b2i(c.Dark(7, 8)) << 8
+	for i := 9; i < 15; i++ {
+		format |= b2i(c.Dark(14-i, 8)) << i
+	}
+	format ^= 0x5412
+	rem := format
+	for i := 14; i >= 10; i-- {
+		if rem>>i&1 == 1 {
+			rem ^= 0x537 << (i - 10)
+		}
+	}
+	if rem != 0 {
+		t.Fatalf("format bits %015b fail the BCH check", format)
+	}
+	if level := format >> 13; level != 0b00 {
+		t.Fatalf("error correction level bits = %02b, want 00 (M)", level)
+	}
+	mask := format >> 10 & 7
+
+	function := newSymbol(version).function
+	var bits []bool
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.Size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if upward {
+					y = c.Size - 1 - vert
+				}
+				if !function[y][x] {
+					bits = append(bits, c.Dark(x, y) != masked(mask, x, y))
+				}
+			}
+		}
+	}
+	var codewords []byte
+	for i := 0; i+8 <= len(bits); i += 8 {
+		var b byte
+		for _, bit := range bits[i : i+8] {
+			b = b<<1 | byte(b2i(bit))
+		}
+		codewords = append(codewords, b)
+	}
+
+	layout := levelM[version-1]
+	blocks := make([][]byte, len(layout.blocks))
+	pos := 0
+	for i := 0; i < layout.blocks[len(layout.blocks)-1]; i++ {
+		for k, n := range layout.blocks {
+			if i < n {
+				blocks[k] = append(blocks[k], codewords[pos])
+				pos++
+			}
+		}
+	}
+	var data []byte
+	for k := range blocks {
+		data = append(data, blocks[k]...)
+	}
+	for range layout.ecLen {
+		for k := range blocks {
+			blocks[k] = append(blocks[k], codewords[pos])
+			pos++
+		}
+	}
+	for k, block := range blocks {
+		root := byte(1)
+		for i := range layout.ecLen {
+			var s byte
+			for _, b := range block {
+				s = gfMul(s, root) ^ b
+			}
+			if s != 0 {
+				t.Fatalf("block %d: syndrome %d = %#x, want 0", k, i, s)
+			}
+			root = gfMul(root, 0x02)
+		}
+	}
+
+	if mode := data[0] >> 4; mode != 0b0100 {
+		t.Fatalf("mode = %04b, want 0100 (byte)", mode)
+	}
+	var stream bitWriter
+	for _, b := range data {
+		stream.write(int(b), 8)
+	}
+	read := func(from, n int) int {
+		v := 0
+		for i := from; i < from+n; i++ {
+			v = v<<1 | int(stream.bytes[i/8]>>(7-i%8)&1)
+		}
+		return v
+	}
+	length := read(4, countBits(version))
+	out := make([]byte, length)
+	for i := range out {
+		out[i] = byte(read(4+countBits(version)+8*i, 8))
+	}
+	return out
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		data    string
+		version int
+	}{
+		{"hello", 1},
+		{"WIFI:T:WPA;S:Home;P:correct horse;;", 3},
+		{strings.Repeat("x", 100), 6},
+		{strings.Repeat("y", 140), 8},
+		{strings.Repeat("z", MaxBytes), 10},
+	}
+
+	for _, tt := range tests {
+		code, err := Encode([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("Encode(%d bytes): %v", len(tt.data), err)
+		}
+		if want := tt.version*4 + 17; code.Size != want {
+			t.Errorf("Encode(%d bytes): size = %d, want %d (version %d)", len(tt.data), code.Size, want, tt.version)
+		}
+		if got := readBack(t, code); string(got) != tt.data {
+			t.Errorf("read back %q, want %q", got, tt.data)
+		}
+	}
+}
+
+func TestEncodeFunctionPatterns(t *testing.T) {
+	code, err := Encode([]byte(strings.Repeat("v", 150)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Finder centers are dark and ringed by light then dark modules
+	for _, c := range [][2]int{{3, 3}, {code.Size - 4, 3}, {3, code.Size - 4}} {
+		if !code.Dark(c[0], c[1]) || code.Dark(c[0]+2, c[1]) || !code.Dark(c[0]+3, c[1]) {
+			t.Errorf("finder at %v is malformed", c)
+		}
+	}
+	for i := 8; i < code.Size-8; i++ {
+		if code.Dark(6, i) != (i%2 == 0) || code.Dark(i, 6) != (i%2 == 0) {
+			t.Fatalf("timing pattern broken at %d", i)
+		}
+	}
+	if !code.Dark(8, code.Size-8) {
+		t.Error("dark module missing")
+	}
+
+	// Version 7 and up carry the version number in two 6x3 blocks
+	version := (code.Size - 17) / 4
+	got := 0
+	for i := range 18 {
+		got |= b2i(code.Dark(code.Size-11+i%3, i/3)) << i
+	}
+	if got>>12 != version {
+		t.Errorf("version info = %d, want %d", got>>12, version)
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(make([]byte, MaxBytes+1)); err == nil {
+		t.Error("expected an error for data over the capacity")
+	}
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	side := code.Size + 2*QuietZone
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(), "\n"), "\n")
+	if len(lines) != (side+1)/2 {
+		t.Errorf("terminal lines = %d, want %d", len(lines), (side+1)/2)
+	}
+	for _, line := range lines {
+		if n := len([]rune(line)); n != side {
+			t.Fatalf("terminal line width = %d, want %d", n, side)
+		}
+	}
+	if !strings.HasPrefix(lines[0], strings.Repeat("█", side)) {
+		t.Error("first terminal line should be all quiet zone")
+	}
+
+	img := code.Image(3)
+	if b := img.Bounds(); b.Dx() != side*3 || b.Dy() != side*3 {
+		t.Errorf("image bounds = %v, want %dx%d", b, side*3, side*3)
+	}
+	dark := func(x, y int) bool {
+		r, _, _, _ := img.At(x, y).RGBA()
+		return r == 0
+	}
+	if dark(0, 0) {
+		t.Error("quiet zone should be white")
+	}
+	if px := QuietZone * 3; !dark(px, px) || !dark(px+2, px+2) {
+		t.Error("top left finder corner should be black")
+	}
+}